@@ -561,17 +561,17 @@ func MakeRewardAddresses(wlt *wallet.Wallet, valAddrsInfo []vault.AddressInfo,
 		// Try to use the first Ed25519 address from the wallet as the reward address.
 		firstEd25519AddrPath := addresspath.NewPath(
 			vault.PurposeBIP44Hardened,
-			wlt.CoinType()+addresspath.HardenedKeyStart,
-			uint32(crypto.AddressTypeEd25519Account)+addresspath.HardenedKeyStart,
-			uint32(0)+addresspath.HardenedKeyStart)
+			addresspath.Hardened(wlt.CoinType()),
+			addresspath.Hardened(uint32(crypto.AddressTypeEd25519Account)),
+			addresspath.Hardened(0))
 
 		addrInfo := wlt.AddressFromPath(firstEd25519AddrPath.String())
 		if addrInfo == nil {
 			// If no Ed25519 address is found, try the first BLS address instead.
 			firstBLSAddrPath := addresspath.NewPath(
 				vault.PurposeBLS12381Hardened,
-				wlt.CoinType()+addresspath.HardenedKeyStart,
-				uint32(crypto.AddressTypeBLSAccount)+addresspath.HardenedKeyStart,
+				addresspath.Hardened(wlt.CoinType()),
+				addresspath.Hardened(uint32(crypto.AddressTypeBLSAccount)),
 				uint32(0))
 
 			addrInfo = wlt.AddressFromPath(firstBLSAddrPath.String())
@@ -6,8 +6,11 @@ import (
 
 // set Ciphersuite for Basic mode
 // https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-04#section-4.2.1
+// and the proof of possession tag for the Proof of Possession mode
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-04#section-4.2.3
 var (
 	dst     = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
+	dstPOP  = []byte("BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_")
 	gen2Aff bls12381.G2Affine
 	gen2Jac bls12381.G2Jac
 )
@@ -198,3 +198,20 @@ func TestHashToCurve(t *testing.T) {
 			"test %v: not match", no)
 	}
 }
+
+func TestProofOfPossession(t *testing.T) {
+	ts := testsuite.NewTestSuite(t)
+
+	pub1, prv1 := ts.RandBLSKeyPair()
+	pub2, _ := ts.RandBLSKeyPair()
+
+	pop := prv1.ProofOfPossession()
+	assert.NoError(t, pub1.VerifyProofOfPossession(pop))
+	assert.ErrorIs(t, pub2.VerifyProofOfPossession(pop), crypto.ErrInvalidSignature)
+	assert.ErrorIs(t, pub1.VerifyProofOfPossession(nil), crypto.ErrInvalidPublicKey)
+
+	// The proof of possession is not a valid signature over the public key,
+	// since they use different domain separation tags.
+	assert.ErrorIs(t, pub1.Verify(pub1.Bytes(), pop), crypto.ErrInvalidSignature)
+	assert.ErrorIs(t, pub1.VerifyProofOfPossession(prv1.SignNative(pub1.Bytes())), crypto.ErrInvalidSignature)
+}
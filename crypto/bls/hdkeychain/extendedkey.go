@@ -100,14 +100,18 @@ func (k *ExtendedKey) IsPrivate() bool {
 
 // DerivePath returns a derived child extended key from this master key at the
 // given path.
+// The intermediate keys are cleared after deriving their child.
 func (k *ExtendedKey) DerivePath(path []uint32) (*ExtendedKey, error) {
 	ext := k
-	var err error
 	for _, index := range path {
-		ext, err = ext.Derive(index)
+		child, err := ext.Derive(index)
+		if ext != k {
+			ext.Clear()
+		}
 		if err != nil {
 			return nil, err
 		}
+		ext = child
 	}
 
 	return ext, nil
@@ -343,6 +347,14 @@ func (k *ExtendedKey) Neuter() *ExtendedKey {
 		k.path, false, k.pubOnG1)
 }
 
+// Clear zeroes the key and the chain code in memory.
+// The extended key, and the public keys neutered from it, should not be used
+// after calling Clear.
+func (k *ExtendedKey) Clear() {
+	clear(k.key)
+	clear(k.chainCode)
+}
+
 // String returns the extended key as a bech32-encoded string.
 func (k *ExtendedKey) String() string {
 	//
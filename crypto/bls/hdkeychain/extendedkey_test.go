@@ -509,3 +509,26 @@ func TestNeuter(t *testing.T) {
 		neuterKey.String())
 	assert.Equal(t, neuterKey, neuterKey.Neuter())
 }
+
+func TestClear(t *testing.T) {
+	ts := testsuite.NewTestSuite(t)
+
+	masterKey, _ := NewMaster(ts.RandBytes(32), false)
+	masterStr := masterKey.String()
+	path := []uint32{hardenedKeyStart + 12381, hardenedKeyStart + 21888, hardenedKeyStart + 2, 0}
+
+	extKey, err := masterKey.DerivePath(path)
+	require.NoError(t, err)
+	extStr := extKey.String()
+
+	// The parent key is not cleared by deriving the child.
+	assert.Equal(t, masterStr, masterKey.String())
+	extKey2, err := masterKey.DerivePath(path)
+	require.NoError(t, err)
+	assert.Equal(t, extStr, extKey2.String())
+
+	extKey.Clear()
+	prv, _ := extKey.RawPrivateKey()
+	assert.Equal(t, make([]byte, len(prv)), prv)
+	assert.Equal(t, make([]byte, len(extKey.chainCode)), extKey.chainCode)
+}
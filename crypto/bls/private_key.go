@@ -124,6 +124,17 @@ func (prv *PrivateKey) Sign(msg []byte) crypto.Signature {
 }
 
 func (prv *PrivateKey) SignNative(msg []byte) *Signature {
+	return prv.sign(msg, dst)
+}
+
+// ProofOfPossession generates a proof that the signer holds the private key.
+// It is the signature over the public key, using the proof of possession tag.
+// It's defined in section 3.3.2 of the spec: PopProve.
+func (prv *PrivateKey) ProofOfPossession() *Signature {
+	return prv.sign(prv.PublicKeyNative().Bytes(), dstPOP)
+}
+
+func (prv *PrivateKey) sign(msg, dst []byte) *Signature {
 	qAffine, err := bls12381.HashToG1(msg, dst)
 	if err != nil {
 		panic(err)
@@ -162,3 +173,10 @@ func (prv *PrivateKey) EqualsTo(x crypto.PrivateKey) bool {
 
 	return prv.scalar.Cmp(xBLS.scalar) == 0
 }
+
+// Clear zeroes the private key in memory.
+// The private key should not be used after calling Clear.
+func (prv *PrivateKey) Clear() {
+	clear(prv.scalar.Bits())
+	prv.scalar.SetInt64(0)
+}
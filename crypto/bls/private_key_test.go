@@ -157,3 +157,11 @@ func TestKeyGen(t *testing.T) {
 		}
 	}
 }
+
+func TestPrivateKeyClear(t *testing.T) {
+	ts := testsuite.NewTestSuite(t)
+
+	_, prv := ts.RandBLSKeyPair()
+	prv.Clear()
+	assert.Equal(t, make([]byte, bls.PrivateKeySize), prv.Bytes())
+}
@@ -104,6 +104,20 @@ func (pub *PublicKey) Decode(r io.Reader) error {
 // Verify checks that a signature is valid for the given message and public key.
 // It's defined in section 2.6 of the spec: CoreVerify.
 func (pub *PublicKey) Verify(msg []byte, sig crypto.Signature) error {
+	return pub.verify(msg, sig, dst)
+}
+
+// VerifyProofOfPossession checks that the proof of possession is valid for the public key.
+// It's defined in section 3.3.3 of the spec: PopVerify.
+func (pub *PublicKey) VerifyProofOfPossession(pop *Signature) error {
+	if pop == nil {
+		return crypto.ErrInvalidPublicKey
+	}
+
+	return pub.verify(pub.Bytes(), pop, dstPOP)
+}
+
+func (pub *PublicKey) verify(msg []byte, sig crypto.Signature, dst []byte) error {
 	if sig == nil {
 		return crypto.ErrInvalidPublicKey
 	}
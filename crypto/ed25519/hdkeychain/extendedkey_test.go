@@ -312,3 +312,25 @@ func TestInvalidString(t *testing.T) {
 		assert.ErrorIs(t, err, tt.expectedError, "test %d error is not matched", no)
 	}
 }
+
+func TestClear(t *testing.T) {
+	ts := testsuite.NewTestSuite(t)
+
+	masterKey, _ := NewMaster(ts.RandBytes(32))
+	masterStr := masterKey.String()
+	path := []uint32{hardenedKeyStart + 44, hardenedKeyStart + 21888, hardenedKeyStart + 3}
+
+	extKey, err := masterKey.DerivePath(path)
+	require.NoError(t, err)
+	extStr := extKey.String()
+
+	// The parent key is not cleared by deriving the child.
+	assert.Equal(t, masterStr, masterKey.String())
+	extKey2, err := masterKey.DerivePath(path)
+	require.NoError(t, err)
+	assert.Equal(t, extStr, extKey2.String())
+
+	extKey.Clear()
+	assert.Equal(t, make([]byte, len(extKey.key)), extKey.RawPrivateKey())
+	assert.Equal(t, make([]byte, len(extKey.chainCode)), extKey.chainCode)
+}
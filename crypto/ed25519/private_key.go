@@ -97,3 +97,9 @@ func (prv *PrivateKey) EqualsTo(x crypto.PrivateKey) bool {
 
 	return prv.inner.Equal(xEd25519.inner)
 }
+
+// Clear zeroes the private key in memory.
+// The private key should not be used after calling Clear.
+func (prv *PrivateKey) Clear() {
+	clear(prv.inner)
+}
@@ -75,3 +75,11 @@ func TestPrivateKeyFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestPrivateKeyClear(t *testing.T) {
+	ts := testsuite.NewTestSuite(t)
+
+	_, prv := ts.RandEd25519KeyPair()
+	prv.Clear()
+	assert.Equal(t, make([]byte, ed25519.PrivateKeySize), prv.Bytes())
+}
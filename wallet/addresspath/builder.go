@@ -0,0 +1,101 @@
+package addresspath
+
+import "fmt"
+
+// Builder builds an address path in the form of
+// `m/purpose'/coin_type'/address_type'/address_index`.
+// The indexes are given without hardening and the builder hardens them based
+// on the rules of the purpose.
+type Builder struct {
+	purpose      uint32
+	coinType     uint32
+	addressType  uint32
+	addressIndex uint32
+}
+
+// NewBuilder creates a new address path builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Purpose sets the purpose of the path, e.g. 12381 for BLS.
+func (b *Builder) Purpose(purpose uint32) *Builder {
+	b.purpose = purpose
+
+	return b
+}
+
+// CoinType sets the coin type of the path, e.g. 21888 for Mainnet.
+func (b *Builder) CoinType(coinType uint32) *Builder {
+	b.coinType = coinType
+
+	return b
+}
+
+// AddressType sets the address type of the path.
+func (b *Builder) AddressType(addressType uint32) *Builder {
+	b.addressType = addressType
+
+	return b
+}
+
+// AddressIndex sets the address index of the path.
+func (b *Builder) AddressIndex(addressIndex uint32) *Builder {
+	b.addressIndex = addressIndex
+
+	return b
+}
+
+// Build returns the address path.
+// It returns an error if the purpose is not known or an index is out of range.
+func (b *Builder) Build() (Path, error) {
+	hardenedIndex, ok := purposeHardenedIndex[b.purpose]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown purpose %d", ErrInvalidPath, b.purpose)
+	}
+
+	for _, index := range []uint32{b.purpose, b.coinType, b.addressType, b.addressIndex} {
+		if IsHardened(index) {
+			return nil, fmt.Errorf("%w: index %d is out of range", ErrInvalidPath, index)
+		}
+	}
+
+	addressIndex := b.addressIndex
+	if hardenedIndex {
+		addressIndex = Hardened(addressIndex)
+	}
+
+	return NewPath(
+		Hardened(b.purpose),
+		Hardened(b.coinType),
+		Hardened(b.addressType),
+		addressIndex), nil
+}
+
+// String returns the canonical string of the address path.
+// It returns an empty string if the path is not valid.
+func (b *Builder) String() string {
+	path, err := b.Build()
+	if err != nil {
+		return ""
+	}
+
+	return path.String()
+}
+
+// NewAddressPath returns the address path for the given purpose, coin type,
+// address type and address index, hardened based on the rules of the purpose.
+// It panics if the purpose is not known or an index is out of range.
+func NewAddressPath(purpose, coinType, addressType, addressIndex uint32) Path {
+	path, err := NewBuilder().
+		Purpose(purpose).
+		CoinType(coinType).
+		AddressType(addressType).
+		AddressIndex(addressIndex).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	return path
+}
@@ -0,0 +1,66 @@
+package addresspath
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		purpose      uint32
+		coinType     uint32
+		addressType  uint32
+		addressIndex uint32
+		wantStr      string
+	}{
+		{12381, 21888, 1, 0, "m/12381'/21888'/1'/0"},
+		{12381, 21888, 2, 5, "m/12381'/21888'/2'/5"},
+		{12381, 21777, 2, 0, "m/12381'/21777'/2'/0"},
+		{44, 21888, 3, 0, "m/44'/21888'/3'/0'"},
+		{44, 21777, 3, 7, "m/44'/21777'/3'/7'"},
+		{65535, 21888, 1, 0, "m/65535'/21888'/1'/0'"},
+		{65534, 21888, 2, 1, "m/65534'/21888'/2'/1'"},
+	}
+	for no, tt := range tests {
+		builder := NewBuilder().
+			Purpose(tt.purpose).
+			CoinType(tt.coinType).
+			AddressType(tt.addressType).
+			AddressIndex(tt.addressIndex)
+
+		path, err := builder.Build()
+		require.NoError(t, err, "case %d failed", no)
+		assert.NoError(t, path.Validate(), "case %d failed", no)
+		assert.Equal(t, tt.wantStr, path.String(), "case %d failed", no)
+		assert.Equal(t, tt.wantStr, builder.String(), "case %d failed", no)
+		assert.Equal(t, path, NewAddressPath(tt.purpose, tt.coinType, tt.addressType, tt.addressIndex))
+	}
+}
+
+func TestBuilderMatchesFormattedPaths(t *testing.T) {
+	for index := uint32(0); index < 3; index++ {
+		assert.Equal(t, fmt.Sprintf("m/%d'/%d'/%d'/%d", 12381, 21888, 2, index),
+			NewAddressPath(12381, 21888, 2, index).String())
+		assert.Equal(t, fmt.Sprintf("m/%d'/%d'/%d'/%d'", 44, 21888, 3, index),
+			NewAddressPath(44, 21888, 3, index).String())
+	}
+}
+
+func TestBuilderInvalid(t *testing.T) {
+	_, err := NewBuilder().Purpose(1).CoinType(21888).Build()
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = NewBuilder().Purpose(44).CoinType(HardenedKeyStart).Build()
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = NewBuilder().Purpose(12381).CoinType(21888).AddressIndex(HardenedKeyStart).Build()
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	assert.Empty(t, NewBuilder().Purpose(1).String())
+	assert.Panics(t, func() {
+		NewAddressPath(1, 21888, 2, 0)
+	})
+}
@@ -10,6 +10,37 @@ const HardenedKeyStart = uint32(0x80000000) // 2^31
 
 type Path []uint32
 
+// purposeHardenedIndex defines whether the address index is hardened for each
+// known purpose. BLS keys are derived non-hardened, so the addresses can be
+// derived from the extended public key.
+var purposeHardenedIndex = map[uint32]bool{
+	12381: false, // BLS12-381 (PIP-8)
+	44:    true,  // BIP-44, Ed25519 (PIP-13)
+	65534: true,  // Watch-only addresses
+	65535: true,  // Imported private keys
+}
+
+// Hardened returns the hardened segment of the index.
+// This function does not check if the index is already hardened.
+func Hardened(index uint32) uint32 {
+	return index + HardenedKeyStart
+}
+
+// IsHardened checks if the segment is hardened.
+func IsHardened(segment uint32) bool {
+	return segment >= HardenedKeyStart
+}
+
+// Unharden returns the index of the segment and whether the segment is hardened.
+// Non-hardened segments are returned unchanged.
+func Unharden(segment uint32) (uint32, bool) {
+	if !IsHardened(segment) {
+		return segment, false
+	}
+
+	return segment - HardenedKeyStart, true
+}
+
 func NewPath(indexes ...uint32) Path {
 	p := make([]uint32, 0, len(indexes))
 	p = append(p, indexes...)
@@ -28,16 +59,24 @@ func FromString(str string) (Path, error) {
 	var path []uint32
 	for i := 1; i < len(sub); i++ {
 		indexStr := sub[i]
-		added := uint32(0)
-		if indexStr[len(indexStr)-1] == '\'' {
-			added = HardenedKeyStart
+		hardened := false
+		if strings.HasSuffix(indexStr, "'") {
+			hardened = true
 			indexStr = indexStr[:len(indexStr)-1]
 		}
-		val, err := strconv.ParseInt(indexStr, 10, 32)
+		// Leading zeros are rejected, so the path has only one string representation.
+		if len(indexStr) > 1 && indexStr[0] == '0' {
+			return nil, ErrInvalidPath
+		}
+		val, err := strconv.ParseUint(indexStr, 10, 31)
 		if err != nil {
 			return nil, err
 		}
-		path = append(path, uint32(val)+added)
+		index := uint32(val)
+		if hardened {
+			index = Hardened(index)
+		}
+		path = append(path, index)
 	}
 
 	return path, nil
@@ -47,8 +86,8 @@ func (p Path) String() string {
 	var builder strings.Builder
 	builder.WriteString("m")
 	for _, i := range p {
-		if i >= HardenedKeyStart {
-			builder.WriteString(fmt.Sprintf("/%d'", i-HardenedKeyStart))
+		if index, hardened := Unharden(i); hardened {
+			builder.WriteString(fmt.Sprintf("/%d'", index))
 		} else {
 			builder.WriteString(fmt.Sprintf("/%d", i))
 		}
@@ -57,6 +96,45 @@ func (p Path) String() string {
 	return builder.String()
 }
 
+// Validate checks that the path is a valid address path:
+// it should have four levels, the purpose, coin type and address type should be
+// hardened, and the hardening of the address index should match the purpose.
+func (p Path) Validate() error {
+	if len(p) != 4 {
+		return fmt.Errorf("%w: expected 4 levels, got %d", ErrInvalidPath, len(p))
+	}
+
+	if !IsHardened(p.Purpose()) {
+		return fmt.Errorf("%w: purpose %d is not hardened", ErrInvalidPath, p.Purpose())
+	}
+
+	if !IsHardened(p.CoinType()) {
+		return fmt.Errorf("%w: coin type %d is not hardened", ErrInvalidPath, p.CoinType())
+	}
+
+	if !IsHardened(p.AddressType()) {
+		return fmt.Errorf("%w: address type %d is not hardened", ErrInvalidPath, p.AddressType())
+	}
+
+	purpose, _ := Unharden(p.Purpose())
+	hardenedIndex, ok := purposeHardenedIndex[purpose]
+	if !ok {
+		return fmt.Errorf("%w: unknown purpose %d", ErrInvalidPath, purpose)
+	}
+
+	if hardenedIndex != IsHardened(p.AddressIndex()) {
+		if hardenedIndex {
+			return fmt.Errorf("%w: address index should be hardened for purpose %d",
+				ErrInvalidPath, purpose)
+		}
+
+		return fmt.Errorf("%w: address index should not be hardened for purpose %d",
+			ErrInvalidPath, purpose)
+	}
+
+	return nil
+}
+
 // TODO: we can add IsBLSPurpose or IsImportedPurpose functions
 
 func (p Path) Purpose() uint32 {
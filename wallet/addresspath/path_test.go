@@ -60,3 +60,115 @@ func TestPathHelpers(t *testing.T) {
 	assert.Equal(t, uint32(addressType), path.AddressType())
 	assert.Equal(t, uint32(addressIndex), path.AddressIndex())
 }
+
+func TestStringToPathStrict(t *testing.T) {
+	tests := []struct {
+		str     string
+		wantErr error
+	}{
+		{"m/", strconv.ErrSyntax},
+		{"m//1", strconv.ErrSyntax},
+		{"m/01", ErrInvalidPath},
+		{"m/00'", ErrInvalidPath},
+		{"m/+1", strconv.ErrSyntax},
+		{"m/-1", strconv.ErrSyntax},
+		{"m/2147483648", strconv.ErrRange},
+		{"m/2147483648'", strconv.ErrRange},
+	}
+	for no, tt := range tests {
+		_, err := FromString(tt.str)
+		assert.ErrorIsf(t, err, tt.wantErr, "case %d failed", no)
+	}
+
+	path, err := FromString("m/2147483647'")
+	assert.NoError(t, err)
+	assert.Equal(t, Path{0xFFFFFFFF}, path)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		str     string
+		wantErr bool
+	}{
+		{"m/12381'/21888'/1'/0", false},
+		{"m/12381'/21888'/2'/5", false},
+		{"m/44'/21888'/3'/0'", false},
+		{"m/65535'/21888'/1'/0'", false},
+		{"m/65534'/21888'/2'/3'", false},
+		{"m", true},
+		{"m/12381'/21888'/1'", true},
+		{"m/12381'/21888'/1'/0/0", true},
+		{"m/12381/21888'/1'/0", true},
+		{"m/12381'/21888/1'/0", true},
+		{"m/12381'/21888'/1/0", true},
+		{"m/12381'/21888'/1'/0'", true},
+		{"m/44'/21888'/3'/0", true},
+		{"m/65535'/21888'/1'/0", true},
+		{"m/1'/21888'/1'/0", true},
+	}
+	for no, tt := range tests {
+		path, err := FromString(tt.str)
+		assert.NoError(t, err)
+
+		err = path.Validate()
+		if tt.wantErr {
+			assert.ErrorIsf(t, err, ErrInvalidPath, "case %d failed", no)
+		} else {
+			assert.NoErrorf(t, err, "case %d failed", no)
+		}
+	}
+}
+
+func FuzzPathRoundTrip(f *testing.F) {
+	f.Add("m")
+	f.Add("m/12381'/21888'/1'/0")
+	f.Add("m/44'/21888'/3'/0'")
+	f.Add("m/0/1/1000000000")
+	f.Add("m/01")
+	f.Add("m//")
+
+	f.Fuzz(func(t *testing.T, str string) {
+		path, err := FromString(str)
+		if err != nil {
+			return
+		}
+
+		assert.Equal(t, str, path.String())
+	})
+}
+
+func TestHardenedHelpers(t *testing.T) {
+	h := HardenedKeyStart
+
+	assert.Equal(t, h, Hardened(0))
+	assert.Equal(t, h+44, Hardened(44))
+	assert.Equal(t, uint32(0xFFFFFFFF), Hardened(h-1))
+
+	assert.False(t, IsHardened(0))
+	assert.False(t, IsHardened(h-1))
+	assert.True(t, IsHardened(h))
+	assert.True(t, IsHardened(0xFFFFFFFF))
+
+	tests := []struct {
+		segment      uint32
+		wantIndex    uint32
+		wantHardened bool
+	}{
+		{0, 0, false},
+		{h - 1, h - 1, false},
+		{h, 0, true},
+		{h + 1, 1, true},
+		{0xFFFFFFFF, h - 1, true},
+	}
+	for no, tt := range tests {
+		index, hardened := Unharden(tt.segment)
+		assert.Equal(t, tt.wantIndex, index, "case %d failed", no)
+		assert.Equal(t, tt.wantHardened, hardened, "case %d failed", no)
+	}
+
+	for _, index := range []uint32{0, 1, h - 1} {
+		unhardened, hardened := Unharden(Hardened(index))
+		assert.True(t, hardened)
+		assert.Equal(t, index, unhardened)
+	}
+}
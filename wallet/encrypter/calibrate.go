@@ -0,0 +1,57 @@
+package encrypter
+
+import (
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// The floors are based on the OWASP recommendation for Argon2id.
+	// https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#argon2id
+	calibrateMinMemory     = 19456   // 19 MiB
+	calibrateMaxMemory     = 1048576 // 1 GiB
+	calibrateMinIterations = 2
+	calibrateMaxIterations = 16
+)
+
+// CalibrateArgon2 benchmarks the host and returns the Argon2id options that
+// take roughly the target time to derive the password hash.
+// The memory is increased first, then the number of iterations.
+// The returned values are never lower than the recommended floors,
+// so the actual time can be longer than the target on slow machines.
+//
+// The result is not deterministic. It depends on the host and its load at
+// the time of calling. Since the parameters are stored with the encrypted
+// data, unlocking the vault on a slower machine takes longer.
+func CalibrateArgon2(target time.Duration) []Option {
+	memory := uint32(calibrateMinMemory)
+	elapsed := measureArgon2(memory)
+	for elapsed*2*calibrateMinIterations <= target && memory*2 <= calibrateMaxMemory {
+		memory *= 2
+		elapsed = measureArgon2(memory)
+	}
+
+	iterations := int64(calibrateMaxIterations)
+	if elapsed > 0 {
+		iterations = int64(target / elapsed)
+	}
+	iterations = max(iterations, calibrateMinIterations)
+	iterations = min(iterations, calibrateMaxIterations)
+
+	return []Option{
+		OptionKDF(KDFArgon2ID),
+		OptionIteration(uint32(iterations)),
+		OptionMemory(memory),
+		OptionParallelism(defaultParallelism),
+	}
+}
+
+// measureArgon2 returns the time of one Argon2id iteration with the given memory.
+func measureArgon2(memory uint32) time.Duration {
+	salt := make([]byte, 16)
+	start := time.Now()
+	_ = argon2.IDKey([]byte("calibrate"), salt, 1, memory, defaultParallelism, defaultKeyLen)
+
+	return time.Since(start)
+}
@@ -0,0 +1,50 @@
+package encrypter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibrateArgon2(t *testing.T) {
+	tests := []time.Duration{
+		0,
+		time.Millisecond,
+		100 * time.Millisecond,
+	}
+
+	for _, target := range tests {
+		params := &parameters{}
+		for _, opt := range CalibrateArgon2(target) {
+			opt(params)
+		}
+
+		assert.Equal(t, KDFArgon2ID, params.kdf)
+		assert.Equal(t, uint8(defaultParallelism), params.parallelism)
+		assert.GreaterOrEqual(t, params.memory, uint32(calibrateMinMemory))
+		assert.LessOrEqual(t, params.memory, uint32(calibrateMaxMemory))
+		assert.GreaterOrEqual(t, params.iterations, uint32(calibrateMinIterations))
+		assert.LessOrEqual(t, params.iterations, uint32(calibrateMaxIterations))
+	}
+
+	t.Run("Floors for small target", func(t *testing.T) {
+		params := &parameters{}
+		for _, opt := range CalibrateArgon2(time.Millisecond) {
+			opt(params)
+		}
+
+		assert.Equal(t, uint32(calibrateMinMemory), params.memory)
+		assert.Equal(t, uint32(calibrateMinIterations), params.iterations)
+	})
+
+	t.Run("Usable with the encrypter", func(t *testing.T) {
+		enc := DefaultEncrypter(CalibrateArgon2(time.Millisecond)...)
+
+		cipher, err := enc.Encrypt("foo", "password")
+		assert.NoError(t, err)
+		msg, err := enc.Decrypt(cipher, "password")
+		assert.NoError(t, err)
+		assert.Equal(t, "foo", msg)
+	})
+}
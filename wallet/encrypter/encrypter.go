@@ -9,48 +9,107 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/pactus-project/pactus/util"
 	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF defines the key derivation function that is used to hash the password.
+type KDF int
+
+const (
+	// KDFArgon2ID uses Argon2id as password hasher.
+	KDFArgon2ID = KDF(0)
+	// KDFScrypt uses scrypt as password hasher.
+	KDFScrypt = KDF(1)
 )
 
 // Parameters are set based on the spec recommendation
 // Read more here https://datatracker.ietf.org/doc/html/rfc9106#section-4
-type argon2dParameters struct {
+// and https://datatracker.ietf.org/doc/html/rfc7914#section-2
+type parameters struct {
+	kdf         KDF
 	iterations  uint32
 	memory      uint32
 	parallelism uint8
+	scryptN     uint32
+	scryptR     uint32
+	scryptP     uint32
 	keyLen      uint32
+	minEntropy  float64 // Password policy, not stored in the parameters
 }
 
-type Option func(p *argon2dParameters)
+type Option func(p *parameters)
+
+// OptionKDF sets the key derivation function. The default is Argon2id.
+func OptionKDF(kdf KDF) Option {
+	return func(p *parameters) {
+		p.kdf = kdf
+	}
+}
 
-func OptionIteration(iterations uint32) func(p *argon2dParameters) {
-	return func(p *argon2dParameters) {
+func OptionIteration(iterations uint32) Option {
+	return func(p *parameters) {
 		p.iterations = iterations
 	}
 }
 
-func OptionMemory(memory uint32) func(p *argon2dParameters) {
-	return func(p *argon2dParameters) {
+func OptionMemory(memory uint32) Option {
+	return func(p *parameters) {
 		p.memory = memory
 	}
 }
 
-func OptionParallelism(parallelism uint8) func(p *argon2dParameters) {
-	return func(p *argon2dParameters) {
+func OptionParallelism(parallelism uint8) Option {
+	return func(p *parameters) {
 		p.parallelism = parallelism
 	}
 }
 
+// OptionScryptN sets the CPU/memory cost parameter of scrypt.
+// It should be a power of two greater than one.
+func OptionScryptN(n uint32) Option {
+	return func(p *parameters) {
+		p.scryptN = n
+	}
+}
+
+// OptionScryptR sets the block size parameter of scrypt.
+func OptionScryptR(r uint32) Option {
+	return func(p *parameters) {
+		p.scryptR = r
+	}
+}
+
+// OptionScryptP sets the parallelization parameter of scrypt.
+func OptionScryptP(p uint32) Option {
+	return func(params *parameters) {
+		params.scryptP = p
+	}
+}
+
+// OptionMinPasswordEntropy sets the minimum estimated entropy of the password in bits.
+// It is checked by CheckPasswordStrength and doesn't change the encryption parameters.
+func OptionMinPasswordEntropy(bits float64) Option {
+	return func(p *parameters) {
+		p.minEntropy = bits
+	}
+}
+
 const (
 	nameParamIterations  = "iterations"
 	nameParamMemory      = "memory"
 	nameParamParallelism = "parallelism"
+	nameParamScryptN     = "n"
+	nameParamScryptR     = "r"
+	nameParamScryptP     = "p"
 	nameParamKeyLen      = "keylen"
 
 	nameFuncNope      = ""
 	nameFuncArgon2ID  = "ARGON2ID"
+	nameFuncScrypt    = "SCRYPT"
 	nameFuncAES256CTR = "AES_256_CTR"
 	nameFuncAES256CBC = "AES_256_CBC"
 	nameFuncMACv1     = "MACV1"
@@ -61,6 +120,18 @@ const (
 	defaultMemory      = 65536 // 2 ^ 16
 	defaultParallelism = 4
 	defaultKeyLen      = 48
+
+	// saltLen is the length of the random salt that is stored at the beginning of the cipher.
+	saltLen = 16
+
+	// macLen is the length of the MAC that is stored at the end of the cipher.
+	macLen = 4
+
+	// Parameter Choice for scrypt
+	// https://www.rfc-editor.org/rfc/rfc7914.html#section-2
+	defaultScryptN = 32768 // 2 ^ 15
+	defaultScryptR = 8
+	defaultScryptP = 1
 )
 
 // Encrypter keeps the method and parameters for the cipher algorithm.
@@ -84,26 +155,42 @@ func NopeEncrypter() Encrypter {
 // If no option sets it uses the default parameters.
 //
 // The default encrypter uses Argon2ID as password hasher and AES_256_CTR as
-// encryption algorithm.
+// encryption algorithm. The password hasher can be changed to scrypt using
+// the OptionKDF option.
 func DefaultEncrypter(opts ...Option) Encrypter {
-	argon2dParameters := &argon2dParameters{
+	parameters := &parameters{
+		kdf:         KDFArgon2ID,
 		iterations:  defaultIterations,
 		memory:      defaultMemory,
 		parallelism: defaultParallelism,
+		scryptN:     defaultScryptN,
+		scryptR:     defaultScryptR,
+		scryptP:     defaultScryptP,
 		keyLen:      defaultKeyLen,
 	}
 	for _, opt := range opts {
-		opt(argon2dParameters)
+		opt(parameters)
 	}
 
-	method := fmt.Sprintf("%s-%s-%s",
-		nameFuncArgon2ID, nameFuncAES256CTR, nameFuncMACv1)
-
 	encParams := newParams()
-	encParams.SetUint32(nameParamIterations, argon2dParameters.iterations)
-	encParams.SetUint32(nameParamMemory, argon2dParameters.memory)
-	encParams.SetUint8(nameParamParallelism, argon2dParameters.parallelism)
-	encParams.SetUint32(nameParamKeyLen, argon2dParameters.keyLen)
+	var hasherFunc string
+	switch parameters.kdf {
+	case KDFScrypt:
+		hasherFunc = nameFuncScrypt
+		encParams.SetUint32(nameParamScryptN, parameters.scryptN)
+		encParams.SetUint32(nameParamScryptR, parameters.scryptR)
+		encParams.SetUint32(nameParamScryptP, parameters.scryptP)
+
+	default:
+		hasherFunc = nameFuncArgon2ID
+		encParams.SetUint32(nameParamIterations, parameters.iterations)
+		encParams.SetUint32(nameParamMemory, parameters.memory)
+		encParams.SetUint8(nameParamParallelism, parameters.parallelism)
+	}
+	encParams.SetUint32(nameParamKeyLen, parameters.keyLen)
+
+	method := fmt.Sprintf("%s-%s-%s",
+		hasherFunc, nameFuncAES256CTR, nameFuncMACv1)
 
 	return Encrypter{
 		Method: method,
@@ -115,6 +202,102 @@ func (e *Encrypter) IsEncrypted() bool {
 	return e.Method != nameFuncNope
 }
 
+// Clone returns a copy of the encrypter that doesn't share the parameters.
+func (e *Encrypter) Clone() Encrypter {
+	cloned := Encrypter{
+		Method: e.Method,
+	}
+	if e.Params != nil {
+		cloned.Params = newParams()
+		for key, val := range e.Params {
+			cloned.Params[key] = val
+		}
+	}
+
+	return cloned
+}
+
+// Equal checks if both encrypters have the same method and parameters.
+func (e *Encrypter) Equal(other *Encrypter) bool {
+	if e.Method != other.Method || len(e.Params) != len(other.Params) {
+		return false
+	}
+	for key, val := range e.Params {
+		otherVal, ok := other.Params[key]
+		if !ok || otherVal != val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Strengthen returns the default encrypter with the given options, if the
+// encrypter is weaker than it. Otherwise it returns false.
+// The encrypter is weaker if it uses another method, or if any of its parameters
+// is lower than the target. The parameters that are already higher are kept,
+// so the returned encrypter is never weaker than the current one.
+func (e *Encrypter) Strengthen(opts ...Option) (Encrypter, bool) {
+	target := DefaultEncrypter(opts...)
+	if e.Method != target.Method {
+		return target, true
+	}
+
+	weaker := false
+	for key := range target.Params {
+		if e.Params.GetUint64(key) < target.Params.GetUint64(key) {
+			weaker = true
+		} else {
+			target.Params[key] = e.Params[key]
+		}
+	}
+
+	return target, weaker
+}
+
+// EncryptionInfo describes the password hasher and the cipher of an encrypter.
+type EncryptionInfo struct {
+	KDF       string            // Name of the password hasher, like ARGON2ID
+	KDFParams map[string]uint64 // Parameters of the password hasher
+	Cipher    string            // Name of the cipher, like AES_256_CTR
+	MAC       string            // Name of the MAC method
+	KeyLen    uint32            // Length of the password hash in bytes
+	SaltLen   int               // Length of the salt in bytes
+	MACLen    int               // Length of the MAC in bytes
+}
+
+// Info returns the password hasher and cipher information of the encrypter.
+// These are stored in the method and parameters, so no password is needed.
+// It returns ErrNotEncrypted for the nope encrypter.
+func (e *Encrypter) Info() (EncryptionInfo, error) {
+	if !e.IsEncrypted() {
+		return EncryptionInfo{}, ErrNotEncrypted
+	}
+
+	funcs := strings.Split(e.Method, "-")
+	if len(funcs) != 3 {
+		return EncryptionInfo{}, ErrMethodNotSupported
+	}
+
+	kdfParams := make(map[string]uint64)
+	for key := range e.Params {
+		if key == nameParamKeyLen {
+			continue
+		}
+		kdfParams[key] = e.Params.GetUint64(key)
+	}
+
+	return EncryptionInfo{
+		KDF:       funcs[0],
+		KDFParams: kdfParams,
+		Cipher:    funcs[1],
+		MAC:       funcs[2],
+		KeyLen:    e.Params.GetUint32(nameParamKeyLen),
+		SaltLen:   saltLen,
+		MACLen:    macLen,
+	}, nil
+}
+
 // Encrypt encrypts the `message` using give `password` and returns the cipher message.
 func (e *Encrypter) Encrypt(message, password string) (string, error) {
 	if e.Method == nameFuncNope {
@@ -136,15 +319,12 @@ func (e *Encrypter) Encrypt(message, password string) (string, error) {
 	}
 
 	// Password hasher method
-	salt := make([]byte, 16)
+	salt := make([]byte, saltLen)
 	_, err := rand.Read(salt)
 	if err != nil {
 		return "", err
 	}
 
-	iterations := e.Params.GetUint32(nameParamIterations)
-	memory := e.Params.GetUint32(nameParamMemory)
-	parallelism := e.Params.GetUint8(nameParamParallelism)
 	keyLen := e.Params.GetUint32(nameParamKeyLen)
 
 	if keyLen == 32 {
@@ -155,17 +335,14 @@ func (e *Encrypter) Encrypt(message, password string) (string, error) {
 	}
 
 	// Password hasher method
-	var passwordHash []byte
-	switch funcs[0] {
-	case nameFuncArgon2ID:
-		// Argon2 currently has three modes:
-		// - data-dependent Argon2d,
-		// - data-independent Argon2i,
-		// - a mix of the two, Argon2id.
-		passwordHash = argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, keyLen)
-	default:
-		return "", ErrMethodNotSupported
+	passwordHash, err := e.hashPassword(funcs[0], password, salt, keyLen)
+	if err != nil {
+		return "", err
 	}
+	defer clear(passwordHash)
+
+	plainMsg := []byte(message)
+	defer clear(plainMsg)
 
 	// Encrypter method
 	// The first 32 bytes are used as the encryption key, and the last 16 bytes are used as the IV.
@@ -174,10 +351,10 @@ func (e *Encrypter) Encrypt(message, password string) (string, error) {
 	var cipher []byte
 	switch funcs[1] {
 	case nameFuncAES256CTR:
-		cipher = aes256CTRCrypt([]byte(message), initVec, cipherKey)
+		cipher = aes256CTRCrypt(plainMsg, initVec, cipherKey)
 
 	case nameFuncAES256CBC:
-		cipher = aes256CBCEncrypt([]byte(message), initVec, cipherKey)
+		cipher = aes256CBCEncrypt(plainMsg, initVec, cipherKey)
 
 	default:
 		return "", ErrMethodNotSupported
@@ -216,36 +393,167 @@ func (e *Encrypter) Decrypt(cipherText, password string) (string, error) {
 		return cipherText, nil
 	}
 
+	opened, err := e.open(cipherText, password)
+	if err != nil {
+		return "", err
+	}
+	defer opened.wipe()
+
+	return opened.decrypt()
+}
+
+// VerifyPassword checks the `password` against the MAC of the `cipher`,
+// without decrypting the message.
+// It returns ErrInvalidPassword if the password is not correct.
+func (e *Encrypter) VerifyPassword(cipherText, password string) error {
+	if e.Method == nameFuncNope {
+		if password != "" {
+			return ErrInvalidPassword
+		}
+
+		return nil
+	}
+
+	_, err := e.open(cipherText, password)
+
+	return err
+}
+
+// DeriveKey verifies the `password` and returns the key derived from it for the `cipher`.
+// The key can be used to decrypt the `cipher` using DecryptWithKey, without
+// running the password hasher again.
+// The key depends on the salt, so it can't decrypt other ciphers.
+func (e *Encrypter) DeriveKey(cipherText, password string) ([]byte, error) {
+	if e.Method == nameFuncNope {
+		return nil, ErrNotEncrypted
+	}
+
+	funcs, data, err := e.parse(cipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	keyLen := e.Params.GetUint32(nameParamKeyLen)
+	passwordHash, err := e.hashPassword(funcs[0], password, data[0:saltLen], keyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = e.openWithKey(funcs, data, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return passwordHash, nil
+}
+
+// DecryptWithKey decrypts the `cipher` using the key returned by DeriveKey.
+// It returns ErrInvalidPassword if the key doesn't match the cipher.
+func (e *Encrypter) DecryptWithKey(cipherText string, key []byte) (string, error) {
+	if e.Method == nameFuncNope {
+		return "", ErrNotEncrypted
+	}
+
+	funcs, data, err := e.parse(cipherText)
+	if err != nil {
+		return "", err
+	}
+
+	opened, err := e.openWithKey(funcs, data, key)
+	if err != nil {
+		return "", err
+	}
+
+	return opened.decrypt()
+}
+
+// openedCipher keeps the cipher message and the keys derived from the password.
+type openedCipher struct {
+	cipherFunc string
+	cipherKey  []byte
+	initVec    []byte
+	cipher     []byte
+}
+
+func (o *openedCipher) decrypt() (string, error) {
+	var msg []byte
+	switch o.cipherFunc {
+	case nameFuncAES256CTR:
+		msg = aes256CTRCrypt(o.cipher, o.initVec, o.cipherKey)
+
+	case nameFuncAES256CBC:
+		msg = aes256CBCDecrypt(o.cipher, o.initVec, o.cipherKey)
+
+	default:
+		return "", ErrMethodNotSupported
+	}
+	defer clear(msg)
+
+	return string(msg), nil
+}
+
+// wipe zeroes the keys derived from the password.
+func (o *openedCipher) wipe() {
+	clear(o.cipherKey)
+	clear(o.initVec)
+}
+
+// parse checks the method and decodes the `cipher`.
+func (e *Encrypter) parse(cipherText string) ([]string, []byte, error) {
 	funcs := strings.Split(e.Method, "-")
 	if len(funcs) != 3 {
-		return "", ErrMethodNotSupported
+		return nil, nil, ErrMethodNotSupported
+	}
+
+	switch funcs[1] {
+	case nameFuncAES256CTR, nameFuncAES256CBC:
+	default:
+		return nil, nil, ErrMethodNotSupported
+	}
+
+	if funcs[2] != nameFuncMACv1 {
+		return nil, nil, ErrMethodNotSupported
 	}
 
 	data, err := base64.StdEncoding.DecodeString(cipherText)
 	if err != nil {
-		return "", ErrInvalidCipher
+		return nil, nil, ErrInvalidCipher
 	}
 
 	// Minimum length of data should be 20 (16 salt + 4 bytes mac)
-	if len(data) < 20 {
-		return "", ErrInvalidCipher
+	if len(data) < saltLen+macLen {
+		return nil, nil, ErrInvalidCipher
+	}
+
+	return funcs, data, nil
+}
+
+// open parses the `cipher`, derives the keys from the `password`, and verifies the MAC.
+func (e *Encrypter) open(cipherText, password string) (*openedCipher, error) {
+	funcs, data, err := e.parse(cipherText)
+	if err != nil {
+		return nil, err
 	}
 
-	iterations := e.Params.GetUint32(nameParamIterations)
-	memory := e.Params.GetUint32(nameParamMemory)
-	parallelism := e.Params.GetUint8(nameParamParallelism)
 	keyLen := e.Params.GetUint32(nameParamKeyLen)
 
 	// Password hasher method
-	salt := data[0:16]
-	var passwordHash []byte
+	salt := data[0:saltLen]
+	passwordHash, err := e.hashPassword(funcs[0], password, salt, keyLen)
+	if err != nil {
+		return nil, err
+	}
 
-	switch funcs[0] {
-	case nameFuncArgon2ID:
-		passwordHash = argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, keyLen)
+	return e.openWithKey(funcs, data, passwordHash)
+}
 
-	default:
-		return "", ErrMethodNotSupported
+// openWithKey splits the password hash into the cipher keys and verifies the MAC.
+func (e *Encrypter) openWithKey(funcs []string, data, passwordHash []byte) (*openedCipher, error) {
+	keyLen := e.Params.GetUint32(nameParamKeyLen)
+	salt := data[0:saltLen]
+
+	if len(passwordHash) != int(keyLen) {
+		return nil, ErrInvalidPassword
 	}
 
 	// Encrypter method
@@ -263,36 +571,74 @@ func (e *Encrypter) Decrypt(cipherText, password string) (string, error) {
 		initVec = passwordHash[32:]
 
 	default:
-		return "", ErrInvalidParam
+		return nil, ErrInvalidParam
 	}
 
-	cipher := data[16 : len(data)-4]
-	var msg []byte
+	cipher := data[saltLen : len(data)-macLen]
 
-	switch funcs[1] {
-	case nameFuncAES256CTR:
-		msg = aes256CTRCrypt(cipher, initVec, cipherKey)
+	// MAC method
+	mac := data[len(data)-macLen:]
+	if !util.SafeCmp(mac, calcMACv1(cipherKey[16:32], cipher)) {
+		return nil, ErrInvalidPassword
+	}
 
-	case nameFuncAES256CBC:
-		msg = aes256CBCDecrypt(cipher, initVec, cipherKey)
+	return &openedCipher{
+		cipherFunc: funcs[1],
+		cipherKey:  cipherKey,
+		initVec:    initVec,
+		cipher:     cipher,
+	}, nil
+}
 
-	default:
-		return "", ErrMethodNotSupported
-	}
+// kdfRunCount is the number of times the password hasher has run.
+var kdfRunCount atomic.Uint64
 
-	// MAC method
-	switch funcs[2] {
-	case nameFuncMACv1:
-		mac := data[len(data)-4:]
-		if !util.SafeCmp(mac, calcMACv1(cipherKey[16:32], cipher)) {
-			return "", ErrInvalidPassword
+// KDFRunCount returns the number of times the password hasher has run in this
+// process, or since the last ResetKDFRunCount.
+// It helps to profile the password hashing, e.g. to check if an unlock
+// session avoids it. Counting is a single atomic addition, which is negligible
+// next to the password hashing itself.
+func KDFRunCount() uint64 {
+	return kdfRunCount.Load()
+}
+
+// ResetKDFRunCount resets the number of times the password hasher has run to zero.
+func ResetKDFRunCount() {
+	kdfRunCount.Store(0)
+}
+
+// hashPassword hashes the password using the given password hasher method
+// and the parameters of the encrypter.
+func (e *Encrypter) hashPassword(hasherFunc, password string, salt []byte, keyLen uint32) ([]byte, error) {
+	kdfRunCount.Add(1)
+
+	switch hasherFunc {
+	case nameFuncArgon2ID:
+		iterations := e.Params.GetUint32(nameParamIterations)
+		memory := e.Params.GetUint32(nameParamMemory)
+		parallelism := e.Params.GetUint8(nameParamParallelism)
+
+		// Argon2 currently has three modes:
+		// - data-dependent Argon2d,
+		// - data-independent Argon2i,
+		// - a mix of the two, Argon2id.
+		return argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, keyLen), nil
+
+	case nameFuncScrypt:
+		n := e.Params.GetUint32(nameParamScryptN)
+		r := e.Params.GetUint32(nameParamScryptR)
+		p := e.Params.GetUint32(nameParamScryptP)
+
+		hash, err := scrypt.Key([]byte(password), salt, int(n), int(r), int(p), int(keyLen))
+		if err != nil {
+			return nil, ErrInvalidParam
 		}
 
+		return hash, nil
+
 	default:
-		return "", ErrMethodNotSupported
+		return nil, ErrMethodNotSupported
 	}
-
-	return string(msg), nil
 }
 
 // aes256CTRCrypt encrypts or decrypts a message using AES-256-CTR mode.
@@ -360,5 +706,5 @@ func calcMACv1(data ...[]byte) []byte {
 		_, _ = hasher.Write(d)
 	}
 
-	return hasher.Sum(nil)[:4]
+	return hasher.Sum(nil)[:macLen]
 }
@@ -46,6 +46,91 @@ func TestDefaultEncrypterParams(t *testing.T) {
 	assert.True(t, enc.IsEncrypted())
 }
 
+func TestScryptEncrypterParams(t *testing.T) {
+	enc := DefaultEncrypter(OptionKDF(KDFScrypt))
+	assert.Equal(t, "SCRYPT-AES_256_CTR-MACV1", enc.Method)
+	assert.Equal(t, "32768", enc.Params["n"])
+	assert.Equal(t, "8", enc.Params["r"])
+	assert.Equal(t, "1", enc.Params["p"])
+	assert.Equal(t, "48", enc.Params["keylen"])
+	assert.NotContains(t, enc.Params, "iterations")
+
+	opts := []Option{
+		OptionKDF(KDFScrypt),
+		OptionScryptN(1024),
+		OptionScryptR(4),
+		OptionScryptP(2),
+	}
+	enc = DefaultEncrypter(opts...)
+	assert.Equal(t, "1024", enc.Params["n"])
+	assert.Equal(t, "4", enc.Params["r"])
+	assert.Equal(t, "2", enc.Params["p"])
+}
+
+func TestScryptEncrypter(t *testing.T) {
+	ts := testsuite.NewTestSuite(t)
+
+	enc := DefaultEncrypter(
+		OptionKDF(KDFScrypt),
+		OptionScryptN(16),
+		OptionScryptR(1),
+		OptionScryptP(1),
+	)
+
+	msg := ts.RandString(ts.RandIntNonZero(100))
+	password := ts.RandString(ts.RandIntNonZero(100))
+
+	cipher, err := enc.Encrypt(msg, password)
+	assert.NoError(t, err)
+
+	dec, err := enc.Decrypt(cipher, password)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, dec)
+
+	_, err = enc.Decrypt(cipher, "invalid-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	// N should be a power of two.
+	enc.Params.SetUint32(nameParamScryptN, 15)
+	_, err = enc.Encrypt(msg, password)
+	assert.ErrorIs(t, err, ErrInvalidParam)
+	_, err = enc.Decrypt(cipher, password)
+	assert.ErrorIs(t, err, ErrInvalidParam)
+}
+
+func TestEncrypterInfo(t *testing.T) {
+	enc := NopeEncrypter()
+	_, err := enc.Info()
+	assert.ErrorIs(t, err, ErrNotEncrypted)
+
+	enc = DefaultEncrypter(OptionIteration(3), OptionMemory(4), OptionParallelism(5))
+	info, err := enc.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, EncryptionInfo{
+		KDF: "ARGON2ID",
+		KDFParams: map[string]uint64{
+			"iterations":  3,
+			"memory":      4,
+			"parallelism": 5,
+		},
+		Cipher:  "AES_256_CTR",
+		MAC:     "MACV1",
+		KeyLen:  48,
+		SaltLen: 16,
+		MACLen:  4,
+	}, info)
+
+	enc = DefaultEncrypter(OptionKDF(KDFScrypt))
+	info, err = enc.Info()
+	assert.NoError(t, err)
+	assert.Equal(t, "SCRYPT", info.KDF)
+	assert.Equal(t, map[string]uint64{"n": 32768, "r": 8, "p": 1}, info.KDFParams)
+
+	enc = Encrypter{Method: "XXX"}
+	_, err = enc.Info()
+	assert.ErrorIs(t, err, ErrMethodNotSupported)
+}
+
 func TestDefaultEncrypter(t *testing.T) {
 	ts := testsuite.NewTestSuite(t)
 
@@ -76,6 +161,53 @@ func TestDefaultEncrypter(t *testing.T) {
 	assert.ErrorIs(t, err, ErrInvalidPassword)
 }
 
+func TestVerifyPassword(t *testing.T) {
+	enc := NopeEncrypter()
+	assert.NoError(t, enc.VerifyPassword("foo", ""))
+	assert.ErrorIs(t, enc.VerifyPassword("foo", "password"), ErrInvalidPassword)
+
+	enc = DefaultEncrypter(OptionIteration(1), OptionMemory(8), OptionParallelism(1))
+	cipher, err := enc.Encrypt("foo", "password")
+	assert.NoError(t, err)
+
+	assert.NoError(t, enc.VerifyPassword(cipher, "password"))
+	assert.ErrorIs(t, enc.VerifyPassword(cipher, "invalid-password"), ErrInvalidPassword)
+	assert.ErrorIs(t, enc.VerifyPassword("invalid-base64", "password"), ErrInvalidCipher)
+}
+
+func TestDeriveKey(t *testing.T) {
+	enc := NopeEncrypter()
+	_, err := enc.DeriveKey("foo", "")
+	assert.ErrorIs(t, err, ErrNotEncrypted)
+	_, err = enc.DecryptWithKey("foo", nil)
+	assert.ErrorIs(t, err, ErrNotEncrypted)
+
+	enc = DefaultEncrypter(OptionIteration(1), OptionMemory(8), OptionParallelism(1))
+	cipher, err := enc.Encrypt("foo", "password")
+	assert.NoError(t, err)
+
+	_, err = enc.DeriveKey(cipher, "invalid-password")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	key, err := enc.DeriveKey(cipher, "password")
+	assert.NoError(t, err)
+
+	count := KDFRunCount()
+	msg, err := enc.DecryptWithKey(cipher, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", msg)
+	assert.Equal(t, count, KDFRunCount(), "no password hashing")
+
+	// The key depends on the salt.
+	otherCipher, err := enc.Encrypt("foo", "password")
+	assert.NoError(t, err)
+	_, err = enc.DecryptWithKey(otherCipher, key)
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	_, err = enc.DecryptWithKey(cipher, key[:16])
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+}
+
 func TestInvalidMethod(t *testing.T) {
 	tests := []struct {
 		method string
@@ -214,3 +346,87 @@ func TestDecrypt(t *testing.T) {
 		assert.Equal(t, msg, dec)
 	}
 }
+
+func TestClone(t *testing.T) {
+	enc := DefaultEncrypter()
+	cloned := enc.Clone()
+	assert.Equal(t, enc, cloned)
+
+	cloned.Params.SetUint32("memory", 8)
+	assert.NotEqual(t, enc.Params["memory"], cloned.Params["memory"])
+
+	nope := NopeEncrypter()
+	assert.Equal(t, nope, nope.Clone())
+}
+
+func TestEqual(t *testing.T) {
+	enc := DefaultEncrypter()
+	assert.True(t, enc.Equal(&enc))
+
+	cloned := enc.Clone()
+	assert.True(t, enc.Equal(&cloned))
+
+	cloned.Params.SetUint32("memory", 8)
+	assert.False(t, enc.Equal(&cloned))
+
+	nope := NopeEncrypter()
+	assert.False(t, enc.Equal(&nope))
+
+	empty := Encrypter{Params: newParams()}
+	assert.True(t, nope.Equal(&empty))
+}
+
+func TestStrengthen(t *testing.T) {
+	t.Run("Weaker parameters", func(t *testing.T) {
+		enc := DefaultEncrypter(OptionIteration(1), OptionMemory(1024))
+
+		stronger, ok := enc.Strengthen(OptionMemory(4096))
+		assert.True(t, ok)
+		assert.Equal(t, "3", stronger.Params["iterations"])
+		assert.Equal(t, "4096", stronger.Params["memory"])
+	})
+
+	t.Run("Keep the stronger parameters", func(t *testing.T) {
+		enc := DefaultEncrypter(OptionIteration(8), OptionMemory(1024))
+
+		stronger, ok := enc.Strengthen(OptionIteration(3), OptionMemory(4096))
+		assert.True(t, ok)
+		assert.Equal(t, "8", stronger.Params["iterations"])
+		assert.Equal(t, "4096", stronger.Params["memory"])
+	})
+
+	t.Run("Strong enough", func(t *testing.T) {
+		enc := DefaultEncrypter(OptionIteration(8))
+
+		_, ok := enc.Strengthen()
+		assert.False(t, ok)
+	})
+
+	t.Run("Different method", func(t *testing.T) {
+		enc := DefaultEncrypter(OptionKDF(KDFScrypt))
+
+		stronger, ok := enc.Strengthen()
+		assert.True(t, ok)
+		assert.Equal(t, DefaultEncrypter(), stronger)
+
+		nope := NopeEncrypter()
+		_, ok = nope.Strengthen()
+		assert.True(t, ok)
+	})
+}
+
+func TestKDFRunCount(t *testing.T) {
+	enc := DefaultEncrypter(OptionIteration(1), OptionMemory(8), OptionParallelism(1))
+
+	ResetKDFRunCount()
+	assert.Zero(t, KDFRunCount())
+
+	cipher, err := enc.Encrypt("foo", "password")
+	assert.NoError(t, err)
+	_, err = enc.Decrypt(cipher, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), KDFRunCount())
+
+	ResetKDFRunCount()
+	assert.Zero(t, KDFRunCount())
+}
@@ -2,6 +2,7 @@ package encrypter
 
 import (
 	"errors"
+	"fmt"
 )
 
 // ErrInvalidPassword describes an error in which the password is invalid.
@@ -15,3 +16,25 @@ var ErrInvalidCipher = errors.New("invalid cipher message")
 
 // ErrMethodNotSupported describes an error in which the cipher method is not known.
 var ErrMethodNotSupported = errors.New("cipher method is not supported")
+
+// ErrNotEncrypted describes an error in which the message is not encrypted.
+var ErrNotEncrypted = errors.New("not encrypted")
+
+// ErrWeakPassword describes an error in which the password is too weak.
+var ErrWeakPassword = errors.New("password is too weak")
+
+// WeakPasswordError describes an error in which the estimated entropy of the
+// password is lower than the minimum entropy.
+type WeakPasswordError struct {
+	Bits    float64
+	MinBits float64
+}
+
+func (e WeakPasswordError) Error() string {
+	return fmt.Sprintf("%s: estimated entropy is %.1f bits, expected at least %.1f bits",
+		ErrWeakPassword.Error(), e.Bits, e.MinBits)
+}
+
+func (WeakPasswordError) Unwrap() error {
+	return ErrWeakPassword
+}
@@ -0,0 +1,141 @@
+package encrypter
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonPasswords is a short list of the most common passwords and words used in passwords.
+// The order is based on their popularity.
+var commonPasswords = []string{
+	"123456", "password", "12345678", "qwerty", "123456789",
+	"12345", "1234", "111111", "1234567", "dragon",
+	"123123", "baseball", "abc123", "football", "monkey",
+	"letmein", "696969", "shadow", "master", "666666",
+	"qwertyuiop", "123321", "mustang", "1234567890", "michael",
+	"654321", "superman", "1qaz2wsx", "7777777", "121212",
+	"000000", "qazwsx", "123qwe", "killer", "trustno1",
+	"jordan", "jennifer", "zxcvbnm", "asdfgh", "hunter",
+	"buster", "soccer", "harley", "batman", "andrew",
+	"tigger", "sunshine", "iloveyou", "2000", "charlie",
+	"robert", "thomas", "hockey", "ranger", "daniel",
+	"starwars", "klaster", "112233", "george", "computer",
+	"michelle", "jessica", "pepper", "1111", "zxcvbn",
+	"555555", "11111111", "131313", "freedom", "777777",
+	"pass", "maggie", "159753", "aaaaaa", "ginger",
+	"princess", "joshua", "cheese", "amanda", "summer",
+	"love", "ashley", "nicole", "chelsea", "biteme",
+	"matthew", "access", "yankees", "987654321", "dallas",
+	"austin", "thunder", "taylor", "matrix", "admin",
+	"welcome", "secret", "wallet", "bitcoin", "crypto",
+	"pactus",
+}
+
+// commonWordPlaceholder replaces the common words found in the password.
+const commonWordPlaceholder = '\x00'
+
+// EstimatePasswordEntropy estimates the entropy of the password in bits.
+// It is a simple heuristic inspired by zxcvbn:
+//   - Each character adds bits based on the size of its character classes.
+//   - Repeated and sequential characters, like "aaa" or "abc", add only one bit.
+//   - Common passwords and words add bits based on the size of the list.
+func EstimatePasswordEntropy(password string) float64 {
+	if password == "" {
+		return 0
+	}
+
+	lower := strings.ToLower(password)
+	for rank, common := range commonPasswords {
+		if lower == common {
+			return math.Log2(float64(rank + 2))
+		}
+	}
+
+	bits := float64(0)
+	for _, common := range commonPasswords {
+		if len(common) < 4 {
+			continue
+		}
+
+		for strings.Contains(lower, common) {
+			lower = strings.Replace(lower, common, string(commonWordPlaceholder), 1)
+			bits += math.Log2(float64(len(commonPasswords)))
+		}
+	}
+
+	bitsPerChar := math.Log2(float64(charsetSize(password)))
+	var prev rune
+	for i, r := range []rune(lower) {
+		if r == commonWordPlaceholder {
+			prev = 0
+
+			continue
+		}
+
+		diff := r - prev
+		if i > 0 && (diff == 0 || diff == 1 || diff == -1) {
+			bits++
+		} else {
+			bits += bitsPerChar
+		}
+		prev = r
+	}
+
+	return bits
+}
+
+// charsetSize returns the size of the character classes used in the password.
+func charsetSize(password string) int {
+	hasLower, hasUpper, hasDigit, hasSymbol, hasOther := false, false, false, false, false
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r < unicode.MaxASCII:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+	}
+
+	size := 0
+	if hasLower {
+		size += 26
+	}
+	if hasUpper {
+		size += 26
+	}
+	if hasDigit {
+		size += 10
+	}
+	if hasSymbol {
+		size += 33
+	}
+	if hasOther {
+		size += 100
+	}
+
+	return size
+}
+
+// CheckPasswordStrength checks the password against the password policy in the options.
+// It returns a WeakPasswordError if the estimated entropy of the password is
+// lower than the minimum entropy set by OptionMinPasswordEntropy.
+func CheckPasswordStrength(password string, opts ...Option) error {
+	parameters := &parameters{}
+	for _, opt := range opts {
+		opt(parameters)
+	}
+
+	bits := EstimatePasswordEntropy(password)
+	if bits < parameters.minEntropy {
+		return WeakPasswordError{Bits: bits, MinBits: parameters.minEntropy}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package encrypter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimatePasswordEntropy(t *testing.T) {
+	tests := []struct {
+		password string
+		minBits  float64
+		maxBits  float64
+	}{
+		{"", 0, 0},
+		{"123456", 0, 2},
+		{"PASSWORD", 0, 2},
+		{"aaaaaaaaaaaa", 0, 20},
+		{"abcdefghijkl", 0, 20},
+		{"password2024", 0, 30},
+		{"xkcd", 15, 25},
+		{"Tr0ub4dor&3", 60, 80},
+		{"correct horse battery staple", 100, 200},
+		{"گذرواژه‌امن", 50, 120},
+	}
+
+	for _, tt := range tests {
+		bits := EstimatePasswordEntropy(tt.password)
+		assert.GreaterOrEqual(t, bits, tt.minBits, "password %q", tt.password)
+		assert.LessOrEqual(t, bits, tt.maxBits, "password %q", tt.password)
+	}
+}
+
+func TestCheckPasswordStrength(t *testing.T) {
+	assert.NoError(t, CheckPasswordStrength("123456"))
+
+	opt := OptionMinPasswordEntropy(50)
+	err := CheckPasswordStrength("123456", opt)
+	assert.ErrorIs(t, err, ErrWeakPassword)
+
+	var weakErr WeakPasswordError
+	assert.ErrorAs(t, err, &weakErr)
+	assert.Equal(t, float64(1), weakErr.Bits)
+	assert.Equal(t, float64(50), weakErr.MinBits)
+
+	assert.NoError(t, CheckPasswordStrength("Tr0ub4dor&3", opt))
+}
+
+func TestMinPasswordEntropyNotStored(t *testing.T) {
+	enc1 := DefaultEncrypter()
+	enc2 := DefaultEncrypter(OptionMinPasswordEntropy(50))
+	assert.Equal(t, enc1, enc2)
+}
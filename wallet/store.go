@@ -7,13 +7,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/pactus-project/pactus/crypto"
-	"github.com/pactus-project/pactus/crypto/bls"
-	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
 	"github.com/pactus-project/pactus/genesis"
 	"github.com/pactus-project/pactus/util"
 	"github.com/pactus-project/pactus/util/logger"
-	"github.com/pactus-project/pactus/wallet/addresspath"
 	"github.com/pactus-project/pactus/wallet/vault"
 )
 
@@ -51,7 +47,6 @@ func (s *Store) ToBytes() ([]byte, error) {
 }
 
 func (s *Store) Clone() *Store {
-	clonedVault := *s.Vault // Assuming Vault has proper pointer handling internally
 	clonedHistory := s.History
 
 	return &Store{
@@ -60,13 +55,15 @@ func (s *Store) Clone() *Store {
 		CreatedAt: s.CreatedAt,
 		Network:   s.Network,
 		VaultCRC:  s.VaultCRC,
-		Vault:     &clonedVault,
+		Vault:     s.Vault.Clone(),
 		History:   clonedHistory,
 	}
 }
 
+// ValidateCRC checks the saved CRC against the vault as it was decoded, so the
+// vaults migrated on decoding are checked before they are saved again.
 func (s *Store) ValidateCRC() error {
-	crc := s.calcVaultCRC()
+	crc := s.Vault.DecodedCRC()
 	if s.VaultCRC != crc {
 		return CRCNotMatchError{
 			Expected: crc,
@@ -80,28 +77,24 @@ func (s *Store) ValidateCRC() error {
 func (s *Store) UpgradeWallet(walletPath string) error {
 	oldVersion := s.Version
 	switch oldVersion {
-	case Version1:
-		if err := s.setPublicKeys(); err != nil {
+	case Version1, Version2:
+		if err := s.Vault.UpgradeLegacy(vault.LegacyFormat(oldVersion)); err != nil {
 			return err
 		}
-		s.Version = Version2
-
-		logger.Info(fmt.Sprintf("wallet upgraded from version %d to version %d",
-			Version1, Version2))
-
-		fallthrough
-
-	case Version2:
-		if s.Vault.IsEncrypted() {
-			s.Vault.Encrypter.Params.SetUint32("keylen", 32)
-		}
 		s.Version = Version3
 
 		logger.Info(fmt.Sprintf("wallet upgraded from version %d to version %d",
-			Version2, Version3))
+			oldVersion, Version3))
 
 	case Version3:
-		return nil
+		// The vault format is migrated on decoding, so the store only needs
+		// to be saved again.
+		if !s.Vault.IsMigrated() {
+			return nil
+		}
+
+		logger.Info(fmt.Sprintf("vault format upgraded to version %d",
+			s.Vault.Version))
 
 	default:
 		return UnsupportedVersionError{
@@ -126,52 +119,6 @@ func (s *Store) UpgradeWallet(walletPath string) error {
 	return nil
 }
 
-func (s *Store) setPublicKeys() error {
-	for addrKey, info := range s.Vault.Addresses {
-		if info.PublicKey != "" {
-			continue
-		}
-
-		// Some old wallet doesn't have public key for all addresses.
-		addr, err := crypto.AddressFromString(info.Address)
-		if err != nil {
-			return err
-		}
-
-		var xPub string
-		if addr.IsAccountAddress() {
-			xPub = s.Vault.Purposes.PurposeBLS.XPubAccount
-		} else if addr.IsValidatorAddress() {
-			xPub = s.Vault.Purposes.PurposeBLS.XPubValidator
-		}
-
-		ext, err := blshdkeychain.NewKeyFromString(xPub)
-		if err != nil {
-			return err
-		}
-
-		p, err := addresspath.FromString(info.Path)
-		if err != nil {
-			return err
-		}
-
-		extendedKey, err := ext.Derive(p.AddressIndex())
-		if err != nil {
-			return err
-		}
-
-		blsPubKey, err := bls.PublicKeyFromBytes(extendedKey.RawPublicKey())
-		if err != nil {
-			return err
-		}
-
-		info.PublicKey = blsPubKey.String()
-		s.Vault.Addresses[addrKey] = info
-	}
-
-	return nil
-}
-
 func (s *Store) calcVaultCRC() uint32 {
 	d, err := json.Marshal(s.Vault)
 	if err != nil {
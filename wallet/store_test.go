@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/pactus-project/pactus/util"
+	"github.com/pactus-project/pactus/wallet/vault"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,8 +33,14 @@ func TestSupportedWallets(t *testing.T) {
 
 		// TODO: use public method to check version, like Wallet.Info()
 		assert.Equal(t, VersionLatest, wlt.store.Version)
+		assert.Equal(t, vault.CurrentVaultVersion, wlt.store.Vault.Version)
 		assert.Equal(t, tt.addressCount, wlt.AddressCount())
 
+		// The upgraded wallet should open again with a valid CRC.
+		reopened, err := Open(tempPath, true)
+		require.NoError(t, err)
+		assert.False(t, reopened.store.Vault.IsMigrated())
+
 		mnemonic, err := wlt.Mnemonic("password")
 		require.NoError(t, err)
 		//nolint:dupword // duplicated seed phrase words
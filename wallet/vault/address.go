@@ -0,0 +1,35 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/util/bech32m"
+)
+
+// ValidateAddressString checks the HRP, the checksum, the type and the length
+// of the address in one call, and returns the address type.
+// UIs can use it to validate the pasted addresses before using them, e.g. in
+// SetLabel or Contains.
+// It returns AddressChecksumError if the address is mistyped, and
+// AddressNetworkError if the address belongs to another network. Both wrap
+// ErrInvalidAddress, like the other failures.
+func ValidateAddressString(addr string) (crypto.AddressType, error) {
+	parsed, err := crypto.AddressFromString(addr)
+	if err == nil {
+		return parsed.Type(), nil
+	}
+
+	var checksumErr bech32m.InvalidChecksumError
+	if errors.As(err, &checksumErr) {
+		return 0, AddressChecksumError{Address: addr}
+	}
+
+	var hrpErr crypto.InvalidHRPError
+	if errors.As(err, &hrpErr) {
+		return 0, AddressNetworkError{HRP: string(hrpErr), ExpectedHRP: crypto.AddressHRP}
+	}
+
+	return 0, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+}
@@ -0,0 +1,64 @@
+package vault
+
+import (
+	"encoding/json"
+
+	"github.com/pactus-project/pactus/crypto"
+)
+
+// AddressBookEntry is an address of the vault in the address book.
+// It has no key, neither public nor private.
+type AddressBookEntry struct {
+	Address     string
+	Label       string
+	Path        string
+	AddressType crypto.AddressType
+	Origin      Origin
+}
+
+// addressBookEntryJSON is the JSON encoding of AddressBookEntry,
+// with the address type and the origin as strings.
+type addressBookEntryJSON struct {
+	Address     string `json:"address"`
+	Label       string `json:"label"`
+	Path        string `json:"path"`
+	AddressType string `json:"type"`
+	Origin      string `json:"origin"`
+}
+
+// MarshalJSON encodes the entry to JSON, with the address type and the origin as strings,
+// like "bls_account" and "imported".
+func (e AddressBookEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addressBookEntryJSON{
+		Address:     e.Address,
+		Label:       e.Label,
+		Path:        e.Path,
+		AddressType: e.AddressType.String(),
+		Origin:      e.Origin.String(),
+	})
+}
+
+// ExportAddressBook returns the addresses of the vault with their labels, paths,
+// types and origins, sorted in the same order as AddressInfos.
+// It can be shared, since no key is exported.
+// It needs no password and works on neutered vaults.
+func (v *Vault) ExportAddressBook() ([]AddressBookEntry, error) {
+	infos := v.AddressInfos()
+	entries := make([]AddressBookEntry, 0, len(infos))
+	for _, info := range infos {
+		addr, err := crypto.AddressFromString(info.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, AddressBookEntry{
+			Address:     info.Address,
+			Label:       info.Label,
+			Path:        info.Path,
+			AddressType: addr.Type(),
+			Origin:      info.Origin,
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,59 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAddressBook(t *testing.T) {
+	td := setup(t)
+
+	entries, err := td.vault.ExportAddressBook()
+	require.NoError(t, err)
+	require.Len(t, entries, td.vault.AddressCount())
+
+	for i, info := range td.vault.AddressInfos() {
+		assert.Equal(t, info.Address, entries[i].Address)
+		assert.Equal(t, info.Label, entries[i].Label)
+		assert.Equal(t, info.Path, entries[i].Path)
+		assert.Equal(t, info.Origin, entries[i].Origin)
+	}
+
+	t.Run("Address types and origins", func(t *testing.T) {
+		validatorAddr := td.vault.AddressesByLabel("validator-address")[0].Address
+		importedAddr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+
+		for _, entry := range entries {
+			switch entry.Address {
+			case validatorAddr:
+				assert.Equal(t, crypto.AddressTypeValidator, entry.AddressType)
+				assert.Equal(t, OriginHDDerived, entry.Origin)
+			case importedAddr:
+				assert.Equal(t, crypto.AddressTypeEd25519Account, entry.AddressType)
+				assert.Equal(t, OriginImported, entry.Origin)
+			}
+		}
+	})
+
+	t.Run("No keys in JSON", func(t *testing.T) {
+		data, err := json.Marshal(entries)
+		require.NoError(t, err)
+
+		for _, info := range td.vault.AddressInfos() {
+			assert.NotContains(t, string(data), info.PublicKey)
+		}
+		assert.NotContains(t, string(data), td.vault.KeyStore)
+		assert.Contains(t, string(data), `"type":"bls_account"`)
+		assert.Contains(t, string(data), `"origin":"imported"`)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		neuteredEntries, err := td.vault.Neuter().ExportAddressBook()
+		require.NoError(t, err)
+		assert.Equal(t, entries, neuteredEntries)
+	})
+}
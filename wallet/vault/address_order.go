@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"cmp"
+	"sync"
+
+	"github.com/pactus-project/pactus/wallet/addresspath"
+	"golang.org/x/exp/slices"
+)
+
+// orderCache keeps the addresses sorted by their paths, so AddressInfos doesn't
+// sort them on every call. It is safe for concurrent use, since the read-only
+// methods of the vault fill it.
+type orderCache struct {
+	lock  sync.Mutex
+	addrs []string // Sorted addresses, never modified once cached
+	paths []string // Paths of the sorted addresses, to detect the changes
+}
+
+// isValid checks if the cached order still matches the addresses of the vault.
+// Since the address map is exported and can be changed directly, the cache is
+// checked on every use, instead of relying on the mutating methods to reset it.
+// Only the addresses and their paths affect the order, so changing a label
+// or a group keeps the cache valid.
+func (c *orderCache) isValid(addresses map[string]AddressInfo) bool {
+	if c.addrs == nil || len(c.addrs) != len(addresses) {
+		return false
+	}
+
+	for i, addr := range c.addrs {
+		info, ok := addresses[addr]
+		if !ok || info.Path != c.paths[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortedAddresses returns the addresses sorted by purpose, address type and
+// address index. The addresses with the same path are sorted by address, and
+// the addresses with a corrupted path come last.
+// The returned slice is shared with the cache and should not be modified.
+func (v *Vault) sortedAddresses() []string {
+	if v.order == nil {
+		v.order = &orderCache{}
+	}
+
+	v.order.lock.Lock()
+	defer v.order.lock.Unlock()
+
+	if v.order.isValid(v.Addresses) {
+		return v.order.addrs
+	}
+
+	type sortKey struct {
+		addr string
+		path addresspath.Path
+	}
+
+	keys := make([]sortKey, 0, len(v.Addresses))
+	for addr, info := range v.Addresses {
+		path, err := v.parsePath(info.Path)
+		if err != nil || len(path) < 3 {
+			path = nil
+		}
+		keys = append(keys, sortKey{addr: addr, path: path})
+	}
+
+	slices.SortFunc(keys, func(a, b sortKey) int {
+		if (a.path == nil) != (b.path == nil) {
+			if a.path == nil {
+				return 1
+			}
+
+			return -1
+		}
+
+		if a.path != nil {
+			if c := cmp.Compare(a.path.Purpose(), b.path.Purpose()); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.path.AddressType(), b.path.AddressType()); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.path.AddressIndex(), b.path.AddressIndex()); c != 0 {
+				return c
+			}
+		}
+
+		return cmp.Compare(a.addr, b.addr)
+	})
+
+	addrs := make([]string, len(keys))
+	paths := make([]string, len(keys))
+	for i, key := range keys {
+		addrs[i] = key.addr
+		paths[i] = v.Addresses[key.addr].Path
+	}
+	v.order.addrs = addrs
+	v.order.paths = paths
+
+	return addrs
+}
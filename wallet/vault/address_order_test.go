@@ -0,0 +1,87 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressInfosCache(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Adding an address", func(t *testing.T) {
+		infos := td.vault.AddressInfos()
+
+		info, err := td.vault.NewBLSAccountAddress("new-address")
+		require.NoError(t, err)
+
+		updated := td.vault.AddressInfos()
+		assert.Len(t, updated, len(infos)+1)
+		assert.Contains(t, updated, *info)
+	})
+
+	t.Run("Changing a label", func(t *testing.T) {
+		info := td.vault.AddressInfos()[0]
+		require.NoError(t, td.vault.SetLabel(info.Address, "changed-label"))
+
+		assert.Equal(t, "changed-label", td.vault.AddressInfos()[0].Label)
+	})
+
+	t.Run("Removing an address", func(t *testing.T) {
+		info, err := td.vault.NewBLSAccountAddress("removing-address")
+		require.NoError(t, err)
+		infos := td.vault.AddressInfos()
+
+		require.NoError(t, td.vault.RemoveAddress(info.Address, tPassword, false))
+		assert.Len(t, td.vault.AddressInfos(), len(infos)-1)
+		assert.NotContains(t, td.vault.AddressInfos(), *info)
+	})
+
+	t.Run("Changing the map directly", func(t *testing.T) {
+		infos := td.vault.AddressInfos()
+		first := infos[0]
+		delete(td.vault.Addresses, first.Address)
+
+		_, prv := td.RandBLSKeyPair()
+		replaced := AddressInfo{
+			Address:   prv.PublicKeyNative().AccountAddress().String(),
+			PublicKey: prv.PublicKeyNative().String(),
+			Path:      first.Path,
+		}
+		td.vault.Addresses[replaced.Address] = replaced
+
+		assert.Equal(t, replaced, td.vault.AddressInfos()[0])
+	})
+
+	t.Run("Modifying the returned slice doesn't affect the cache", func(t *testing.T) {
+		infos := td.vault.AddressInfos()
+		infos[0].Label = "modified"
+
+		assert.NotEqual(t, "modified", td.vault.AddressInfos()[0].Label)
+	})
+}
+
+func BenchmarkAddressInfos(b *testing.B) {
+	mnemonic, _ := GenerateMnemonic(128)
+	vlt, _ := CreateVaultFromMnemonic(mnemonic, 21888)
+	_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeValidator, 1000, "")
+	_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 1000, "")
+
+	b.Run("First call", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			vlt.order = nil
+			_ = vlt.AddressInfos()
+		}
+	})
+
+	b.Run("Repeated call", func(b *testing.B) {
+		b.ReportAllocs()
+		_ = vlt.AddressInfos()
+		for i := 0; i < b.N; i++ {
+			_ = vlt.AddressInfos()
+		}
+	})
+}
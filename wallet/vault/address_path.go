@@ -0,0 +1,100 @@
+package vault
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+// pathCache keeps the parsed paths. It is safe for concurrent use,
+// since the read-only methods of the vault fill it.
+type pathCache struct {
+	lock  sync.Mutex
+	paths map[string]addresspath.Path
+}
+
+func newPathCache() *pathCache {
+	return &pathCache{
+		paths: make(map[string]addresspath.Path),
+	}
+}
+
+// AddressPath returns the parsed derivation path of the address.
+// It returns an error if the address is not in the vault, or if its stored path
+// is corrupted.
+func (v *Vault) AddressPath(addr string) (addresspath.Path, error) {
+	info, ok := v.Addresses[addr]
+	if !ok {
+		return nil, NewErrAddressNotFound(addr)
+	}
+
+	path, err := v.parsePath(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return addresspath.NewPath(path...), nil
+}
+
+// parsePath parses the path string and caches the result, so the stored paths
+// are parsed only once.
+// The returned path is shared with the cache and should not be modified.
+func (v *Vault) parsePath(pathStr string) (addresspath.Path, error) {
+	if v.paths == nil {
+		v.paths = newPathCache()
+	}
+
+	v.paths.lock.Lock()
+	defer v.paths.lock.Unlock()
+
+	if path, ok := v.paths.paths[pathStr]; ok {
+		return path, nil
+	}
+
+	path, err := addresspath.FromString(pathStr)
+	if err != nil {
+		return nil, err
+	}
+	v.paths.paths[pathStr] = path
+
+	return path, nil
+}
+
+// parseDerivablePath parses the path of a key that is derived from the seed.
+// The purpose and the coin type of the path should match the vault, and the
+// address type should match the purpose. The paths with the import purpose
+// return ErrUnsupportedPurpose, since the imported keys are not derived.
+func (v *Vault) parseDerivablePath(pathStr string) (addresspath.Path, error) {
+	path, err := addresspath.FromString(pathStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+	}
+	if err := path.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+	}
+
+	purpose := _N(path.Purpose())
+	addressType := crypto.AddressType(_N(path.AddressType()))
+	switch purpose {
+	case PurposeBLS12381:
+		if addressType != crypto.AddressTypeValidator && addressType != crypto.AddressTypeBLSAccount {
+			return nil, ErrUnsupportedAddressType
+		}
+
+	case PurposeBIP44:
+		if addressType != crypto.AddressTypeEd25519Account {
+			return nil, ErrUnsupportedAddressType
+		}
+
+	default:
+		return nil, ErrUnsupportedPurpose
+	}
+
+	if err := v.checkKeyPath(path, purpose); err != nil {
+		return nil, err
+	}
+
+	return path, nil
+}
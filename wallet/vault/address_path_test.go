@@ -0,0 +1,58 @@
+package vault
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressPath(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Known addresses", func(t *testing.T) {
+		for _, info := range td.vault.AddressInfos() {
+			path, err := td.vault.AddressPath(info.Address)
+			require.NoError(t, err)
+			assert.Equal(t, info.Path, path.String())
+		}
+	})
+
+	t.Run("Validator address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		path, err := td.vault.AddressPath(info.Address)
+		require.NoError(t, err)
+
+		assert.Equal(t, _H(PurposeBLS12381), path.Purpose())
+		assert.Equal(t, _H(crypto.AddressTypeValidator), path.AddressType())
+		assert.Equal(t, uint32(0), path.AddressIndex())
+	})
+
+	t.Run("Modifying the returned path doesn't affect the cache", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		path, err := td.vault.AddressPath(info.Address)
+		require.NoError(t, err)
+		path[3] = 100
+
+		path, err = td.vault.AddressPath(info.Address)
+		require.NoError(t, err)
+		assert.Equal(t, info.Path, path.String())
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandAccAddress().String()
+		_, err := td.vault.AddressPath(addr)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Corrupted path", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("bls-account-address")[0]
+		info.Path = "m/12381'/21888'/x'/0"
+		td.vault.Addresses[info.Address] = info
+
+		_, err := td.vault.AddressPath(info.Address)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	})
+}
@@ -0,0 +1,66 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/util/bech32m"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAddressString(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Valid addresses", func(t *testing.T) {
+		tests := []struct {
+			label       string
+			addressType crypto.AddressType
+		}{
+			{"validator-address", crypto.AddressTypeValidator},
+			{"bls-account-address", crypto.AddressTypeBLSAccount},
+			{"ed25519-account-address", crypto.AddressTypeEd25519Account},
+		}
+		for _, tt := range tests {
+			info := td.vault.AddressesByLabel(tt.label)[0]
+			addressType, err := ValidateAddressString(info.Address)
+			require.NoError(t, err)
+			assert.Equal(t, tt.addressType, addressType)
+		}
+	})
+
+	t.Run("Mutated checksum", func(t *testing.T) {
+		addr := td.RandAccAddress().String()
+		last := addr[len(addr)-1]
+		mutated := addr[:len(addr)-1] + "q"
+		if last == 'q' {
+			mutated = addr[:len(addr)-1] + "p"
+		}
+
+		_, err := ValidateAddressString(mutated)
+		assert.ErrorIs(t, err, ErrInvalidAddress)
+		assert.ErrorIs(t, err, AddressChecksumError{Address: mutated})
+	})
+
+	t.Run("Another network", func(t *testing.T) {
+		addr := td.RandAccAddress()
+		testnetAddr, err := bech32m.EncodeFromBase256WithType("tpc", addr[0], addr[1:])
+		require.NoError(t, err)
+
+		_, err = ValidateAddressString(testnetAddr)
+		assert.ErrorIs(t, err, ErrInvalidAddress)
+		assert.ErrorIs(t, err, AddressNetworkError{HRP: "tpc", ExpectedHRP: crypto.AddressHRP})
+	})
+
+	t.Run("Invalid addresses", func(t *testing.T) {
+		addr := td.RandAccAddress()
+		invalidType, _ := bech32m.EncodeFromBase256WithType(crypto.AddressHRP, 4, addr[1:])
+		invalidLength, _ := bech32m.EncodeFromBase256WithType(crypto.AddressHRP, addr[0], addr[2:])
+
+		for _, input := range []string{"", "garbage", invalidType, invalidLength} {
+			_, err := ValidateAddressString(input)
+			assert.ErrorIs(t, err, ErrInvalidAddress, "input: %s", input)
+			assert.NotErrorIs(t, err, AddressChecksumError{Address: input}, "input: %s", input)
+		}
+	})
+}
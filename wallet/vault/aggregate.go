@@ -0,0 +1,149 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+// AggregatePublicKey returns the aggregated BLS public key of the given validator addresses.
+// All the addresses should be validator addresses that exist in the vault.
+// It returns ErrDuplicateAddress if an address is given more than once.
+func (v *Vault) AggregatePublicKey(addrs []string) (*bls.PublicKey, error) {
+	if err := checkAggregateAddresses(addrs); err != nil {
+		return nil, err
+	}
+
+	pubs := make([]*bls.PublicKey, 0, len(addrs))
+	for _, addr := range addrs {
+		info, err := v.validatorAddressInfo(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		pub, err := bls.PublicKeyFromString(info.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		pubs = append(pubs, pub)
+	}
+
+	return bls.PublicKeyAggregate(pubs...), nil
+}
+
+// SignAggregate signs the message with the private key of each given BLS address
+// and returns the aggregated signature.
+// For validator addresses, the signature can be verified using AggregatePublicKey.
+// Like AggregatePublicKey, it returns ErrDuplicateAddress if an address is given
+// more than once.
+// The private keys are zeroed after signing.
+func (v *Vault) SignAggregate(password string, addrs []string, msg []byte) (*bls.Signature, error) {
+	if err := checkAggregateAddresses(addrs); err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		info := v.AddressInfo(addr)
+		if info == nil {
+			return nil, NewErrAddressNotFound(addr)
+		}
+
+		addrPath, err := addresspath.FromString(info.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch addrPath.AddressType() {
+		case _H(crypto.AddressTypeValidator), _H(crypto.AddressTypeBLSAccount):
+		default:
+			return nil, ErrUnsupportedAddressType
+		}
+	}
+
+	prvs, err := v.PrivateKeys(password, addrs)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, prv := range prvs {
+			clearPrivateKey(prv)
+		}
+	}()
+
+	sigs := make([]*bls.Signature, 0, len(prvs))
+	for _, prv := range prvs {
+		blsPrv, ok := prv.(*bls.PrivateKey)
+		if !ok {
+			return nil, ErrUnsupportedAddressType
+		}
+		sigs = append(sigs, blsPrv.SignNative(msg))
+	}
+
+	return bls.SignatureAggregate(sigs...), nil
+}
+
+// checkAggregateAddresses checks that the addresses to aggregate are not empty
+// and each address is given once, since a duplicated key would be aggregated twice.
+func checkAggregateAddresses(addrs []string) error {
+	if len(addrs) == 0 {
+		return ErrInvalidCount
+	}
+
+	seen := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		if seen[addr] {
+			return fmt.Errorf("%w: %s", ErrDuplicateAddress, addr)
+		}
+		seen[addr] = true
+	}
+
+	return nil
+}
+
+// ProofOfPossession generates the BLS proof of possession for the given validator address.
+// It works for both derived and imported BLS keys, and can be verified using
+// bls.PublicKey.VerifyProofOfPossession.
+func (v *Vault) ProofOfPossession(password, validatorAddr string) (*bls.Signature, error) {
+	if v.IsNeutered() {
+		return nil, ErrNeutered
+	}
+
+	_, err := v.validatorAddressInfo(validatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	prvs, err := v.PrivateKeys(password, []string{validatorAddr})
+	if err != nil {
+		return nil, err
+	}
+
+	blsPrv, ok := prvs[0].(*bls.PrivateKey)
+	if !ok {
+		return nil, ErrUnsupportedAddressType
+	}
+	defer blsPrv.Clear()
+
+	return blsPrv.ProofOfPossession(), nil
+}
+
+// validatorAddressInfo returns the address information if it is a validator address.
+func (v *Vault) validatorAddressInfo(addr string) (*AddressInfo, error) {
+	info := v.AddressInfo(addr)
+	if info == nil {
+		return nil, NewErrAddressNotFound(addr)
+	}
+
+	addrPath, err := addresspath.FromString(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if addrPath.AddressType() != _H(crypto.AddressTypeValidator) {
+		return nil, ErrUnsupportedAddressType
+	}
+
+	return info, nil
+}
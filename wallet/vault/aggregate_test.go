@@ -0,0 +1,149 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregatePublicKey(t *testing.T) {
+	td := setup(t)
+
+	info1 := td.vault.AddressFromPath("m/12381'/21888'/1'/0")
+	info2, err := td.vault.NewValidatorAddress("validator-2")
+	require.NoError(t, err)
+	info3 := td.vault.AddressInfo(td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String())
+	addrs := []string{info1.Address, info2.Address, info3.Address}
+
+	t.Run("No address", func(t *testing.T) {
+		_, err := td.vault.AggregatePublicKey([]string{})
+		assert.ErrorIs(t, err, ErrInvalidCount)
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandValAddress().String()
+		_, err := td.vault.AggregatePublicKey([]string{info1.Address, addr})
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Account address", func(t *testing.T) {
+		accInfo := td.vault.AddressFromPath("m/12381'/21888'/2'/0")
+		_, err := td.vault.AggregatePublicKey([]string{info1.Address, accInfo.Address})
+		assert.ErrorIs(t, err, ErrUnsupportedAddressType)
+	})
+
+	t.Run("Duplicate address", func(t *testing.T) {
+		_, err := td.vault.AggregatePublicKey([]string{info1.Address, info2.Address, info1.Address})
+		assert.ErrorIs(t, err, ErrDuplicateAddress)
+	})
+
+	t.Run("Verify aggregated signature", func(t *testing.T) {
+		aggPub, err := td.vault.AggregatePublicKey(addrs)
+		require.NoError(t, err)
+
+		prvs, err := td.vault.PrivateKeys(tPassword, addrs)
+		require.NoError(t, err)
+
+		msg := []byte("pactus")
+		sigs := make([]*bls.Signature, 0, len(prvs))
+		for _, prv := range prvs {
+			sigs = append(sigs, prv.(*bls.PrivateKey).SignNative(msg))
+		}
+
+		assert.NoError(t, aggPub.Verify(msg, bls.SignatureAggregate(sigs...)))
+	})
+}
+
+func TestSignAggregate(t *testing.T) {
+	td := setup(t)
+
+	info1 := td.vault.AddressFromPath("m/12381'/21888'/1'/0")
+	info2, err := td.vault.NewValidatorAddress("validator-2")
+	require.NoError(t, err)
+	info3 := td.vault.AddressInfo(td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String())
+	addrs := []string{info1.Address, info2.Address, info3.Address}
+	msg := []byte("pactus")
+
+	t.Run("No address", func(t *testing.T) {
+		_, err := td.vault.SignAggregate(tPassword, []string{}, msg)
+		assert.ErrorIs(t, err, ErrInvalidCount)
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandValAddress().String()
+		_, err := td.vault.SignAggregate(tPassword, []string{info1.Address, addr}, msg)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Ed25519 address", func(t *testing.T) {
+		ed25519Info := td.vault.AddressFromPath("m/44'/21888'/3'/0'")
+		_, err := td.vault.SignAggregate(tPassword, []string{info1.Address, ed25519Info.Address}, msg)
+		assert.ErrorIs(t, err, ErrUnsupportedAddressType)
+	})
+
+	t.Run("Duplicate address", func(t *testing.T) {
+		_, err := td.vault.SignAggregate(tPassword, []string{info1.Address, info2.Address, info1.Address}, msg)
+		assert.ErrorIs(t, err, ErrDuplicateAddress)
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		sig, err := td.vault.SignAggregate(tPassword, addrs, msg)
+		require.NoError(t, err)
+
+		aggPub, err := td.vault.AggregatePublicKey(addrs)
+		require.NoError(t, err)
+		assert.NoError(t, aggPub.Verify(msg, sig))
+
+		// One signer is omitted.
+		aggPub, err = td.vault.AggregatePublicKey(addrs[:2])
+		require.NoError(t, err)
+		assert.ErrorIs(t, aggPub.Verify(msg, sig), crypto.ErrInvalidSignature)
+	})
+
+	t.Run("BLS account addresses", func(t *testing.T) {
+		accInfo := td.vault.AddressFromPath("m/12381'/21888'/2'/0")
+		sig, err := td.vault.SignAggregate(tPassword, []string{accInfo.Address}, msg)
+		require.NoError(t, err)
+
+		pub, _ := bls.PublicKeyFromString(accInfo.PublicKey)
+		assert.NoError(t, pub.Verify(msg, sig))
+	})
+}
+
+func TestProofOfPossession(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		info := td.vault.AddressFromPath("m/12381'/21888'/1'/0")
+		_, err := td.vault.Neuter().ProofOfPossession(tPassword, info.Address)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandValAddress().String()
+		_, err := td.vault.ProofOfPossession(tPassword, addr)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Account address", func(t *testing.T) {
+		info := td.vault.AddressFromPath("m/12381'/21888'/2'/0")
+		_, err := td.vault.ProofOfPossession(tPassword, info.Address)
+		assert.ErrorIs(t, err, ErrUnsupportedAddressType)
+	})
+
+	t.Run("Derived and imported keys", func(t *testing.T) {
+		derivedInfo := td.vault.AddressFromPath("m/12381'/21888'/1'/0")
+		importedInfo := td.vault.AddressInfo(td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String())
+
+		for _, info := range []*AddressInfo{derivedInfo, importedInfo} {
+			pop, err := td.vault.ProofOfPossession(tPassword, info.Address)
+			require.NoError(t, err)
+
+			pub, _ := bls.PublicKeyFromString(info.PublicKey)
+			assert.NoError(t, pub.VerifyProofOfPossession(pop))
+		}
+	})
+}
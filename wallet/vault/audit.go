@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+// AuditOperation defines the sensitive operation of an audit event.
+type AuditOperation string
+
+const (
+	AuditPrivateKeys      = AuditOperation("private-keys")       // PrivateKeys and the methods that sign
+	AuditMnemonic         = AuditOperation("mnemonic")           // Mnemonic, MnemonicSeed and SeedChecksum
+	AuditUpdatePassword   = AuditOperation("update-password")    // UpdatePassword and Rekey
+	AuditExportKeystore   = AuditOperation("export-keystore")    // ExportKeystore
+	AuditExportSeedShares = AuditOperation("export-seed-shares") // ExportSeedShares
+	AuditExportXPrv       = AuditOperation("export-xprv")        // XPrvAccount
+)
+
+// AuditEvent describes an access to the secrets of the vault.
+// It never contains the secrets.
+type AuditEvent struct {
+	Operation AuditOperation // Accessing operation
+	Time      time.Time      // Time of the access, in UTC
+	Reason    string         // Reason given by the caller, see WithAuditReason
+	Addresses []string       // Addresses of the accessed private keys, if any
+	Err       error          // Authentication error, nil on success
+}
+
+// AuditLogger records the audit events, see SetAuditLogger.
+type AuditLogger func(AuditEvent)
+
+type auditReasonKey struct{}
+
+// WithAuditReason returns a copy of the context that carries the reason for
+// accessing the secrets. The reason is recorded in the audit events of the
+// methods that take the context, like PrivateKeysCtx, MnemonicCtx and ExportKeystoreCtx.
+func WithAuditReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, auditReasonKey{}, reason)
+}
+
+// SetAuditLogger sets the function that records the accesses to the secrets,
+// like PrivateKeys, SignAtPath, Mnemonic, UpdatePassword, ExportKeystore,
+// ExportSeedShares and XPrvAccount.
+// The events are recorded on success, and on authentication failure to detect
+// guessing the password. The other failures are not recorded.
+// The logger is called synchronously, so it should not block. It is not copied
+// to the clones of the vault. Setting nil removes the logger.
+func (v *Vault) SetAuditLogger(logger AuditLogger) {
+	v.auditLogger = logger
+}
+
+// audit records the audit event of the operation, if the operation succeeded
+// or failed to authenticate.
+func (v *Vault) audit(ctx context.Context, op AuditOperation, addrs []string, err error) {
+	if v.auditLogger == nil {
+		return
+	}
+
+	if err != nil && !errors.Is(err, encrypter.ErrInvalidPassword) {
+		return
+	}
+
+	reason, _ := ctx.Value(auditReasonKey{}).(string)
+	v.auditLogger(AuditEvent{
+		Operation: op,
+		Time:      time.Now().UTC(),
+		Reason:    reason,
+		Addresses: addrs,
+		Err:       err,
+	})
+}
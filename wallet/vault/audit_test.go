@@ -0,0 +1,139 @@
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogger(t *testing.T) {
+	td := setup(t)
+
+	var events []AuditEvent
+	td.vault.SetAuditLogger(func(event AuditEvent) {
+		events = append(events, event)
+	})
+	reset := func() {
+		events = nil
+	}
+
+	t.Run("Mnemonic", func(t *testing.T) {
+		reset()
+		ctx := WithAuditReason(context.Background(), "backup")
+		_, err := td.vault.MnemonicCtx(ctx, tPassword)
+		require.NoError(t, err)
+
+		_, err = td.vault.Mnemonic("invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, AuditMnemonic, events[0].Operation)
+		assert.Equal(t, "backup", events[0].Reason)
+		assert.NoError(t, events[0].Err)
+		assert.False(t, events[0].Time.IsZero())
+
+		assert.Equal(t, AuditMnemonic, events[1].Operation)
+		assert.Empty(t, events[1].Reason)
+		assert.ErrorIs(t, events[1].Err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Private keys", func(t *testing.T) {
+		reset()
+		addr := td.vault.AddressInfos()[0].Address
+		_, err := td.vault.SignMessage(tPassword, addr, []byte("pactus"))
+		require.NoError(t, err)
+
+		require.Len(t, events, 1)
+		assert.Equal(t, AuditPrivateKeys, events[0].Operation)
+		assert.Equal(t, []string{addr}, events[0].Addresses)
+	})
+
+	t.Run("Sign at path", func(t *testing.T) {
+		reset()
+		_, err := td.vault.SignAtPath(tPassword, "m/12381'/21888'/2'/50", []byte("pactus"))
+		require.NoError(t, err)
+		_, err = td.vault.SignAtPath("invalid-password", "m/12381'/21888'/2'/50", []byte("pactus"))
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, AuditPrivateKeys, events[0].Operation)
+		assert.NoError(t, events[0].Err)
+		assert.Equal(t, AuditPrivateKeys, events[1].Operation)
+		assert.ErrorIs(t, events[1].Err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Seed and extended private key", func(t *testing.T) {
+		reset()
+		_, err := td.vault.SeedChecksum(tPassword)
+		require.NoError(t, err)
+		_, err = td.vault.XPrvAccount(tPassword, PurposeBLS12381)
+		require.NoError(t, err)
+		_, err = td.vault.XPrvAccount("invalid-password", PurposeBIP44)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+
+		// Deriving the Ed25519 addresses doesn't reveal the seed.
+		_, err = td.vault.NewEd25519AccountAddress("", tPassword)
+		require.NoError(t, err)
+
+		require.Len(t, events, 3)
+		assert.Equal(t, AuditMnemonic, events[0].Operation)
+		assert.Equal(t, AuditExportXPrv, events[1].Operation)
+		assert.NoError(t, events[1].Err)
+		assert.Equal(t, AuditExportXPrv, events[2].Operation)
+		assert.ErrorIs(t, events[2].Err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Other failures are not recorded", func(t *testing.T) {
+		reset()
+		_, err := td.vault.PrivateKeys(tPassword, []string{td.RandAccAddress().String()})
+		assert.ErrorIs(t, err, ErrAddressNotFound)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = td.vault.MnemonicCtx(ctx, tPassword)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Empty(t, events)
+	})
+
+	t.Run("Update password and export", func(t *testing.T) {
+		reset()
+		require.NoError(t, td.vault.UpdatePassword(tPassword, tPassword, rekeyOptions(8)...))
+		_, err := td.vault.ExportKeystore(tPassword)
+		require.NoError(t, err)
+		_, err = td.vault.ExportSeedShares(tPassword, 2, 3)
+		require.NoError(t, err)
+
+		require.Len(t, events, 3)
+		assert.Equal(t, AuditUpdatePassword, events[0].Operation)
+		assert.Equal(t, AuditExportKeystore, events[1].Operation)
+		assert.Equal(t, AuditExportSeedShares, events[2].Operation)
+	})
+
+	t.Run("Export with reason", func(t *testing.T) {
+		reset()
+		ctx := WithAuditReason(context.Background(), "migration")
+		_, err := td.vault.ExportKeystoreCtx(ctx, tPassword)
+		require.NoError(t, err)
+		_, err = td.vault.ExportSeedSharesCtx(ctx, tPassword, 2, 3)
+		require.NoError(t, err)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, AuditExportKeystore, events[0].Operation)
+		assert.Equal(t, "migration", events[0].Reason)
+		assert.Equal(t, AuditExportSeedShares, events[1].Operation)
+		assert.Equal(t, "migration", events[1].Reason)
+	})
+
+	t.Run("Remove the logger", func(t *testing.T) {
+		reset()
+		td.vault.SetAuditLogger(nil)
+		_, err := td.vault.Mnemonic(tPassword)
+		require.NoError(t, err)
+
+		assert.Empty(t, events)
+	})
+}
@@ -0,0 +1,92 @@
+package vault
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Backup is a self-verifying backup of the vault.
+// It holds the vault in JSON, with the secrets encrypted, the checksum of the
+// vault, and some metadata to check the restored vault.
+type Backup struct {
+	Vault        json.RawMessage `json:"vault"`         // Vault in JSON, the secrets remain encrypted
+	Checksum     string          `json:"checksum"`      // SHA-256 of the vault in hex
+	Fingerprint  string          `json:"fingerprint"`   // Fingerprint of the master public key in hex
+	Version      int             `json:"version"`       // Vault format version
+	AddressCount int             `json:"address_count"` // Number of the addresses in the vault
+	CreatedAt    time.Time       `json:"created_at"`    // Time that the backup is created
+}
+
+// Backup creates a backup of the vault.
+// The password is checked first, so the backup is known to be restorable with it.
+// Neutered vaults have no password and can be backed up with any password.
+func (v *Vault) Backup(password string) (Backup, error) {
+	if !v.IsNeutered() {
+		if err := v.VerifyPassword(password); err != nil {
+			return Backup{}, err
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	return Backup{
+		Vault:        data,
+		Checksum:     backupChecksum(data),
+		Fingerprint:  v.Fingerprint,
+		Version:      v.Version,
+		AddressCount: v.AddressCount(),
+		CreatedAt:    timeNow(),
+	}, nil
+}
+
+// RestoreBackup restores the vault from the backup.
+// The checksum is verified before decoding the vault, and it returns
+// ErrInvalidBackupChecksum if the backup is truncated or corrupted.
+// Then the fingerprint is checked against the metadata and the password is
+// checked, unless the vault is neutered.
+//
+// If the number of the restored addresses doesn't match the metadata, both the
+// vault and an AddressCountMismatchError are returned, as a warning, so the
+// caller can decide whether to use the vault.
+func RestoreBackup(b Backup, password string) (*Vault, error) {
+	if backupChecksum(b.Vault) != b.Checksum {
+		return nil, ErrInvalidBackupChecksum
+	}
+
+	vlt := new(Vault)
+	if err := json.Unmarshal(b.Vault, vlt); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	if vlt.Fingerprint != b.Fingerprint {
+		return nil, fmt.Errorf("%w: fingerprint mismatch, expected %s, got %s",
+			ErrInvalidBackup, b.Fingerprint, vlt.Fingerprint)
+	}
+
+	if !vlt.IsNeutered() {
+		if err := vlt.VerifyPassword(password); err != nil {
+			return nil, err
+		}
+	}
+
+	if vlt.AddressCount() != b.AddressCount {
+		return vlt, AddressCountMismatchError{
+			Expected: b.AddressCount,
+			Got:      vlt.AddressCount(),
+		}
+	}
+
+	return vlt, nil
+}
+
+func backupChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
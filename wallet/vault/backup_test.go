@@ -0,0 +1,109 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackup(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.Backup("wrong_password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	backup, err := td.vault.Backup(tPassword)
+	require.NoError(t, err)
+	assert.Equal(t, td.vault.Fingerprint, backup.Fingerprint)
+	assert.Equal(t, td.vault.Version, backup.Version)
+	assert.Equal(t, td.vault.AddressCount(), backup.AddressCount)
+
+	t.Run("Restore", func(t *testing.T) {
+		restored, err := RestoreBackup(backup, tPassword)
+		require.NoError(t, err)
+		assert.True(t, restored.Equal(td.vault))
+
+		mnemonic, err := restored.Mnemonic(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+	})
+
+	t.Run("Restore from JSON", func(t *testing.T) {
+		data, err := json.Marshal(backup)
+		require.NoError(t, err)
+
+		decoded := Backup{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		_, err = RestoreBackup(decoded, tPassword)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Restore with invalid password", func(t *testing.T) {
+		_, err := RestoreBackup(backup, "wrong_password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Truncated backup", func(t *testing.T) {
+		truncated := backup
+		truncated.Vault = backup.Vault[:len(backup.Vault)/2]
+
+		_, err := RestoreBackup(truncated, tPassword)
+		assert.ErrorIs(t, err, ErrInvalidBackupChecksum)
+	})
+
+	t.Run("Corrupted backup", func(t *testing.T) {
+		corrupted := backup
+		corrupted.Vault = append(json.RawMessage{}, backup.Vault...)
+		corrupted.Vault[10] ^= 0x01
+
+		_, err := RestoreBackup(corrupted, tPassword)
+		assert.ErrorIs(t, err, ErrInvalidBackupChecksum)
+	})
+
+	t.Run("Invalid vault with valid checksum", func(t *testing.T) {
+		invalid := backup
+		invalid.Vault = json.RawMessage(`{"type":"full"}`)
+		invalid.Checksum = backupChecksum(invalid.Vault)
+
+		_, err := RestoreBackup(invalid, tPassword)
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+	})
+
+	t.Run("Fingerprint mismatch", func(t *testing.T) {
+		mismatched := backup
+		mismatched.Fingerprint = "00000000"
+
+		_, err := RestoreBackup(mismatched, tPassword)
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+	})
+
+	t.Run("Address count mismatch", func(t *testing.T) {
+		mismatched := backup
+		mismatched.AddressCount++
+
+		restored, err := RestoreBackup(mismatched, tPassword)
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+		assert.ErrorIs(t, err, AddressCountMismatchError{
+			Expected: td.vault.AddressCount() + 1,
+			Got:      td.vault.AddressCount(),
+		})
+		require.NotNil(t, restored)
+		assert.Equal(t, td.vault.AddressCount(), restored.AddressCount())
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+		backup, err := neutered.Backup("")
+		require.NoError(t, err)
+
+		restored, err := RestoreBackup(backup, "")
+		require.NoError(t, err)
+		assert.True(t, restored.IsNeutered())
+		assert.True(t, restored.Equal(neutered))
+	})
+}
@@ -0,0 +1,336 @@
+package vault
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/pactus-project/pactus/util/bech32m"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"golang.org/x/exp/slices"
+)
+
+// BinaryFormatVersion is the version of the binary encoding of the vault.
+// It is independent of the vault version: the binary encoding carries the
+// vault version too, and old vaults are migrated after decoding, like JSON.
+// The version should be increased whenever the layout of the encoding changes.
+const BinaryFormatVersion = 1
+
+// vaultBinary is the binary layout of the vault.
+// The structs are encoded as CBOR arrays, so the field names are not stored
+// and the order of the fields must be kept.
+type vaultBinary struct {
+	_ struct{} `cbor:",toarray"`
+
+	Format      int
+	Version     int
+	Type        int
+	CoinType    uint32
+	Addresses   []addressInfoBinary
+	Encrypter   encrypter.Encrypter
+	KeyStore    compactText
+	Purposes    purposesBinary
+	Fingerprint string
+	Signings    map[string][]signingRecordBinary
+	DefaultAddr compactText
+	Hint        string
+	Policy      *UnlockPolicy
+}
+
+type addressInfoBinary struct {
+	_ struct{} `cbor:",toarray"`
+
+	Address     compactText
+	PublicKey   compactText
+	Label       string
+	Path        string
+	IsWatchOnly bool
+	Origin      Origin
+	Group       string
+	CreatedAt   *binaryTime
+	LastUsedAt  *binaryTime
+}
+
+type purposesBinary struct {
+	_ struct{} `cbor:",toarray"`
+
+	XPubValidator      compactText
+	XPubAccount        compactText
+	NextAccountIndex   uint32
+	NextValidatorIndex uint32
+	NextEd25519Index   uint32
+}
+
+type signingRecordBinary struct {
+	_ struct{} `cbor:",toarray"`
+
+	Height   uint32
+	Subject  string
+	Scope    string
+	SignedAt *binaryTime
+}
+
+// compactText encodes the bech32m strings, like the addresses and the keys,
+// and the base64 strings, like the encrypted key store, as raw bytes.
+// Other strings, or the strings that don't decode and encode back to the same
+// string, are encoded as text. So the encoding is lossless.
+type compactText string
+
+// bech32Text is the raw form of a bech32m string with type.
+type bech32Text struct {
+	_ struct{} `cbor:",toarray"`
+
+	HRP  string
+	Type byte
+	Data []byte
+}
+
+func (t compactText) MarshalCBOR() ([]byte, error) {
+	str := string(t)
+	hrp, typ, data, err := bech32m.DecodeToBase256WithTypeNoLimit(str)
+	if err == nil {
+		encoded, err := bech32m.EncodeFromBase256WithType(hrp, typ, data)
+		if err == nil && encoded == str {
+			return cbor.Marshal(bech32Text{HRP: hrp, Type: typ, Data: data})
+		}
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(str)
+	if err == nil && len(raw) > 0 && base64.StdEncoding.EncodeToString(raw) == str {
+		return cbor.Marshal(raw)
+	}
+
+	return cbor.Marshal(str)
+}
+
+func (t *compactText) UnmarshalCBOR(data []byte) error {
+	var str string
+	if err := cbor.Unmarshal(data, &str); err == nil {
+		*t = compactText(str)
+
+		return nil
+	}
+
+	var raw []byte
+	if err := cbor.Unmarshal(data, &raw); err == nil {
+		*t = compactText(base64.StdEncoding.EncodeToString(raw))
+
+		return nil
+	}
+
+	var bech bech32Text
+	if err := cbor.Unmarshal(data, &bech); err != nil {
+		return err
+	}
+
+	str, err := bech32m.EncodeFromBase256WithType(bech.HRP, bech.Type, bech.Data)
+	if err != nil {
+		return err
+	}
+	*t = compactText(str)
+
+	return nil
+}
+
+// binaryTime encodes the UTC times with whole seconds, like the ones set by
+// the vault, as Unix seconds.
+// Other times are encoded as RFC 3339 text, to keep the fraction and the zone.
+type binaryTime struct {
+	time.Time
+}
+
+func newBinaryTime(t time.Time) *binaryTime {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &binaryTime{Time: t}
+}
+
+func (t *binaryTime) value() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return t.Time
+}
+
+func (t binaryTime) MarshalCBOR() ([]byte, error) {
+	if t.Location() == time.UTC && t.Nanosecond() == 0 {
+		return cbor.Marshal(t.Unix())
+	}
+
+	return cbor.Marshal(t.Format(time.RFC3339Nano))
+}
+
+func (t *binaryTime) UnmarshalCBOR(data []byte) error {
+	var unix int64
+	if err := cbor.Unmarshal(data, &unix); err == nil {
+		t.Time = time.Unix(unix, 0).UTC()
+
+		return nil
+	}
+
+	var text string
+	if err := cbor.Unmarshal(data, &text); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+
+	return nil
+}
+
+// MarshalBinary encodes the vault in a compact binary form, based on CBOR.
+// It keeps all the fields of the vault, like MarshalJSON, so a vault that is
+// decoded from the binary form encodes to the same JSON.
+// The output is deterministic and it starts with BinaryFormatVersion.
+// The secrets remain encrypted in the key store.
+func (v *Vault) MarshalBinary() ([]byte, error) {
+	addrs := make([]string, 0, len(v.Addresses))
+	for addr := range v.Addresses {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+
+	infos := make([]addressInfoBinary, 0, len(addrs))
+	for _, addr := range addrs {
+		info := v.Addresses[addr]
+		infos = append(infos, addressInfoBinary{
+			Address:     compactText(info.Address),
+			PublicKey:   compactText(info.PublicKey),
+			Label:       info.Label,
+			Path:        info.Path,
+			IsWatchOnly: info.IsWatchOnly,
+			Origin:      info.Origin,
+			Group:       info.Group,
+			CreatedAt:   newBinaryTime(info.CreatedAt),
+			LastUsedAt:  newBinaryTime(info.LastUsedAt),
+		})
+	}
+
+	var signings map[string][]signingRecordBinary
+	if len(v.Signings) > 0 {
+		signings = make(map[string][]signingRecordBinary, len(v.Signings))
+		for addr, records := range v.Signings {
+			encoded := make([]signingRecordBinary, 0, len(records))
+			for _, rec := range records {
+				encoded = append(encoded, signingRecordBinary{
+					Height:   rec.Height,
+					Subject:  rec.Subject,
+					Scope:    rec.Scope,
+					SignedAt: newBinaryTime(rec.SignedAt),
+				})
+			}
+			signings[addr] = encoded
+		}
+	}
+
+	encoded := vaultBinary{
+		Format:    BinaryFormatVersion,
+		Version:   v.Version,
+		Type:      v.Type,
+		CoinType:  v.CoinType,
+		Addresses: infos,
+		Encrypter: v.Encrypter,
+		KeyStore:  compactText(v.KeyStore),
+		Purposes: purposesBinary{
+			XPubValidator:      compactText(v.Purposes.PurposeBLS.XPubValidator),
+			XPubAccount:        compactText(v.Purposes.PurposeBLS.XPubAccount),
+			NextAccountIndex:   v.Purposes.PurposeBLS.NextAccountIndex,
+			NextValidatorIndex: v.Purposes.PurposeBLS.NextValidatorIndex,
+			NextEd25519Index:   v.Purposes.PurposeBIP44.NextEd25519Index,
+		},
+		Fingerprint: v.Fingerprint,
+		Signings:    signings,
+		DefaultAddr: compactText(v.DefaultAddr),
+		Hint:        v.Hint,
+		Policy:      v.UnlockPolicy,
+	}
+
+	encMode, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		return nil, err
+	}
+
+	return encMode.Marshal(encoded)
+}
+
+// UnmarshalBinary decodes the vault from the binary form of MarshalBinary.
+// Vaults in older formats are migrated to the current version.
+// It returns ErrInvalidVaultBinary if the data is malformed or its binary
+// format version is not supported.
+func (v *Vault) UnmarshalBinary(data []byte) error {
+	decoded := new(vaultBinary)
+	if err := cbor.Unmarshal(data, decoded); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVaultBinary, err)
+	}
+
+	if decoded.Format != BinaryFormatVersion {
+		return fmt.Errorf("%w: unsupported format %d", ErrInvalidVaultBinary, decoded.Format)
+	}
+
+	addresses := make(map[string]AddressInfo, len(decoded.Addresses))
+	for _, info := range decoded.Addresses {
+		addresses[string(info.Address)] = AddressInfo{
+			Address:     string(info.Address),
+			PublicKey:   string(info.PublicKey),
+			Label:       info.Label,
+			Path:        info.Path,
+			IsWatchOnly: info.IsWatchOnly,
+			Origin:      info.Origin,
+			Group:       info.Group,
+			CreatedAt:   info.CreatedAt.value(),
+			LastUsedAt:  info.LastUsedAt.value(),
+		}
+	}
+
+	var signings map[string][]SigningRecord
+	if len(decoded.Signings) > 0 {
+		signings = make(map[string][]SigningRecord, len(decoded.Signings))
+		for addr, records := range decoded.Signings {
+			decodedRecords := make([]SigningRecord, 0, len(records))
+			for _, rec := range records {
+				decodedRecords = append(decodedRecords, SigningRecord{
+					Height:   rec.Height,
+					Subject:  rec.Subject,
+					Scope:    rec.Scope,
+					SignedAt: rec.SignedAt.value(),
+				})
+			}
+			signings[addr] = decodedRecords
+		}
+	}
+
+	*v = Vault{
+		Version:   decoded.Version,
+		Type:      decoded.Type,
+		CoinType:  decoded.CoinType,
+		Addresses: addresses,
+		Encrypter: decoded.Encrypter,
+		KeyStore:  string(decoded.KeyStore),
+		Purposes: purposes{
+			PurposeBLS: purposeBLS{
+				XPubValidator:      string(decoded.Purposes.XPubValidator),
+				XPubAccount:        string(decoded.Purposes.XPubAccount),
+				NextAccountIndex:   decoded.Purposes.NextAccountIndex,
+				NextValidatorIndex: decoded.Purposes.NextValidatorIndex,
+			},
+			PurposeBIP44: purposeBIP44{
+				NextEd25519Index: decoded.Purposes.NextEd25519Index,
+			},
+		},
+		Fingerprint:  decoded.Fingerprint,
+		Signings:     signings,
+		DefaultAddr:  string(decoded.DefaultAddr),
+		Hint:         decoded.Hint,
+		UnlockPolicy: decoded.Policy,
+	}
+
+	return migrate(v)
+}
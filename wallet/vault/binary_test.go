@@ -0,0 +1,143 @@
+package vault
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultBinary(t *testing.T) {
+	td := setup(t)
+
+	valAddr := td.vault.AddressesByLabel("validator-address")[0].Address
+	require.NoError(t, td.vault.RecordSigning(valAddr, signingContext(td.RandHash().Bytes(), 10, 0)))
+	require.NoError(t, td.vault.SetGroup(valAddr, "Treasury/Cold"))
+	require.NoError(t, td.vault.TouchAddress(valAddr))
+	pub, _ := td.RandEd25519KeyPair()
+	_, err := td.vault.ImportWatchOnlyPublicKey(pub, "watch-only")
+	require.NoError(t, err)
+
+	// A time with fraction and zone, like the ones in the vaults edited by hand.
+	accAddr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+	info := td.vault.Addresses[accAddr]
+	info.CreatedAt = time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.FixedZone("", 3600))
+	td.vault.Addresses[accAddr] = info
+
+	jsonData, err := json.Marshal(td.vault)
+	require.NoError(t, err)
+
+	data, err := td.vault.MarshalBinary()
+	require.NoError(t, err)
+
+	t.Run("Round trip", func(t *testing.T) {
+		restored := new(Vault)
+		require.NoError(t, restored.UnmarshalBinary(data))
+
+		assert.True(t, restored.Equal(td.vault))
+		assert.Equal(t, td.vault.Signings, restored.Signings)
+
+		mnemonic, err := restored.Mnemonic(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+
+		restoredJSON, err := json.Marshal(restored)
+		require.NoError(t, err)
+		assert.Equal(t, string(jsonData), string(restoredJSON))
+
+		fromJSON := new(Vault)
+		require.NoError(t, json.Unmarshal(jsonData, fromJSON))
+		data2, err := fromJSON.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, data, data2)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+		neuteredData, err := neutered.MarshalBinary()
+		require.NoError(t, err)
+
+		restored := new(Vault)
+		require.NoError(t, restored.UnmarshalBinary(neuteredData))
+		assert.True(t, restored.IsNeutered())
+		assert.True(t, restored.Equal(neutered))
+	})
+
+	t.Run("Smaller than JSON", func(t *testing.T) {
+		t.Logf("JSON: %d bytes, binary: %d bytes", len(jsonData), len(data))
+		assert.Less(t, len(data), len(jsonData)*3/5)
+	})
+
+	t.Run("Deterministic output", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			data2, err := td.vault.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, data, data2)
+		}
+	})
+
+	t.Run("Migration", func(t *testing.T) {
+		old := td.vault.Clone()
+		old.Version = VaultVersion2
+		oldData, err := old.MarshalBinary()
+		require.NoError(t, err)
+
+		restored := new(Vault)
+		require.NoError(t, restored.UnmarshalBinary(oldData))
+		assert.True(t, restored.IsMigrated())
+		assert.Equal(t, CurrentVaultVersion, restored.Version)
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		decoded := new(vaultBinary)
+		require.NoError(t, cbor.Unmarshal(data, decoded))
+		decoded.Format = BinaryFormatVersion + 1
+		newerData, err := cbor.Marshal(decoded)
+		require.NoError(t, err)
+
+		err = new(Vault).UnmarshalBinary(newerData)
+		assert.ErrorIs(t, err, ErrInvalidVaultBinary)
+	})
+
+	t.Run("Invalid data", func(t *testing.T) {
+		inputs := [][]byte{
+			nil,
+			{0x01},
+			data[:len(data)/2],
+			jsonData,
+		}
+		for _, input := range inputs {
+			err := new(Vault).UnmarshalBinary(input)
+			assert.ErrorIs(t, err, ErrInvalidVaultBinary)
+		}
+	})
+}
+
+func TestCompactText(t *testing.T) {
+	td := setup(t)
+
+	pub, _ := td.RandBLSKeyPair()
+	texts := []string{
+		"",
+		"plain text",
+		pub.String(),
+		td.RandAccAddress().String(),
+		strings.ToUpper(pub.String()),
+		"AQID",
+		"AQI=",
+		"AQI",
+		td.vault.Purposes.PurposeBLS.XPubAccount,
+	}
+	for _, text := range texts {
+		data, err := cbor.Marshal(compactText(text))
+		require.NoError(t, err)
+
+		var decoded compactText
+		require.NoError(t, cbor.Unmarshal(data, &decoded))
+		assert.Equal(t, text, string(decoded))
+	}
+}
@@ -0,0 +1,58 @@
+package vault
+
+import "github.com/pactus-project/pactus/wallet/addresspath"
+
+// Compact rebuilds the address map and the internal indexes of the vault.
+// Go maps never shrink, so after removing many addresses, the map still holds
+// the buckets of the removed addresses, and iterating over it, e.g. in
+// AddressInfos, visits the empty buckets as well. The cache of the parsed paths
+// and the public keys also keep the entries of the removed addresses.
+// It is worth calling after removing a large part of the addresses, or before
+// keeping a large vault, e.g. with 10,000 addresses or more, in memory for a long time.
+// The content of the vault is not changed.
+func (v *Vault) Compact() {
+	addrs := make(map[string]AddressInfo, len(v.Addresses))
+	for addr, info := range v.Addresses {
+		addrs[addr] = info
+	}
+	v.Addresses = addrs
+
+	if len(v.Signings) != 0 {
+		signings := make(map[string][]SigningRecord, len(v.Signings))
+		for addr, records := range v.Signings {
+			signings[addr] = records
+		}
+		v.Signings = signings
+	}
+
+	if v.paths != nil {
+		v.paths.lock.Lock()
+		defer v.paths.lock.Unlock()
+
+		paths := make(map[string]addresspath.Path, len(v.Addresses))
+		for _, info := range v.Addresses {
+			if path, ok := v.paths.paths[info.Path]; ok {
+				paths[info.Path] = path
+			}
+		}
+		v.paths.paths = paths
+	}
+
+	if v.pubKeys != nil {
+		v.pubKeys.lock.Lock()
+		defer v.pubKeys.lock.Unlock()
+
+		pubStrs := make(map[string]bool, len(v.Addresses))
+		for _, info := range v.Addresses {
+			pubStrs[info.PublicKey] = true
+		}
+
+		pubKeys := make(map[pubKeyCacheKey][]byte, len(v.Addresses))
+		for key, data := range v.pubKeys.keys {
+			if pubStrs[key.pubStr] {
+				pubKeys[key] = data
+			}
+		}
+		v.pubKeys.keys = pubKeys
+	}
+}
@@ -0,0 +1,41 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	td := setup(t)
+
+	infos, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 100, "")
+	require.NoError(t, err)
+	require.NoError(t, td.vault.SetGroup(infos[0].Address, "savings"))
+	for _, info := range infos {
+		_, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+	}
+	for i := len(infos) - 1; i > 0; i-- {
+		require.NoError(t, td.vault.RemoveAddress(infos[i].Address, tPassword, false))
+	}
+
+	expected := td.vault.Clone()
+	td.vault.Compact()
+
+	assert.Equal(t, expected.Addresses, td.vault.Addresses)
+	assert.Equal(t, expected.AddressInfos(), td.vault.AddressInfos())
+	assert.True(t, expected.Equal(td.vault))
+	assert.Len(t, td.vault.paths.paths, len(td.vault.Addresses))
+	assert.Len(t, td.vault.pubKeys.keys, 1)
+
+	for _, info := range expected.AddressInfos() {
+		got := td.vault.AddressInfo(info.Address)
+		require.NotNil(t, got)
+		assert.Equal(t, info.Label, got.Label)
+		assert.Equal(t, info.Path, got.Path)
+		assert.Equal(t, info.Group, got.Group)
+	}
+}
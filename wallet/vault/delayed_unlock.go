@@ -0,0 +1,153 @@
+package vault
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+// UnlockPolicy is the delayed unlock policy of the vault.
+// With the policy, the secrets can't be accessed by the password directly.
+// Instead, an unlock is requested by the password and it can be redeemed
+// only after the delay, which deters the smash-and-grab attacks.
+//
+// The policy is enforced by this package only: whoever can edit the vault file
+// can remove it, so it doesn't replace a strong password.
+type UnlockPolicy struct {
+	Delay  time.Duration `json:"delay"`  // Delay between requesting and redeeming an unlock
+	TTL    time.Duration `json:"ttl"`    // Duration that the vault stays unlocked after redeeming
+	Reason string        `json:"reason"` // Human-readable reason of the policy
+}
+
+func (p *UnlockPolicy) clone() *UnlockPolicy {
+	if p == nil {
+		return nil
+	}
+	cloned := *p
+
+	return &cloned
+}
+
+// UnlockTicket is a requested unlock that can be redeemed after the delay of
+// the unlock policy, see RequestUnlock.
+// It keeps the key derived from the password, so it should be redeemed or
+// discarded soon.
+type UnlockTicket struct {
+	lk       sync.Mutex
+	readyAt  time.Time
+	key      []byte
+	keyStore string
+}
+
+// ReadyAt returns the time that the ticket can be redeemed.
+func (t *UnlockTicket) ReadyAt() time.Time {
+	return t.readyAt
+}
+
+// SetUnlockPolicy sets the delayed unlock policy of the vault.
+// A non-positive delay removes the policy.
+// The password is required to change the policy. If the vault already has a
+// policy, the vault should be unlocked by redeeming a ticket, and the empty
+// password should be used.
+// It returns ErrInvalidDuration if the delay is set and the ttl is not positive.
+// The policy is only set for the encrypted vaults, and it is removed when the
+// encryption is disabled.
+func (v *Vault) SetUnlockPolicy(password string, delay, ttl time.Duration, reason string) error {
+	if delay > 0 && ttl <= 0 {
+		return ErrInvalidDuration
+	}
+
+	if delay > 0 && !v.IsEncrypted() {
+		return encrypter.ErrNotEncrypted
+	}
+
+	if _, err := v.decryptKeyStore(password); err != nil {
+		return err
+	}
+
+	if delay <= 0 {
+		v.UnlockPolicy = nil
+
+		return nil
+	}
+
+	reason, err := validateLabel(reason)
+	if err != nil {
+		return err
+	}
+
+	v.UnlockPolicy = &UnlockPolicy{
+		Delay:  delay,
+		TTL:    ttl,
+		Reason: reason,
+	}
+
+	return nil
+}
+
+// RequestUnlock verifies the password and returns a ticket that can be
+// redeemed by RedeemUnlock after the delay of the unlock policy.
+// The password is checked immediately, so a wrong password is reported
+// without waiting for the delay.
+// It returns ErrNoUnlockPolicy if the vault has no delayed unlock policy,
+// in this case use Unlock.
+func (v *Vault) RequestUnlock(password string) (*UnlockTicket, error) {
+	if v.IsNeutered() {
+		return nil, ErrNeutered
+	}
+
+	if v.UnlockPolicy == nil {
+		return nil, ErrNoUnlockPolicy
+	}
+
+	key, err := v.Encrypter.DeriveKey(v.KeyStore, password)
+	if err != nil {
+		return nil, err
+	}
+
+	// timeNow truncates to seconds, so the request time is rounded up to make
+	// sure the whole delay passes.
+	return &UnlockTicket{
+		readyAt:  timeNow().Add(time.Second + v.UnlockPolicy.Delay),
+		key:      key,
+		keyStore: v.KeyStore,
+	}, nil
+}
+
+// RedeemUnlock unlocks the vault by the ticket, for the ttl of the unlock policy.
+// Then the secrets can be accessed by the empty password, like Unlock.
+// The ticket can be redeemed once. It returns ErrUnlockNotReady if the delay is
+// not passed yet, and the ticket can be redeemed later.
+// It returns ErrInvalidTicket if the ticket is already redeemed or the key
+// store is changed after the request, like by updating the password.
+// It returns ErrNoUnlockPolicy if the policy is removed after the request.
+func (v *Vault) RedeemUnlock(ticket *UnlockTicket) error {
+	if v.UnlockPolicy == nil {
+		return ErrNoUnlockPolicy
+	}
+
+	ticket.lk.Lock()
+	defer ticket.lk.Unlock()
+
+	if ticket.key == nil {
+		return ErrInvalidTicket
+	}
+
+	if ticket.keyStore != v.KeyStore {
+		clear(ticket.key)
+		ticket.key = nil
+
+		return ErrInvalidTicket
+	}
+
+	if timeNow().Before(ticket.readyAt) {
+		return ErrUnlockNotReady
+	}
+
+	key := ticket.key
+	ticket.key = nil
+	v.startSession(key, v.UnlockPolicy.TTL)
+
+	return nil
+}
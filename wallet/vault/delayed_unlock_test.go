@@ -0,0 +1,164 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// advanceClock moves the clock of the package forward until the test ends.
+func advanceClock(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	now := timeNow
+	timeNow = func() time.Time {
+		return now().Add(d)
+	}
+	t.Cleanup(func() {
+		timeNow = now
+	})
+}
+
+func TestSetUnlockPolicy(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid ttl", func(t *testing.T) {
+		err := td.vault.SetUnlockPolicy(tPassword, time.Hour, 0, "")
+		assert.ErrorIs(t, err, ErrInvalidDuration)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		err := td.vault.SetUnlockPolicy("wrong_password", time.Hour, time.Minute, "")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.Nil(t, td.vault.UnlockPolicy)
+	})
+
+	t.Run("Not encrypted vault", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		require.NoError(t, vlt.UpdatePassword(tPassword, ""))
+
+		err := vlt.SetUnlockPolicy("", time.Hour, time.Minute, "")
+		assert.ErrorIs(t, err, encrypter.ErrNotEncrypted)
+	})
+
+	t.Run("Survives serialization", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		require.NoError(t, vlt.SetUnlockPolicy(tPassword, time.Hour, time.Minute, "treasury funds"))
+
+		data, err := json.Marshal(vlt)
+		require.NoError(t, err)
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, vlt.UnlockPolicy, restored.UnlockPolicy)
+
+		data, err = vlt.MarshalBinary()
+		require.NoError(t, err)
+		restored = new(Vault)
+		require.NoError(t, restored.UnmarshalBinary(data))
+		assert.Equal(t, vlt.UnlockPolicy, restored.UnlockPolicy)
+
+		assert.Nil(t, vlt.Neuter().UnlockPolicy)
+	})
+
+	t.Run("Removing the policy needs an unlock", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		require.NoError(t, vlt.SetUnlockPolicy(tPassword, 10*time.Millisecond, time.Minute, "treasury"))
+
+		err := vlt.SetUnlockPolicy(tPassword, 0, 0, "")
+		assert.ErrorIs(t, err, ErrDelayedUnlock)
+
+		ticket, err := vlt.RequestUnlock(tPassword)
+		require.NoError(t, err)
+		advanceClock(t, 10*time.Millisecond+time.Second)
+		require.NoError(t, vlt.RedeemUnlock(ticket))
+
+		require.NoError(t, vlt.SetUnlockPolicy("", 0, 0, ""))
+		assert.Nil(t, vlt.UnlockPolicy)
+	})
+}
+
+func TestDelayedUnlock(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressInfos()[0].Address
+	delay := time.Hour
+	require.NoError(t, td.vault.SetUnlockPolicy(tPassword, delay, time.Minute, "treasury"))
+	assert.Equal(t, "treasury", td.vault.UnlockPolicy.Reason)
+
+	t.Run("Secrets are not accessible by the password", func(t *testing.T) {
+		_, err := td.vault.PrivateKeys(tPassword, []string{addr})
+		assert.ErrorIs(t, err, ErrDelayedUnlock)
+
+		_, err = td.vault.Mnemonic(tPassword)
+		assert.ErrorIs(t, err, ErrDelayedUnlock)
+
+		err = td.vault.Unlock(tPassword, time.Minute)
+		assert.ErrorIs(t, err, ErrDelayedUnlock)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.RequestUnlock("wrong_password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("No policy", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		vlt.UnlockPolicy = nil
+
+		_, err := vlt.RequestUnlock(tPassword)
+		assert.ErrorIs(t, err, ErrNoUnlockPolicy)
+	})
+
+	t.Run("Redeeming early fails", func(t *testing.T) {
+		ticket, err := td.vault.RequestUnlock(tPassword)
+		require.NoError(t, err)
+
+		err = td.vault.RedeemUnlock(ticket)
+		assert.ErrorIs(t, err, ErrUnlockNotReady)
+
+		advanceClock(t, delay-time.Second)
+		err = td.vault.RedeemUnlock(ticket)
+		assert.ErrorIs(t, err, ErrUnlockNotReady)
+		assert.False(t, td.vault.IsUnlocked())
+
+		_, err = td.vault.PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, ErrDelayedUnlock)
+	})
+
+	t.Run("Redeeming after the delay succeeds", func(t *testing.T) {
+		ticket, err := td.vault.RequestUnlock(tPassword)
+		require.NoError(t, err)
+		assert.False(t, ticket.ReadyAt().Before(time.Now().Add(delay)))
+
+		advanceClock(t, delay+time.Second)
+		require.NoError(t, td.vault.RedeemUnlock(ticket))
+		assert.True(t, td.vault.IsUnlocked())
+
+		prvs, err := td.vault.PrivateKeys("", []string{addr})
+		require.NoError(t, err)
+		assert.Len(t, prvs, 1)
+
+		// The ticket can be redeemed once.
+		err = td.vault.RedeemUnlock(ticket)
+		assert.ErrorIs(t, err, ErrInvalidTicket)
+
+		td.vault.Lock()
+		_, err = td.vault.PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, ErrDelayedUnlock)
+	})
+
+	t.Run("Key store is changed after the request", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		ticket, err := vlt.RequestUnlock(tPassword)
+		require.NoError(t, err)
+
+		vlt.KeyStore = ""
+		advanceClock(t, delay+time.Second)
+		err = vlt.RedeemUnlock(ticket)
+		assert.ErrorIs(t, err, ErrInvalidTicket)
+	})
+}
@@ -0,0 +1,266 @@
+package vault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+//
+// Output Descriptors
+//
+// The descriptors are adapted from BIP-380 for Pactus purposes.
+// Each descriptor is written on a separate line and has this format:
+//
+//   <address_type>([<fingerprint>/<path>]<key>)
+//
+// * `address_type`: One of `validator`, `bls_account` or `ed25519_account`.
+// * `fingerprint`: The master key fingerprint in hex.
+// * `path`: The derivation path of the key, without the leading `m`.
+// * `key`: Either an extended public key followed by `/*`, which derives
+//    all addresses of this type, or a public key of a single address.
+//
+// Example:
+//
+//   bls_account([a1b2c3d4/12381'/21888'/2']xpublic1.../*)
+//   ed25519_account([a1b2c3d4/44'/21888'/3'/0']public1...)
+//
+// References:
+//  - https://github.com/bitcoin/bips/blob/master/bip-0380.mediawiki
+//
+
+var descriptorRegex = regexp.MustCompile(
+	`^([a-z0-9_]+)\(\[([0-9a-f]{8})((?:/[0-9]+'?)+)\]([a-z0-9]+)(/\*)?\)$`)
+
+// unknownFingerprint is used when the master fingerprint is not known.
+const unknownFingerprint = "00000000"
+
+// ExportDescriptor returns the output descriptors of the given purpose.
+// For the BLS purpose, it returns the extended public keys of the validator
+// and account addresses. For the other purposes, it returns the public key
+// of each address, since they can't be derived from an extended public key.
+func (v *Vault) ExportDescriptor(purpose uint32) (string, error) {
+	fingerprint := v.Fingerprint
+	if fingerprint == "" {
+		fingerprint = unknownFingerprint
+	}
+
+	descriptors := make([]string, 0)
+	switch purpose {
+	case PurposeBLS12381:
+		validatorPath := addresspath.NewPath(_H(PurposeBLS12381), _H(v.CoinType), _H(crypto.AddressTypeValidator))
+		accountPath := addresspath.NewPath(_H(PurposeBLS12381), _H(v.CoinType), _H(crypto.AddressTypeBLSAccount))
+
+		descriptors = append(descriptors,
+			makeDescriptor(crypto.AddressTypeValidator, fingerprint, validatorPath,
+				v.Purposes.PurposeBLS.XPubValidator+"/*"),
+			makeDescriptor(crypto.AddressTypeBLSAccount, fingerprint, accountPath,
+				v.Purposes.PurposeBLS.XPubAccount+"/*"))
+
+	case PurposeBIP44, PurposeImportPrivateKey:
+		for _, info := range v.AddressInfos() {
+			addrPath, err := addresspath.FromString(info.Path)
+			if err != nil {
+				return "", err
+			}
+
+			if addrPath.Purpose() != _H(purpose) {
+				continue
+			}
+
+			addressType := crypto.AddressType(_N(addrPath.AddressType()))
+			descriptors = append(descriptors,
+				makeDescriptor(addressType, fingerprint, addrPath, info.PublicKey))
+		}
+
+	default:
+		return "", ErrUnsupportedPurpose
+	}
+
+	return strings.Join(descriptors, "\n"), nil
+}
+
+// ImportDescriptor creates a neutered vault from the output descriptors.
+// The descriptors should have the same fingerprint and coin type.
+// Addresses of an extended public key are not derived.
+func ImportDescriptor(descriptor string) (*Vault, error) {
+	vlt := &Vault{
+		Version:   CurrentVaultVersion,
+		Type:      TypeNeutered,
+		Encrypter: encrypter.NopeEncrypter(),
+		Addresses: make(map[string]AddressInfo),
+		KeyStore:  "",
+	}
+
+	lines := strings.Fields(descriptor)
+	if len(lines) == 0 {
+		return nil, ErrInvalidDescriptor
+	}
+
+	for i, line := range lines {
+		matches := descriptorRegex.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, ErrInvalidDescriptor
+		}
+
+		addressType, err := addressTypeFromString(matches[1])
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint := matches[2]
+		keyPath, err := addresspath.FromString("m" + matches[3])
+		if err != nil {
+			return nil, err
+		}
+		if len(keyPath) < 3 || keyPath[2] != _H(addressType) {
+			return nil, ErrInvalidPath
+		}
+		coinType := _N(keyPath[1])
+
+		if i == 0 {
+			vlt.CoinType = coinType
+			if fingerprint != unknownFingerprint {
+				vlt.Fingerprint = fingerprint
+			}
+		} else {
+			if coinType != vlt.CoinType {
+				return nil, ErrInvalidCoinType
+			}
+			if fingerprint != vlt.Fingerprint && fingerprint != unknownFingerprint {
+				return nil, ErrInvalidDescriptor
+			}
+		}
+
+		isExtended := matches[5] != ""
+		if isExtended {
+			err = vlt.importDescriptorXPub(addressType, keyPath, matches[4])
+		} else {
+			err = vlt.importDescriptorPublicKey(addressType, keyPath, matches[4])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vlt, nil
+}
+
+func (v *Vault) importDescriptorXPub(addressType crypto.AddressType,
+	keyPath addresspath.Path, xPub string,
+) error {
+	if len(keyPath) != 3 || keyPath.Purpose() != _H(PurposeBLS12381) {
+		return ErrInvalidPath
+	}
+
+	ext, err := blshdkeychain.NewKeyFromString(xPub)
+	if err != nil {
+		return err
+	}
+
+	if ext.IsPrivate() {
+		return ErrInvalidKey
+	}
+
+	if addresspath.NewPath(ext.Path()...).String() != keyPath.String() {
+		return ErrInvalidPath
+	}
+
+	switch addressType {
+	case crypto.AddressTypeValidator:
+		v.Purposes.PurposeBLS.XPubValidator = xPub
+	case crypto.AddressTypeBLSAccount:
+		v.Purposes.PurposeBLS.XPubAccount = xPub
+	default:
+		return ErrUnsupportedAddressType
+	}
+
+	return nil
+}
+
+func (v *Vault) importDescriptorPublicKey(addressType crypto.AddressType,
+	keyPath addresspath.Path, pubStr string,
+) error {
+	if len(keyPath) != 4 {
+		return ErrInvalidPath
+	}
+
+	var addr crypto.Address
+	switch addressType {
+	case crypto.AddressTypeValidator:
+		pub, err := bls.PublicKeyFromString(pubStr)
+		if err != nil {
+			return err
+		}
+		addr = pub.ValidatorAddress()
+
+	case crypto.AddressTypeBLSAccount:
+		pub, err := bls.PublicKeyFromString(pubStr)
+		if err != nil {
+			return err
+		}
+		addr = pub.AccountAddress()
+
+	case crypto.AddressTypeEd25519Account:
+		pub, err := ed25519.PublicKeyFromString(pubStr)
+		if err != nil {
+			return err
+		}
+		addr = pub.AccountAddress()
+
+	default:
+		return ErrUnsupportedAddressType
+	}
+
+	origin := OriginHDDerived
+	if keyPath.Purpose() == _H(PurposeImportPrivateKey) {
+		origin = OriginImported
+	}
+
+	v.Addresses[addr.String()] = AddressInfo{
+		Address:   addr.String(),
+		PublicKey: pubStr,
+		Path:      keyPath.String(),
+		Origin:    origin,
+		CreatedAt: timeNow(),
+	}
+
+	if keyPath.Purpose() == _H(PurposeBIP44) {
+		nextIndex := _N(keyPath.AddressIndex()) + 1
+		if nextIndex > v.Purposes.PurposeBIP44.NextEd25519Index {
+			v.Purposes.PurposeBIP44.NextEd25519Index = nextIndex
+		}
+	}
+	v.notify(EventAddressAdded, addr.String())
+
+	return nil
+}
+
+func makeDescriptor(addressType crypto.AddressType, fingerprint string,
+	keyPath addresspath.Path, key string,
+) string {
+	// Remove the leading `m` from the path.
+	return fmt.Sprintf("%s([%s%s]%s)", addressType.String(), fingerprint,
+		strings.TrimPrefix(keyPath.String(), "m"), key)
+}
+
+func addressTypeFromString(str string) (crypto.AddressType, error) {
+	for _, addressType := range []crypto.AddressType{
+		crypto.AddressTypeValidator,
+		crypto.AddressTypeBLSAccount,
+		crypto.AddressTypeEd25519Account,
+	} {
+		if addressType.String() == str {
+			return addressType, nil
+		}
+	}
+
+	return 0, ErrUnsupportedAddressType
+}
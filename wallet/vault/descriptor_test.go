@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDescriptor(t *testing.T) {
+	td := setup(t)
+
+	t.Run("BLS purpose", func(t *testing.T) {
+		desc, err := td.vault.ExportDescriptor(PurposeBLS12381)
+		assert.NoError(t, err)
+
+		lines := strings.Split(desc, "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, fmt.Sprintf("validator([%s/12381'/21888'/1']%s/*)",
+			td.vault.Fingerprint, td.vault.Purposes.PurposeBLS.XPubValidator), lines[0])
+		assert.Equal(t, fmt.Sprintf("bls_account([%s/12381'/21888'/2']%s/*)",
+			td.vault.Fingerprint, td.vault.Purposes.PurposeBLS.XPubAccount), lines[1])
+	})
+
+	t.Run("BIP44 purpose", func(t *testing.T) {
+		desc, err := td.vault.ExportDescriptor(PurposeBIP44)
+		assert.NoError(t, err)
+
+		info := td.vault.AddressFromPath("m/44'/21888'/3'/0'")
+		assert.Equal(t, fmt.Sprintf("ed25519_account([%s/44'/21888'/3'/0']%s)",
+			td.vault.Fingerprint, info.PublicKey), desc)
+	})
+
+	t.Run("Import purpose", func(t *testing.T) {
+		desc, err := td.vault.ExportDescriptor(PurposeImportPrivateKey)
+		assert.NoError(t, err)
+
+		lines := strings.Split(desc, "\n")
+		assert.Len(t, lines, 3)
+		assert.Equal(t, fmt.Sprintf("validator([%s/65535'/21888'/1'/0']%s)",
+			td.vault.Fingerprint, td.importedBLSPrv.PublicKeyNative().String()), lines[0])
+	})
+
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		_, err := td.vault.ExportDescriptor(1)
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+	})
+
+	t.Run("Unknown fingerprint", func(t *testing.T) {
+		vlt := td.vault.Neuter()
+		vlt.Fingerprint = ""
+
+		desc, err := vlt.ExportDescriptor(PurposeBIP44)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(desc, "ed25519_account([00000000/44'/21888'/3'/0']"))
+	})
+}
+
+func TestImportDescriptor(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Round trip", func(t *testing.T) {
+		descriptors := make([]string, 0)
+		for _, purpose := range []uint32{PurposeBLS12381, PurposeBIP44, PurposeImportPrivateKey} {
+			desc, err := td.vault.ExportDescriptor(purpose)
+			require.NoError(t, err)
+			descriptors = append(descriptors, desc)
+		}
+
+		vlt, err := ImportDescriptor(strings.Join(descriptors, "\n"))
+		assert.NoError(t, err)
+
+		assert.True(t, vlt.IsNeutered())
+		assert.Equal(t, td.vault.CoinType, vlt.CoinType)
+		assert.Equal(t, td.vault.Fingerprint, vlt.Fingerprint)
+		assert.Equal(t, td.vault.Purposes.PurposeBLS.XPubValidator, vlt.Purposes.PurposeBLS.XPubValidator)
+		assert.Equal(t, td.vault.Purposes.PurposeBLS.XPubAccount, vlt.Purposes.PurposeBLS.XPubAccount)
+		assert.Equal(t, td.vault.Purposes.PurposeBIP44, vlt.Purposes.PurposeBIP44)
+		assert.Equal(t, 4, vlt.AddressCount())
+
+		// The recreated vault derives the same addresses.
+		info, err := vlt.NewBLSAccountAddress("")
+		assert.NoError(t, err)
+		assert.Equal(t, td.vault.AddressFromPath(info.Path).Address, info.Address)
+	})
+
+	t.Run("Invalid descriptors", func(t *testing.T) {
+		xPub := td.vault.Purposes.PurposeBLS.XPubAccount
+		tests := []struct {
+			desc    string
+			wantErr error
+		}{
+			{"", ErrInvalidDescriptor},
+			{"bls_account(" + xPub + "/*)", ErrInvalidDescriptor},
+			{"bls_account([1234/12381'/21888'/2']" + xPub + "/*)", ErrInvalidDescriptor},
+			{"treasury([12345678/12381'/21888'/2']" + xPub + "/*)", ErrUnsupportedAddressType},
+			{"validator([12345678/12381'/21888'/2']" + xPub + "/*)", ErrInvalidPath},
+			{"bls_account([12345678/12381'/21777'/2']" + xPub + "/*)", ErrInvalidPath},
+			{"bls_account([12345678/44'/21888'/2']" + xPub + "/*)", ErrInvalidPath},
+		}
+
+		for no, tt := range tests {
+			_, err := ImportDescriptor(tt.desc)
+			assert.ErrorIs(t, err, tt.wantErr, "test %v failed", no)
+		}
+	})
+
+	t.Run("Mismatched coin types", func(t *testing.T) {
+		testnetVault, _ := CreateVaultFromMnemonic(td.mnemonic, 21777)
+		desc1, _ := td.vault.ExportDescriptor(PurposeBLS12381)
+		desc2, _ := testnetVault.ExportDescriptor(PurposeBLS12381)
+
+		_, err := ImportDescriptor(desc1 + "\n" + desc2)
+		assert.ErrorIs(t, err, ErrInvalidCoinType)
+	})
+}
@@ -6,6 +6,18 @@ import (
 )
 
 var (
+	// ErrAddressNotFound describes an error in which the address doesn't exist
+	// in wallet. AddressNotFoundError wraps it.
+	ErrAddressNotFound = errors.New("address not found")
+
+	// ErrPathNotFound describes an error in which no address is derived at the
+	// path in wallet. PathNotFoundError wraps it.
+	ErrPathNotFound = errors.New("no address found at path")
+
+	// ErrInvalidAddress describes an error in which the address string is not valid.
+	// AddressChecksumError and AddressNetworkError wrap it.
+	ErrInvalidAddress = errors.New("invalid address")
+
 	// ErrAddressExists describes an error in which the address already exist
 	// in wallet.
 	ErrAddressExists = errors.New("address already exists")
@@ -16,23 +28,342 @@ var (
 	// ErrNeutered describes an error in which the wallet is neutered.
 	ErrNeutered = errors.New("wallet is neutered")
 
+	// ErrNoLocalSeed describes an error in which the seed is kept by an external
+	// seed provider, so the vault has no local seed, see WithReadOnlySeed.
+	ErrNoLocalSeed = errors.New("no local seed, the seed is kept by the seed provider")
+
 	// ErrInvalidCoinType describes an error in which the coin type is not valid.
 	ErrInvalidCoinType = errors.New("invalid coin type")
 
 	// ErrUnsupportedPurpose describes an error in which the purpose is not supported.
 	ErrUnsupportedPurpose = errors.New("unsupported purpose")
+
+	// ErrUnsupportedAddressType describes an error in which the address type is not supported.
+	ErrUnsupportedAddressType = errors.New("unsupported address type")
+
+	// ErrUnsupportedChain describes an error in which the BIP-44 chain is not supported.
+	// Pactus has no change addresses, so only the external chain is supported.
+	ErrUnsupportedChain = errors.New("unsupported chain, only the external chain is supported")
+
+	// ErrInvalidCount describes an error in which the number of requested items is invalid.
+	ErrInvalidCount = errors.New("invalid count")
+
+	// ErrDuplicateAddress describes an error in which an address is given more than once.
+	ErrDuplicateAddress = errors.New("duplicate address")
+
+	// ErrInvalidDuration describes an error in which the duration is not positive.
+	ErrInvalidDuration = errors.New("invalid duration")
+
+	// ErrNotImported describes an error in which the address is not imported.
+	ErrNotImported = errors.New("address is not imported")
+
+	// ErrWatchOnly describes an error in which the address is watch-only and
+	// the vault doesn't hold its private key.
+	ErrWatchOnly = errors.New("address is watch-only, no private key")
+
+	// ErrInvalidKey describes an error in which the key is not valid.
+	ErrInvalidKey = errors.New("invalid key")
+
+	// ErrInvalidKeyEncoding describes an error in which the encoded key is malformed.
+	ErrInvalidKeyEncoding = errors.New("invalid key encoding")
+
+	// ErrInvalidDescriptor describes an error in which the output descriptor is not valid.
+	ErrInvalidDescriptor = errors.New("invalid descriptor")
+
+	// ErrIndexGap describes an error in which removing an address leaves a gap
+	// in the derivation indexes, because an address with a higher index exists.
+	ErrIndexGap = errors.New("an address with a higher index exists")
+
+	// ErrUnsupportedValidatorScheme describes an error in which a validator address
+	// is requested for a signature scheme other than BLS.
+	// Validators in Pactus must use BLS keys, because the consensus votes and
+	// certificates rely on BLS signature aggregation.
+	ErrUnsupportedValidatorScheme = errors.New("validators only support BLS signature scheme")
+
+	// ErrUnsupportedLanguage describes an error in which the mnemonic language is not supported.
+	ErrUnsupportedLanguage = errors.New("unsupported mnemonic language")
+
+	// ErrLabelTooLong describes an error in which the label is longer than MaxLabelLength bytes.
+	ErrLabelTooLong = errors.New("label is too long")
+
+	// ErrReencryptionMismatch describes an error in which the re-encrypted key
+	// store doesn't decrypt to the same secrets.
+	ErrReencryptionMismatch = errors.New("re-encrypted key store doesn't match")
+
+	// ErrDelayedUnlock describes an error in which the vault has a delayed unlock
+	// policy, so the secrets are only accessible after redeeming an unlock ticket.
+	ErrDelayedUnlock = errors.New("vault has a delayed unlock policy, request an unlock first")
+
+	// ErrNoUnlockPolicy describes an error in which the vault has no delayed unlock policy.
+	ErrNoUnlockPolicy = errors.New("vault has no delayed unlock policy")
+
+	// ErrUnlockNotReady describes an error in which the unlock ticket is redeemed
+	// before its delay is passed.
+	ErrUnlockNotReady = errors.New("unlock ticket is not ready yet")
+
+	// ErrInvalidTicket describes an error in which the unlock ticket is already
+	// redeemed or it doesn't belong to the current key store.
+	ErrInvalidTicket = errors.New("invalid unlock ticket")
+
+	// ErrPasswordInHint describes an error in which the password hint contains the password.
+	ErrPasswordInHint = errors.New("password hint contains the password")
+
+	// ErrInvalidLabelsCSV describes an error in which a row of the labels CSV is malformed.
+	ErrInvalidLabelsCSV = errors.New("invalid labels CSV")
+
+	// ErrInvalidGroup describes an error in which the group has an empty segment
+	// or is longer than MaxLabelLength bytes.
+	ErrInvalidGroup = errors.New("invalid group")
+
+	// ErrPoolDisabled describes an error in which the address pool is not enabled.
+	ErrPoolDisabled = errors.New("address pool is not enabled")
+
+	// ErrTxDone describes an error in which the transaction is already committed or rolled back.
+	ErrTxDone = errors.New("transaction has already been committed or rolled back")
+
+	// ErrInvalidBlob describes an error in which the watch-only blob is malformed.
+	ErrInvalidBlob = errors.New("invalid watch-only blob")
+
+	// ErrInvalidBlobChecksum describes an error in which the checksum of the
+	// watch-only blob doesn't match, e.g. because of a scan error.
+	ErrInvalidBlobChecksum = errors.New("watch-only blob checksum is invalid")
+
+	// ErrInvalidChecksum describes an error in which the mnemonic checksum is not valid.
+	ErrInvalidChecksum = errors.New("mnemonic checksum is invalid")
+
+	// ErrInvalidThreshold describes an error in which the threshold or the number
+	// of seed shares is not valid.
+	ErrInvalidThreshold = errors.New("invalid threshold or number of shares")
+
+	// ErrInvalidShare describes an error in which the seed share is malformed.
+	ErrInvalidShare = errors.New("invalid seed share")
+
+	// ErrInvalidShareChecksum describes an error in which the checksum of the
+	// seed share doesn't match, e.g. because of a typo.
+	ErrInvalidShareChecksum = errors.New("seed share checksum is invalid")
+
+	// ErrShareMismatch describes an error in which the seed shares don't belong to the same split.
+	ErrShareMismatch = errors.New("seed shares don't belong to the same split")
+
+	// ErrInvalidKeystore describes an error in which the portable keystore is malformed.
+	ErrInvalidKeystore = errors.New("invalid keystore")
+
+	// ErrInvalidBackup describes an error in which the backup is malformed or
+	// doesn't match its metadata.
+	ErrInvalidBackup = errors.New("invalid backup")
+
+	// ErrInvalidBackupChecksum describes an error in which the checksum of the
+	// backup doesn't match, so the backup is truncated or corrupted.
+	ErrInvalidBackupChecksum = errors.New("backup checksum is invalid")
+
+	// ErrInvalidVaultBinary describes an error in which the binary form of the
+	// vault is malformed or its format is not supported.
+	ErrInvalidVaultBinary = errors.New("invalid vault binary")
+
+	// ErrInvalidSigningContext describes an error in which the signing context
+	// is too short to hold the subject and the height.
+	ErrInvalidSigningContext = errors.New("invalid signing context")
+
+	// ErrNotEnoughShares describes an error in which fewer shares than the threshold are provided.
+	ErrNotEnoughShares = errors.New("not enough seed shares")
+
+	// ErrInvalidLegacyVault describes an error in which the legacy vault is malformed,
+	// its format is not supported, or its addresses don't match their keys.
+	ErrInvalidLegacyVault = errors.New("invalid legacy vault")
 )
 
 // AddressNotFoundError describes an error in which the address doesn't exist
-// in wallet.
+// in wallet. It wraps ErrAddressNotFound.
 type AddressNotFoundError struct {
-	addr string
+	Address string
 }
 
 func NewErrAddressNotFound(addr string) error {
-	return AddressNotFoundError{addr: addr}
+	return AddressNotFoundError{Address: addr}
 }
 
 func (e AddressNotFoundError) Error() string {
-	return fmt.Sprintf("address not found: %s", e.addr)
+	return fmt.Sprintf("address not found: %s", e.Address)
+}
+
+func (AddressNotFoundError) Unwrap() error {
+	return ErrAddressNotFound
+}
+
+// AddressExistsError describes an error in which the address already exists
+// in wallet. It wraps ErrAddressExists.
+type AddressExistsError struct {
+	Address string
+}
+
+func (e AddressExistsError) Error() string {
+	return fmt.Sprintf("address already exists: %s", e.Address)
+}
+
+func (AddressExistsError) Unwrap() error {
+	return ErrAddressExists
+}
+
+// AddressChecksumError describes an error in which the checksum of the address
+// doesn't match, e.g. because of a typo. It wraps ErrInvalidAddress.
+type AddressChecksumError struct {
+	Address string
+}
+
+func (e AddressChecksumError) Error() string {
+	return fmt.Sprintf("invalid address checksum: %s", e.Address)
+}
+
+func (AddressChecksumError) Unwrap() error {
+	return ErrInvalidAddress
+}
+
+// AddressNetworkError describes an error in which the address belongs to
+// another network, so its HRP is not the expected one. It wraps ErrInvalidAddress.
+type AddressNetworkError struct {
+	HRP         string
+	ExpectedHRP string
+}
+
+func (e AddressNetworkError) Error() string {
+	return fmt.Sprintf("address is for another network, expected HRP %s, got %s", e.ExpectedHRP, e.HRP)
+}
+
+func (AddressNetworkError) Unwrap() error {
+	return ErrInvalidAddress
+}
+
+// PathNotFoundError describes an error in which no address is derived at the
+// path in wallet. It wraps ErrPathNotFound.
+type PathNotFoundError struct {
+	Path string
+}
+
+func (e PathNotFoundError) Error() string {
+	return fmt.Sprintf("no address found at path: %s", e.Path)
+}
+
+func (PathNotFoundError) Unwrap() error {
+	return ErrPathNotFound
+}
+
+// CoinTypeMismatchError describes an error in which the coin type of the key
+// path doesn't match the coin type of the vault. It wraps ErrInvalidCoinType.
+type CoinTypeMismatchError struct {
+	Expected uint32
+	Got      uint32
+}
+
+func (e CoinTypeMismatchError) Error() string {
+	return fmt.Sprintf("coin type mismatch, expected %d, got %d", e.Expected, e.Got)
+}
+
+func (CoinTypeMismatchError) Unwrap() error {
+	return ErrInvalidCoinType
+}
+
+// InvalidWordCountError describes an error in which the number of words in
+// the mnemonic is not valid.
+type InvalidWordCountError struct {
+	Count int
+}
+
+func (e InvalidWordCountError) Error() string {
+	return fmt.Sprintf("invalid number of words: %d, expected 12, 15, 18, 21 or 24", e.Count)
+}
+
+// WordCountMismatchError describes an error in which the number of words in
+// the mnemonic doesn't match the expected strength.
+type WordCountMismatchError struct {
+	Expected int
+	Got      int
+}
+
+func (e WordCountMismatchError) Error() string {
+	return fmt.Sprintf("word count mismatch, expected %d, got %d", e.Expected, e.Got)
+}
+
+// UnknownWordError describes an error in which a word of the mnemonic is not
+// in the wordlist.
+type UnknownWordError struct {
+	Index int // One-based position of the word in the mnemonic
+	Word  string
+}
+
+func (e UnknownWordError) Error() string {
+	return fmt.Sprintf("word %d '%s' is not in the wordlist", e.Index, e.Word)
+}
+
+// InvalidEntropySizeError describes an error in which the entropy size is not
+// a multiple of 32 between 128 and 256 bits.
+type InvalidEntropySizeError int
+
+func (e InvalidEntropySizeError) Error() string {
+	return fmt.Sprintf("invalid entropy size: %d bits, expected a multiple of 32 between 128 and 256",
+		int(e))
+}
+
+// FingerprintMismatchError describes an error in which two vaults are not
+// created from the same seed.
+type FingerprintMismatchError struct {
+	Fingerprint      string
+	OtherFingerprint string
+}
+
+func (e FingerprintMismatchError) Error() string {
+	return fmt.Sprintf("vaults have different master fingerprints: %s, %s",
+		e.Fingerprint, e.OtherFingerprint)
+}
+
+// UnsupportedVaultVersionError describes an error in which the vault format
+// version is newer than the supported version.
+type UnsupportedVaultVersionError struct {
+	Version          int
+	SupportedVersion int
+}
+
+func (e UnsupportedVaultVersionError) Error() string {
+	return fmt.Sprintf("vault version %d is not supported, latest supported version is %d",
+		e.Version, e.SupportedVersion)
+}
+
+// VaultError describes an error in an operation on an address of the vault.
+// It carries the address and the path, and wraps the cause, so both
+// errors.Is and errors.As work on the cause.
+type VaultError struct {
+	Op      string // Operation, like "private key"
+	Address string // Address, if known
+	Path    string // Derivation path, if known
+	Err     error  // Cause of the error
+}
+
+func (e VaultError) Error() string {
+	msg := e.Op
+	if e.Address != "" {
+		msg += " " + e.Address
+	}
+	if e.Path != "" {
+		msg += " (" + e.Path + ")"
+	}
+
+	return fmt.Sprintf("%s: %s", msg, e.Err.Error())
+}
+
+func (e VaultError) Unwrap() error {
+	return e.Err
+}
+
+// AddressCountMismatchError describes an error in which the number of the
+// restored addresses doesn't match the backup metadata. It wraps ErrInvalidBackup.
+type AddressCountMismatchError struct {
+	Expected int
+	Got      int
+}
+
+func (e AddressCountMismatchError) Error() string {
+	return fmt.Sprintf("address count mismatch, expected %d, got %d", e.Expected, e.Got)
+}
+
+func (AddressCountMismatchError) Unwrap() error {
+	return ErrInvalidBackup
 }
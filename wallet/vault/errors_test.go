@@ -0,0 +1,62 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestErrorChains(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Address not found", func(t *testing.T) {
+		addr := td.RandAccAddress().String()
+		_, err := td.vault.PrivateKeys(tPassword, []string{addr})
+
+		var notFoundErr AddressNotFoundError
+		require.True(t, errors.As(err, &notFoundErr))
+		assert.Equal(t, addr, notFoundErr.Address)
+		assert.ErrorIs(t, err, ErrAddressNotFound)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+		assert.NotErrorIs(t, err, NewErrAddressNotFound(td.RandAccAddress().String()))
+	})
+
+	t.Run("Path not found", func(t *testing.T) {
+		err := error(PathNotFoundError{Path: "m/12381'/21888'/1'/100"})
+
+		assert.ErrorIs(t, err, ErrPathNotFound)
+	})
+
+	t.Run("Coin type mismatch", func(t *testing.T) {
+		err := error(CoinTypeMismatchError{Expected: 21888, Got: 21777})
+
+		assert.ErrorIs(t, err, ErrInvalidCoinType)
+	})
+
+	t.Run("Private key of a corrupted address", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		info := vlt.AddressInfos()[0]
+		info.Path = "m/12381'/21777'/1'/0"
+		vlt.Addresses[info.Address] = info
+
+		_, err := vlt.PrivateKeysMap(tPassword, []string{info.Address})
+
+		var vaultErr VaultError
+		require.True(t, errors.As(err, &vaultErr))
+		assert.Equal(t, info.Address, vaultErr.Address)
+		assert.Equal(t, info.Path, vaultErr.Path)
+		assert.ErrorIs(t, err, ErrInvalidCoinType)
+		assert.Contains(t, err.Error(), info.Address)
+	})
+
+	t.Run("Invalid checksum keeps the cause", func(t *testing.T) {
+		err := ValidateMnemonic(
+			"abandon ability able about above absent absorb abstract absurd abuse access accident")
+
+		assert.ErrorIs(t, err, ErrInvalidChecksum)
+		assert.ErrorIs(t, err, bip39.ErrChecksumIncorrect)
+	})
+}
@@ -0,0 +1,51 @@
+package vault
+
+import (
+	"time"
+
+	"github.com/pactus-project/pactus/crypto"
+)
+
+// DerivationSamples is the number of addresses derived by EstimateDerivationTime
+// to measure the derivation time.
+const DerivationSamples = 3
+
+// EstimateDerivationTime estimates how long it takes to derive `count` BLS
+// account addresses, for example by ScanAddresses.
+// It times DerivationSamples derivations from the next unused index and
+// extrapolates linearly. The sample addresses are not added to the vault.
+//
+// Derivation uses the extended public key that is already loaded, so there is
+// no key derivation function to run and no password is needed. The KDF cost,
+// if any, is paid once by the operations that decrypt the seed, and it is not
+// part of this estimate.
+//
+// The estimate is a rough hint, like showing a spinner in the UI:
+// the first derivations may be slower due to cold caches, and the time can
+// vary with CPU frequency scaling and the load of the device.
+// It returns zero if count is not positive or if the derivation fails.
+func (v *Vault) EstimateDerivationTime(count int) time.Duration {
+	if count <= 0 {
+		return 0
+	}
+
+	ext, nextIndex, err := v.blsExtendedKey(PurposeBLS12381, crypto.AddressTypeBLSAccount)
+	if err != nil {
+		return 0
+	}
+
+	samples := DerivationSamples
+	if count < samples {
+		samples = count
+	}
+
+	start := time.Now()
+	for i := 0; i < samples; i++ {
+		if _, err := deriveBLSAddressInfo(ext, crypto.AddressTypeBLSAccount, *nextIndex+uint32(i)); err != nil {
+			return 0
+		}
+	}
+	elapsed := time.Since(start)
+
+	return elapsed / time.Duration(samples) * time.Duration(count)
+}
@@ -0,0 +1,30 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateDerivationTime(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid count", func(t *testing.T) {
+		assert.Zero(t, td.vault.EstimateDerivationTime(0))
+		assert.Zero(t, td.vault.EstimateDerivationTime(-1))
+	})
+
+	t.Run("Vault is not changed", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		estimate := vlt.EstimateDerivationTime(100)
+
+		assert.Positive(t, estimate)
+		assert.True(t, vlt.Equal(td.vault))
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+
+		assert.Positive(t, neutered.EstimateDerivationTime(10))
+	})
+}
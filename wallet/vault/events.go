@@ -0,0 +1,127 @@
+package vault
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EventBufferSize is the number of events buffered for each subscriber.
+// If the subscriber doesn't keep up, the oldest events are dropped.
+const EventBufferSize = 64
+
+// EventKind defines the kind of a vault event.
+type EventKind int
+
+const (
+	EventAddressAdded    = EventKind(1) // An address is derived or imported
+	EventAddressRemoved  = EventKind(2) // An address is removed or rolled back
+	EventLabelChanged    = EventKind(3) // The label or the group of an address is changed
+	EventPasswordChanged = EventKind(4) // The password or the encryption parameters are changed
+	EventNeutered        = EventKind(5) // The vault is neutered in place
+
+	EventDefaultAddressChanged = EventKind(6) // The default address is set or cleared
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventAddressAdded:
+		return "address-added"
+	case EventAddressRemoved:
+		return "address-removed"
+	case EventLabelChanged:
+		return "label-changed"
+	case EventPasswordChanged:
+		return "password-changed"
+	case EventNeutered:
+		return "neutered"
+	case EventDefaultAddressChanged:
+		return "default-address-changed"
+	default:
+		return fmt.Sprintf("unknown event: %d", int(k))
+	}
+}
+
+// VaultEvent describes a change in the vault.
+type VaultEvent struct {
+	Kind    EventKind // Kind of the change
+	Address string    // Affected address, empty for the changes of the whole vault
+}
+
+// eventHub delivers the events to the subscribers, without blocking the sender.
+type eventHub struct {
+	lk     sync.Mutex
+	subs   map[int]chan VaultEvent
+	nextID int
+}
+
+// Subscribe returns a channel that receives the events of the vault, and a
+// function to unsubscribe, which closes the channel.
+// The events are sent after the change is applied. Sending never blocks the
+// changing goroutine: each subscriber has a buffer of EventBufferSize events,
+// and the oldest events are dropped if the buffer is full.
+// Clones of the vault don't share the subscribers.
+func (v *Vault) Subscribe() (<-chan VaultEvent, func()) {
+	if v.events == nil {
+		v.events = &eventHub{
+			subs: make(map[int]chan VaultEvent),
+		}
+	}
+
+	return v.events.subscribe()
+}
+
+func (h *eventHub) subscribe() (<-chan VaultEvent, func()) {
+	h.lk.Lock()
+	defer h.lk.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	ch := make(chan VaultEvent, EventBufferSize)
+	h.subs[id] = ch
+
+	unsubscribe := func() {
+		h.lk.Lock()
+		defer h.lk.Unlock()
+
+		if _, ok := h.subs[id]; ok {
+			delete(h.subs, id)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *eventHub) send(event VaultEvent) {
+	h.lk.Lock()
+	defer h.lk.Unlock()
+
+	for _, ch := range h.subs {
+		select {
+		case ch <- event:
+			continue
+		default:
+		}
+
+		// The buffer is full, drop the oldest event.
+		select {
+		case <-ch:
+		default:
+		}
+
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// notify sends the event to the subscribers.
+// The address is empty for the changes of the whole vault.
+func (v *Vault) notify(kind EventKind, addr string) {
+	if v.events == nil {
+		return
+	}
+
+	v.events.send(VaultEvent{Kind: kind, Address: addr})
+}
@@ -0,0 +1,142 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainEvents returns the events that are buffered in the channel.
+func drainEvents(ch <-chan VaultEvent) []VaultEvent {
+	events := make([]VaultEvent, 0)
+	for {
+		select {
+		case event := <-ch:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	td := setup(t)
+
+	ch, unsubscribe := td.vault.Subscribe()
+	defer unsubscribe()
+
+	t.Run("Derive an address", func(t *testing.T) {
+		info, err := td.vault.NewBLSAccountAddress("new-address")
+		require.NoError(t, err)
+
+		assert.Equal(t, []VaultEvent{{Kind: EventAddressAdded, Address: info.Address}}, drainEvents(ch))
+	})
+
+	t.Run("Change the label", func(t *testing.T) {
+		addr := td.vault.AddressesByLabel("validator-address")[0].Address
+		require.NoError(t, td.vault.SetLabel(addr, "validator-1"))
+		require.NoError(t, td.vault.SetLabel(addr, "validator-1"))
+
+		assert.Equal(t, []VaultEvent{{Kind: EventLabelChanged, Address: addr}}, drainEvents(ch))
+	})
+
+	t.Run("Remove an address", func(t *testing.T) {
+		info, err := td.vault.NewValidatorAddress("")
+		require.NoError(t, err)
+		require.NoError(t, td.vault.RemoveAddress(info.Address, tPassword, false))
+
+		assert.Equal(t, []VaultEvent{
+			{Kind: EventAddressAdded, Address: info.Address},
+			{Kind: EventAddressRemoved, Address: info.Address},
+		}, drainEvents(ch))
+	})
+
+	t.Run("Change the password", func(t *testing.T) {
+		require.NoError(t, td.vault.UpdatePassword(tPassword, tPassword))
+
+		assert.Equal(t, []VaultEvent{{Kind: EventPasswordChanged}}, drainEvents(ch))
+	})
+
+	t.Run("Neutered copy", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+		_, err := neutered.NewBLSAccountAddress("")
+		require.NoError(t, err)
+
+		assert.Empty(t, drainEvents(ch), "the vault itself is not neutered")
+	})
+
+	t.Run("Failed change", func(t *testing.T) {
+		_, prv := td.RandEd25519KeyPair()
+		require.Error(t, td.vault.ImportEd25519PrivateKey("invalid-password", prv))
+
+		assert.Empty(t, drainEvents(ch))
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		tx := td.vault.Begin()
+		info, err := tx.NewValidatorAddress("")
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+
+		assert.Equal(t, []VaultEvent{
+			{Kind: EventAddressAdded, Address: info.Address},
+			{Kind: EventAddressRemoved, Address: info.Address},
+		}, drainEvents(ch))
+	})
+
+	t.Run("Neuter in place", func(t *testing.T) {
+		td.vault.NeuterInPlace()
+
+		assert.Equal(t, []VaultEvent{{Kind: EventNeutered}}, drainEvents(ch))
+	})
+}
+
+func TestSubscribeDropOldest(t *testing.T) {
+	td := setup(t)
+
+	ch, unsubscribe := td.vault.Subscribe()
+	defer unsubscribe()
+
+	infos, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount,
+		EventBufferSize+5, "")
+	require.NoError(t, err)
+
+	events := drainEvents(ch)
+	require.Len(t, events, EventBufferSize)
+	assert.Equal(t, infos[5].Address, events[0].Address)
+	assert.Equal(t, infos[len(infos)-1].Address, events[len(events)-1].Address)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	td := setup(t)
+
+	ch1, unsubscribe1 := td.vault.Subscribe()
+	ch2, unsubscribe2 := td.vault.Subscribe()
+	defer unsubscribe2()
+
+	unsubscribe1()
+	unsubscribe1()
+
+	_, ok := <-ch1
+	assert.False(t, ok, "channel should be closed")
+
+	info, err := td.vault.NewBLSAccountAddress("")
+	require.NoError(t, err)
+	assert.Equal(t, []VaultEvent{{Kind: EventAddressAdded, Address: info.Address}}, drainEvents(ch2))
+
+	t.Run("Clones don't share the subscribers", func(t *testing.T) {
+		_, err := td.vault.Clone().NewBLSAccountAddress("")
+		require.NoError(t, err)
+
+		assert.Empty(t, drainEvents(ch2))
+	})
+}
+
+func TestEventKindString(t *testing.T) {
+	assert.Equal(t, "address-added", EventAddressAdded.String())
+	assert.Equal(t, "neutered", EventNeutered.String())
+	assert.Equal(t, "default-address-changed", EventDefaultAddressChanged.String())
+	assert.Equal(t, "unknown event: 9", EventKind(9).String())
+}
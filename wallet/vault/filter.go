@@ -0,0 +1,88 @@
+package vault
+
+import (
+	"strings"
+
+	"github.com/pactus-project/pactus/crypto"
+	"golang.org/x/exp/slices"
+)
+
+// FilterOptions defines the criteria to filter the addresses in the vault.
+// An empty field matches all the addresses.
+type FilterOptions struct {
+	Purposes         []uint32             // Purposes of the address path, without hardening (e.g. PurposeBLS12381)
+	AddressTypes     []crypto.AddressType // Types of the address
+	Origins          []Origin             // Origins of the address key
+	Chains           []uint32             // BIP-44 chains of the address, all addresses are on ChainExternal
+	Label            string               // Substring that the label should contain
+	OnlyWatchOnly    bool                 // Only include watch-only addresses
+	ExcludeWatchOnly bool                 // Exclude watch-only addresses
+}
+
+func (opts FilterOptions) match(v *Vault, info AddressInfo) bool {
+	if opts.OnlyWatchOnly && !info.IsWatchOnly {
+		return false
+	}
+
+	if opts.ExcludeWatchOnly && info.IsWatchOnly {
+		return false
+	}
+
+	if len(opts.Origins) > 0 && !slices.Contains(opts.Origins, info.Origin) {
+		return false
+	}
+
+	if len(opts.Chains) > 0 && !slices.Contains(opts.Chains, ChainExternal) {
+		return false
+	}
+
+	if opts.Label != "" && !strings.Contains(info.Label, opts.Label) {
+		return false
+	}
+
+	if len(opts.Purposes) == 0 && len(opts.AddressTypes) == 0 {
+		return true
+	}
+
+	addrPath, err := v.parsePath(info.Path)
+	if err != nil {
+		return false
+	}
+
+	if len(opts.Purposes) > 0 &&
+		!slices.Contains(opts.Purposes, _N(addrPath.Purpose())) {
+		return false
+	}
+
+	if len(opts.AddressTypes) > 0 &&
+		!slices.Contains(opts.AddressTypes, crypto.AddressType(_N(addrPath.AddressType()))) {
+		return false
+	}
+
+	return true
+}
+
+// FilterAddresses returns the addresses that match the filter options.
+// The addresses are sorted in the same order as AddressInfos.
+func (v *Vault) FilterAddresses(opts FilterOptions) []AddressInfo {
+	addrs := make([]AddressInfo, 0, 1)
+	for _, addrInfo := range v.Addresses {
+		if opts.match(v, addrInfo) {
+			addrs = append(addrs, addrInfo)
+		}
+	}
+
+	v.sortAddressesByAddressIndex(addrs...)
+	v.sortAddressesByAddressType(addrs...)
+	v.sortAddressesByPurpose(addrs...)
+
+	return addrs
+}
+
+// NonRecoverableAddresses returns the addresses that can't be recovered from the mnemonic.
+// These are the imported and watch-only addresses, which need a separate backup.
+func (v *Vault) NonRecoverableAddresses() []AddressInfo {
+	return v.FilterAddresses(FilterOptions{
+		Origins: []Origin{OriginImported, OriginWatchOnly},
+	})
+}
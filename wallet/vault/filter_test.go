@@ -0,0 +1,137 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filteredPaths(infos []AddressInfo) []string {
+	paths := make([]string, 0, len(infos))
+	for _, info := range infos {
+		paths = append(paths, info.Path)
+	}
+
+	return paths
+}
+
+func TestFilterAddresses(t *testing.T) {
+	td := setup(t)
+
+	pub, _ := td.RandBLSKeyPair()
+	_, err := td.vault.ImportWatchOnlyPublicKey(pub, "watch-only-address")
+	require.NoError(t, err)
+
+	t.Run("No filter", func(t *testing.T) {
+		assert.Equal(t, td.vault.AddressInfos(), td.vault.FilterAddresses(FilterOptions{}))
+		assert.Len(t, td.vault.FilterAddresses(FilterOptions{}), 8)
+	})
+
+	t.Run("Filter by purpose", func(t *testing.T) {
+		infos := td.vault.FilterAddresses(FilterOptions{
+			Purposes: []uint32{PurposeBLS12381, PurposeBIP44},
+		})
+		assert.Equal(t, []string{
+			"m/44'/21888'/3'/0'",
+			"m/12381'/21888'/1'/0",
+			"m/12381'/21888'/2'/0",
+		}, filteredPaths(infos))
+	})
+
+	t.Run("Filter by address type", func(t *testing.T) {
+		infos := td.vault.FilterAddresses(FilterOptions{
+			AddressTypes: []crypto.AddressType{crypto.AddressTypeBLSAccount},
+		})
+		assert.Equal(t, []string{
+			"m/12381'/21888'/2'/0",
+			"m/65534'/21888'/2'/0'",
+			"m/65535'/21888'/2'/0'",
+		}, filteredPaths(infos))
+	})
+
+	t.Run("Filter by label", func(t *testing.T) {
+		infos := td.vault.FilterAddresses(FilterOptions{Label: "account"})
+		assert.Equal(t, []string{
+			"m/44'/21888'/3'/0'",
+			"m/12381'/21888'/2'/0",
+		}, filteredPaths(infos))
+	})
+
+	t.Run("Filter by watch-only flag", func(t *testing.T) {
+		watchOnly := td.vault.FilterAddresses(FilterOptions{OnlyWatchOnly: true})
+		assert.Len(t, watchOnly, 2)
+		for _, info := range watchOnly {
+			assert.True(t, info.IsWatchOnly)
+		}
+
+		others := td.vault.FilterAddresses(FilterOptions{ExcludeWatchOnly: true})
+		assert.Len(t, others, 6)
+		for _, info := range others {
+			assert.False(t, info.IsWatchOnly)
+		}
+	})
+
+	t.Run("Filter by origin", func(t *testing.T) {
+		infos := td.vault.FilterAddresses(FilterOptions{
+			Origins: []Origin{OriginImported},
+		})
+		assert.Equal(t, []string{
+			"m/65535'/21888'/1'/0'",
+			"m/65535'/21888'/2'/0'",
+			"m/65535'/21888'/3'/1'",
+		}, filteredPaths(infos))
+	})
+
+	t.Run("Filter by chain", func(t *testing.T) {
+		infos := td.vault.FilterAddresses(FilterOptions{Chains: []uint32{ChainExternal}})
+		assert.Len(t, infos, 8)
+
+		assert.Empty(t, td.vault.FilterAddresses(FilterOptions{Chains: []uint32{ChainInternal}}))
+	})
+
+	t.Run("Combine filters", func(t *testing.T) {
+		infos := td.vault.FilterAddresses(FilterOptions{
+			AddressTypes:     []crypto.AddressType{crypto.AddressTypeValidator},
+			ExcludeWatchOnly: true,
+		})
+		assert.Equal(t, []string{
+			"m/12381'/21888'/1'/0",
+			"m/65535'/21888'/1'/0'",
+		}, filteredPaths(infos))
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		assert.Empty(t, td.vault.FilterAddresses(FilterOptions{Label: "not-exists"}))
+	})
+}
+
+func TestNonRecoverableAddresses(t *testing.T) {
+	td := setup(t)
+
+	pub, _ := td.RandBLSKeyPair()
+	_, err := td.vault.ImportWatchOnlyPublicKey(pub, "watch-only-address")
+	require.NoError(t, err)
+
+	_, err = td.vault.NewBLSAccountAddress("new-account-address")
+	require.NoError(t, err)
+
+	infos := td.vault.NonRecoverableAddresses()
+	assert.Equal(t, []string{
+		"m/65534'/21888'/1'/0'",
+		"m/65534'/21888'/2'/0'",
+		"m/65535'/21888'/1'/0'",
+		"m/65535'/21888'/2'/0'",
+		"m/65535'/21888'/3'/1'",
+	}, filteredPaths(infos))
+
+	for _, info := range infos {
+		assert.NotEqual(t, OriginHDDerived, info.Origin)
+	}
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+		assert.Equal(t, infos, neutered.NonRecoverableAddresses())
+	})
+}
@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/util/bech32m"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+// ImportPrivateKeyString imports the private key from its bech32m encoded string,
+// as exported by other Pactus tools.
+// The signature scheme, BLS or Ed25519, is detected from the encoded key type.
+// For BLS keys, both the account and the validator addresses are imported and
+// the account address is returned.
+// It returns ErrInvalidKeyEncoding if the string is not a valid private key.
+func (v *Vault) ImportPrivateKeyString(password, keyStr string) (*AddressInfo, error) {
+	keyStr = strings.ToLower(strings.TrimSpace(keyStr))
+	_, typ, _, err := bech32m.DecodeToBase256WithTypeNoLimit(keyStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyEncoding, err)
+	}
+
+	switch typ {
+	case crypto.SignatureTypeBLS:
+		prv, err := bls.PrivateKeyFromString(keyStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKeyEncoding, err)
+		}
+
+		if err := v.ImportBLSPrivateKey(password, prv); err != nil {
+			return nil, err
+		}
+
+		return v.AddressInfo(prv.PublicKeyNative().AccountAddress().String()), nil
+
+	case crypto.SignatureTypeEd25519:
+		prv, err := ed25519.PrivateKeyFromString(keyStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKeyEncoding, err)
+		}
+
+		if err := v.ImportEd25519PrivateKey(password, prv); err != nil {
+			return nil, err
+		}
+
+		return v.AddressInfo(prv.PublicKeyNative().AccountAddress().String()), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyEncoding, crypto.InvalidSignatureTypeError(typ))
+	}
+}
+
+// RotateImportedKey replaces a compromised imported key with a new key.
+// The new key is imported, the old key and its addresses are removed, and the
+// label and the group of each old address are moved to the new address of the
+// same type. For BLS keys, both the validator and the account addresses are
+// rotated. The default address is moved as well, but not the signing history.
+// The new key should use the same signature scheme as the old key.
+// It returns the new address of the same type as oldAddr.
+// It returns ErrNotImported if oldAddr is not an imported address, and
+// AddressExistsError if the new key is already in the vault.
+// If rotation fails, the vault remains unchanged.
+func (v *Vault) RotateImportedKey(password, oldAddr string, newPrv crypto.PrivateKey) (*AddressInfo, error) {
+	if v.IsNeutered() {
+		return nil, ErrNeutered
+	}
+
+	oldInfo, ok := v.Addresses[oldAddr]
+	if !ok {
+		return nil, NewErrAddressNotFound(oldAddr)
+	}
+	oldPath, err := addresspath.FromString(oldInfo.Path)
+	if err != nil {
+		return nil, VaultError{Op: "rotate imported key", Address: oldAddr, Path: oldInfo.Path, Err: err}
+	}
+	if oldPath.Purpose() != _H(PurposeImportPrivateKey) {
+		return nil, ErrNotImported
+	}
+
+	// The old addresses of the same key, by their address type.
+	oldInfos := make(map[uint32]AddressInfo)
+	for _, info := range v.Addresses {
+		path, err := v.parsePath(info.Path)
+		if err == nil && len(path) == len(oldPath) &&
+			path.Purpose() == oldPath.Purpose() &&
+			path.AddressIndex() == oldPath.AddressIndex() {
+			oldInfos[_N(path.AddressType())] = info
+		}
+	}
+
+	tx := v.Begin()
+	oldKeyStore := v.KeyStore
+	oldDefaultAddr := v.DefaultAddr
+	rollback := func(err error) (*AddressInfo, error) {
+		_ = tx.Rollback()
+		v.KeyStore = oldKeyStore
+		v.DefaultAddr = oldDefaultAddr
+
+		return nil, err
+	}
+
+	newAddrs := make(map[uint32]string)
+	switch prv := newPrv.(type) {
+	case *bls.PrivateKey:
+		if oldPath.AddressType() == _H(crypto.AddressTypeEd25519Account) {
+			return nil, fmt.Errorf("%w: expected an Ed25519 private key", ErrInvalidKey)
+		}
+		if err := v.ImportBLSPrivateKey(password, prv); err != nil {
+			return rollback(err)
+		}
+		newAddrs[uint32(crypto.AddressTypeValidator)] = prv.PublicKeyNative().ValidatorAddress().String()
+		newAddrs[uint32(crypto.AddressTypeBLSAccount)] = prv.PublicKeyNative().AccountAddress().String()
+
+	case *ed25519.PrivateKey:
+		if oldPath.AddressType() != _H(crypto.AddressTypeEd25519Account) {
+			return nil, fmt.Errorf("%w: expected a BLS private key", ErrInvalidKey)
+		}
+		if err := v.ImportEd25519PrivateKey(password, prv); err != nil {
+			return rollback(err)
+		}
+		newAddrs[uint32(crypto.AddressTypeEd25519Account)] = prv.PublicKeyNative().AccountAddress().String()
+
+	default:
+		return nil, ErrInvalidKey
+	}
+
+	for addressType, info := range oldInfos {
+		newInfo, ok := v.Addresses[newAddrs[addressType]]
+		if !ok {
+			continue
+		}
+		newInfo.Label = info.Label
+		newInfo.Group = info.Group
+		v.Addresses[newInfo.Address] = newInfo
+		if v.DefaultAddr == info.Address {
+			v.DefaultAddr = newInfo.Address
+		}
+
+		if err := v.RemoveAddress(info.Address, password, true); err != nil {
+			return rollback(err)
+		}
+		v.notify(EventLabelChanged, newInfo.Address)
+	}
+	if v.DefaultAddr != oldDefaultAddr {
+		v.notify(EventDefaultAddressChanged, v.DefaultAddr)
+	}
+	_ = tx.Commit()
+
+	addressType := _N(oldPath.AddressType())
+
+	return v.AddressInfo(newAddrs[addressType]), nil
+}
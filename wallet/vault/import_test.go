@@ -0,0 +1,178 @@
+package vault
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPrivateKeyString(t *testing.T) {
+	td := setup(t)
+
+	t.Run("BLS private key", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+
+		info, err := td.vault.ImportPrivateKeyString(tPassword, prv.String())
+		require.NoError(t, err)
+		assert.Equal(t, prv.PublicKeyNative().AccountAddress().String(), info.Address)
+		assert.Equal(t, "m/65535'/21888'/2'/2'", info.Path)
+		assert.True(t, td.vault.Contains(prv.PublicKeyNative().ValidatorAddress().String()))
+
+		prvs, err := td.vault.PrivateKeys(tPassword, []string{info.Address})
+		require.NoError(t, err)
+		assert.Equal(t, prv.String(), prvs[0].String())
+	})
+
+	t.Run("Ed25519 private key", func(t *testing.T) {
+		_, prv := td.RandEd25519KeyPair()
+
+		info, err := td.vault.ImportPrivateKeyString(tPassword, " "+strings.ToUpper(prv.String())+"\n")
+		require.NoError(t, err)
+		assert.Equal(t, prv.PublicKeyNative().AccountAddress().String(), info.Address)
+		assert.Equal(t, "m/65535'/21888'/3'/3'", info.Path)
+
+		prvs, err := td.vault.PrivateKeys(tPassword, []string{info.Address})
+		require.NoError(t, err)
+		assert.Equal(t, prv.String(), prvs[0].String())
+	})
+
+	t.Run("Reimporting private key", func(t *testing.T) {
+		_, prv := td.RandEd25519KeyPair()
+		_, err := td.vault.ImportPrivateKeyString(tPassword, prv.String())
+		require.NoError(t, err)
+
+		_, err = td.vault.ImportPrivateKeyString(tPassword, prv.String())
+		assert.ErrorIs(t, err, ErrAddressExists)
+		assert.NotErrorIs(t, err, ErrInvalidKeyEncoding)
+	})
+
+	t.Run("Same key through different routes", func(t *testing.T) {
+		_, blsPrv := td.RandBLSKeyPair()
+		require.NoError(t, td.vault.ImportBLSPrivateKey(tPassword, blsPrv))
+
+		_, err := td.vault.ImportPrivateKeyString(tPassword, blsPrv.String())
+		assert.ErrorIs(t, err, ErrAddressExists)
+
+		_, ed25519Prv := td.RandEd25519KeyPair()
+		_, err = td.vault.ImportPrivateKeyString(tPassword, ed25519Prv.String())
+		require.NoError(t, err)
+
+		err = td.vault.ImportEd25519PrivateKey(tPassword, ed25519Prv)
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+		_, err := td.vault.ImportPrivateKeyString("invalid-password", prv.String())
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Garbage input", func(t *testing.T) {
+		_, blsPrv := td.RandBLSKeyPair()
+		pub, _ := td.RandBLSKeyPair()
+
+		inputs := []string{
+			"",
+			"garbage",
+			"0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			blsPrv.String()[:len(blsPrv.String())-1] + "x",
+			pub.String(),
+			td.RandAccAddress().String(),
+		}
+		for _, input := range inputs {
+			_, err := td.vault.ImportPrivateKeyString(tPassword, input)
+			assert.ErrorIs(t, err, ErrInvalidKeyEncoding, "input: %s", input)
+		}
+	})
+}
+
+func TestRotateImportedKey(t *testing.T) {
+	td := setup(t)
+
+	oldValAddr := td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String()
+	oldAccAddr := td.importedBLSPrv.PublicKeyNative().AccountAddress().String()
+
+	t.Run("Unknown address", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+		addr := td.RandAccAddress().String()
+		_, err := td.vault.RotateImportedKey(tPassword, addr, prv)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Derived address", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		_, err := td.vault.RotateImportedKey(tPassword, info.Address, prv)
+		assert.ErrorIs(t, err, ErrNotImported)
+	})
+
+	t.Run("Different signature scheme", func(t *testing.T) {
+		_, prv := td.RandEd25519KeyPair()
+		_, err := td.vault.RotateImportedKey(tPassword, oldValAddr, prv)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("New key is already in the vault", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+		require.NoError(t, td.vault.ImportBLSPrivateKey(tPassword, prv))
+		count := td.vault.AddressCount()
+		keyStore := td.vault.KeyStore
+
+		_, err := td.vault.RotateImportedKey(tPassword, oldValAddr, prv)
+		assert.ErrorIs(t, err, ErrAddressExists)
+		assert.Equal(t, count, td.vault.AddressCount())
+		assert.Equal(t, keyStore, td.vault.KeyStore)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+		_, err := td.vault.RotateImportedKey("invalid-password", oldValAddr, prv)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.True(t, td.vault.Contains(oldValAddr))
+	})
+
+	t.Run("Rotate BLS key", func(t *testing.T) {
+		require.NoError(t, td.vault.SetLabel(oldValAddr, "my-validator"))
+		require.NoError(t, td.vault.SetGroup(oldValAddr, "validators/main"))
+		require.NoError(t, td.vault.SetLabel(oldAccAddr, "my-reward"))
+		require.NoError(t, td.vault.SetDefaultAddress(oldAccAddr))
+
+		_, prv := td.RandBLSKeyPair()
+		info, err := td.vault.RotateImportedKey(tPassword, oldValAddr, prv)
+		require.NoError(t, err)
+
+		assert.Equal(t, prv.PublicKeyNative().ValidatorAddress().String(), info.Address)
+		assert.Equal(t, "my-validator", info.Label)
+		assert.Equal(t, "validators/main", info.Group)
+		assert.Equal(t, OriginImported, info.Origin)
+
+		accInfo := td.vault.AddressInfo(prv.PublicKeyNative().AccountAddress().String())
+		require.NotNil(t, accInfo)
+		assert.Equal(t, "my-reward", accInfo.Label)
+		defaultAddr, _ := td.vault.DefaultAddress()
+		assert.Equal(t, accInfo.Address, defaultAddr)
+
+		assert.False(t, td.vault.Contains(oldValAddr))
+		assert.False(t, td.vault.Contains(oldAccAddr))
+
+		prvs, err := td.vault.PrivateKeys(tPassword, []string{info.Address})
+		require.NoError(t, err)
+		assert.Equal(t, prv.String(), prvs[0].String())
+	})
+
+	t.Run("Rotate Ed25519 key", func(t *testing.T) {
+		oldAddr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+		require.NoError(t, td.vault.SetLabel(oldAddr, "my-account"))
+
+		_, prv := td.RandEd25519KeyPair()
+		info, err := td.vault.RotateImportedKey(tPassword, oldAddr, prv)
+		require.NoError(t, err)
+
+		assert.Equal(t, prv.PublicKeyNative().AccountAddress().String(), info.Address)
+		assert.Equal(t, "my-account", info.Label)
+		assert.False(t, td.vault.Contains(oldAddr))
+	})
+}
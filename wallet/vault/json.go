@@ -0,0 +1,141 @@
+package vault
+
+import (
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// vaultJSON has the same fields as Vault, without the JSON methods.
+type vaultJSON Vault
+
+// MarshalJSON encodes the vault to JSON.
+// The output is deterministic: the fields keep their declaration order and the
+// map keys, like the addresses, are sorted.
+// So two vaults with the same state produce byte-identical JSON.
+// The secrets remain encrypted in the key store.
+func (v *Vault) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*vaultJSON)(v))
+}
+
+// UnmarshalJSON decodes the vault from JSON.
+// Vaults in older formats are migrated to the current version.
+func (v *Vault) UnmarshalJSON(data []byte) error {
+	decoded := new(vaultJSON)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return err
+	}
+
+	*v = Vault(*decoded)
+
+	return v.afterDecode()
+}
+
+// afterDecode prepares the decoded vault for use.
+// Vaults in older formats are migrated to the current version.
+func (v *Vault) afterDecode() error {
+	// The CRC of the vault as decoded is kept, since migration changes the encoding.
+	if v.Version != CurrentVaultVersion {
+		v.decodedCRC = v.calcCRC()
+	}
+
+	if v.Addresses == nil {
+		v.Addresses = make(map[string]AddressInfo)
+	}
+
+	return migrate(v)
+}
+
+// DecodedCRC returns the CRC of the vault encoded in JSON, as it was decoded.
+// For migrated vaults, it is calculated before the migration, so it can be
+// checked against the CRC saved with the vault in the older format.
+// For the other vaults, it is the CRC of the current state of the vault.
+func (v *Vault) DecodedCRC() uint32 {
+	if v.IsMigrated() {
+		return v.decodedCRC
+	}
+
+	return v.calcCRC()
+}
+
+// calcCRC returns the CRC-32 of the vault encoded in JSON.
+func (v *Vault) calcCRC() uint32 {
+	data, err := json.Marshal((*vaultJSON)(v))
+	if err != nil {
+		return 0
+	}
+
+	return crc32.ChecksumIEEE(data)
+}
+
+// ReadVault decodes the vault from the JSON read from r.
+// The JSON is decoded directly from the reader, so unlike UnmarshalJSON, the
+// caller doesn't need to read the whole file into memory first, and the decoded
+// vault is not copied.
+// Vaults in older formats are migrated to the current version.
+func ReadVault(r io.Reader) (*Vault, error) {
+	decoded := new(vaultJSON)
+	if err := json.NewDecoder(r).Decode(decoded); err != nil {
+		return nil, err
+	}
+
+	v := (*Vault)(decoded)
+	if err := v.afterDecode(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// WriteVault encodes the vault to JSON and writes it to w, followed by a newline.
+// The output is the same as MarshalJSON.
+func WriteVault(w io.Writer, v *Vault) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// addressInfoJSON has the same fields as AddressInfo, without the JSON methods.
+// The timestamps are overridden by pointers, so they are omitted when they are zero
+// and old vaults keep the same encoding.
+type addressInfoJSON struct {
+	addressInfoFields
+
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+type addressInfoFields AddressInfo
+
+// MarshalJSON encodes the address info to JSON.
+// The zero timestamps are omitted.
+func (info AddressInfo) MarshalJSON() ([]byte, error) {
+	encoded := addressInfoJSON{
+		addressInfoFields: addressInfoFields(info),
+	}
+	if !info.CreatedAt.IsZero() {
+		encoded.CreatedAt = &info.CreatedAt
+	}
+	if !info.LastUsedAt.IsZero() {
+		encoded.LastUsedAt = &info.LastUsedAt
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes the address info from JSON.
+func (info *AddressInfo) UnmarshalJSON(data []byte) error {
+	decoded := new(addressInfoJSON)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return err
+	}
+
+	*info = AddressInfo(decoded.addressInfoFields)
+	if decoded.CreatedAt != nil {
+		info.CreatedAt = *decoded.CreatedAt
+	}
+	if decoded.LastUsedAt != nil {
+		info.LastUsedAt = *decoded.LastUsedAt
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultJSON(t *testing.T) {
+	td := setup(t)
+
+	data1, err := json.Marshal(td.vault)
+	require.NoError(t, err)
+
+	t.Run("Round trip", func(t *testing.T) {
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data1, restored))
+
+		assert.Equal(t, td.vault.Purposes, restored.Purposes)
+		assert.Equal(t, td.vault.Addresses, restored.Addresses)
+		assert.Equal(t, td.vault.Encrypter, restored.Encrypter)
+		assert.Equal(t, td.vault.KeyStore, restored.KeyStore)
+		assert.Equal(t, td.vault.Fingerprint, restored.Fingerprint)
+
+		mnemonic, err := restored.Mnemonic(tPassword)
+		assert.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+
+		data2, err := json.Marshal(restored)
+		require.NoError(t, err)
+		assert.Equal(t, data1, data2)
+	})
+
+	t.Run("Deterministic output", func(t *testing.T) {
+		// Insert the addresses in the reverse order.
+		infos := td.vault.AddressInfos()
+		addresses := make(map[string]AddressInfo)
+		for i := len(infos) - 1; i >= 0; i-- {
+			addresses[infos[i].Address] = infos[i]
+		}
+
+		cloned := *td.vault
+		cloned.Addresses = addresses
+
+		for i := 0; i < 10; i++ {
+			data2, err := json.Marshal(&cloned)
+			require.NoError(t, err)
+			assert.Equal(t, data1, data2)
+		}
+	})
+
+	t.Run("No plaintext secrets", func(t *testing.T) {
+		assert.NotContains(t, string(data1), td.mnemonic)
+		assert.NotContains(t, string(data1), td.importedBLSPrv.String())
+		assert.NotContains(t, string(data1), td.importedEd25519Prv.String())
+	})
+
+	t.Run("Missing addresses", func(t *testing.T) {
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal([]byte(`{"type":2}`), restored))
+		assert.NotNil(t, restored.Addresses)
+		assert.Equal(t, 0, restored.AddressCount())
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		restored := new(Vault)
+		assert.Error(t, json.Unmarshal([]byte(`{"type":"full"}`), restored))
+	})
+}
+
+func TestReadWriteVault(t *testing.T) {
+	td := setup(t)
+
+	data, err := json.Marshal(td.vault)
+	require.NoError(t, err)
+
+	t.Run("Write", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		require.NoError(t, WriteVault(buf, td.vault))
+		assert.Equal(t, string(data)+"\n", buf.String())
+	})
+
+	t.Run("Read from pipe", func(t *testing.T) {
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(WriteVault(writer, td.vault))
+		}()
+
+		read, err := ReadVault(reader)
+		require.NoError(t, err)
+
+		unmarshaled := new(Vault)
+		require.NoError(t, json.Unmarshal(data, unmarshaled))
+		assert.Equal(t, unmarshaled, read)
+
+		mnemonic, err := read.Mnemonic(tPassword)
+		assert.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+	})
+
+	t.Run("Missing addresses", func(t *testing.T) {
+		read, err := ReadVault(strings.NewReader(`{"type":2}`))
+		require.NoError(t, err)
+		assert.NotNil(t, read.Addresses)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := ReadVault(strings.NewReader(`{"type":"full"}`))
+		assert.Error(t, err)
+
+		_, err = ReadVault(strings.NewReader(`{"type":2`))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
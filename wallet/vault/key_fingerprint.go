@@ -0,0 +1,52 @@
+package vault
+
+import (
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/hash"
+	"github.com/pactus-project/pactus/util/bech32m"
+)
+
+// keyFingerprint returns a stable fingerprint of the public key.
+// The fingerprint is the hash of the raw public key, so it doesn't depend on
+// the address type or on how the key is imported.
+func keyFingerprint(rawPublicKey []byte) string {
+	return hash.CalcHash(rawPublicKey).String()
+}
+
+// keySet is the set of the public keys in the vault, indexed by their fingerprints.
+// It maps each fingerprint to the first address of the key, in lexicographic order.
+type keySet map[string]string
+
+// keyFingerprints builds the set of the public keys in the vault.
+// The set is built from the addresses on each import, rather than kept along
+// with the vault, since the addresses can be changed directly.
+// Both the account and the validator addresses of a BLS key share one fingerprint.
+func (v *Vault) keyFingerprints() keySet {
+	keys := make(keySet, len(v.Addresses))
+	for addr, info := range v.Addresses {
+		_, _, rawPublicKey, err := bech32m.DecodeToBase256WithTypeNoLimit(info.PublicKey)
+		if err != nil {
+			continue
+		}
+
+		fingerprint := keyFingerprint(rawPublicKey)
+		if existing, ok := keys[fingerprint]; !ok || addr < existing {
+			keys[fingerprint] = addr
+		}
+	}
+
+	return keys
+}
+
+// add adds the public key to the set, under the given address.
+// It returns an AddressExistsError with the address of the existing key, if the
+// key is already in the set, regardless of the route it was imported through.
+func (s keySet) add(pub crypto.PublicKey, addr string) error {
+	fingerprint := keyFingerprint(pub.Bytes())
+	if existing, ok := s[fingerprint]; ok {
+		return AddressExistsError{Address: existing}
+	}
+	s[fingerprint] = addr
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyFingerprints(t *testing.T) {
+	td := setup(t)
+
+	t.Run("BLS addresses share the key", func(t *testing.T) {
+		keys := td.vault.keyFingerprints()
+
+		// The imported BLS key has both the validator and the account addresses.
+		assert.Len(t, keys, td.vault.AddressCount()-1)
+	})
+
+	t.Run("Key exists", func(t *testing.T) {
+		prv := td.importedBLSPrv
+		keys := td.vault.keyFingerprints()
+
+		err := keys.add(prv.PublicKey(), "")
+		assert.ErrorIs(t, err, AddressExistsError{
+			Address: prv.PublicKeyNative().ValidatorAddress().String(),
+		})
+	})
+
+	t.Run("New key", func(t *testing.T) {
+		pub, _ := td.RandEd25519KeyPair()
+		keys := td.vault.keyFingerprints()
+
+		assert.NoError(t, keys.add(pub, "addr"))
+		assert.ErrorIs(t, keys.add(pub, ""), AddressExistsError{Address: "addr"})
+	})
+}
@@ -0,0 +1,288 @@
+package vault
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLabelLength is the maximum length of a label in bytes.
+const MaxLabelLength = 256
+
+// normalizeLabel normalizes the label to NFC and removes the characters that
+// can corrupt the displays.
+// Tabs and new lines are replaced with space, and other control characters,
+// including the bidirectional text controls, are removed.
+// Joiners, like the zero-width joiner used in emoji sequences, are kept.
+func normalizeLabel(label string) string {
+	label = strings.ToValidUTF8(label, "")
+	label = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t', r == '\n', r == '\r':
+			return ' '
+		case unicode.IsControl(r), unicode.Is(unicode.Bidi_Control, r):
+			return -1
+		default:
+			return r
+		}
+	}, label)
+
+	return norm.NFC.String(label)
+}
+
+// validateLabel normalizes the label and checks its length.
+func validateLabel(label string) (string, error) {
+	label = normalizeLabel(label)
+	if len(label) > MaxLabelLength {
+		return "", ErrLabelTooLong
+	}
+
+	return label, nil
+}
+
+// AddressesByLabel returns the addresses that their label is exactly the given label.
+// The addresses are sorted in the same order as AddressInfos.
+func (v *Vault) AddressesByLabel(label string) []AddressInfo {
+	return v.findByLabel(func(addrLabel string) bool {
+		return addrLabel == label
+	})
+}
+
+// SearchLabels returns the addresses that their label contains the given substring.
+// The search is case-insensitive and the addresses are sorted in the same order as AddressInfos.
+func (v *Vault) SearchLabels(substr string) []AddressInfo {
+	substr = strings.ToLower(substr)
+
+	return v.findByLabel(func(addrLabel string) bool {
+		return strings.Contains(strings.ToLower(addrLabel), substr)
+	})
+}
+
+func (v *Vault) findByLabel(match func(label string) bool) []AddressInfo {
+	addrs := make([]AddressInfo, 0)
+	for _, info := range v.AddressInfos() {
+		if match(info.Label) {
+			addrs = append(addrs, info)
+		}
+	}
+
+	return addrs
+}
+
+// SetLabels sets the labels of multiple addresses at once.
+// All the addresses and labels are validated before applying any change,
+// so on failure the vault remains unchanged.
+// The addresses are checked in sorted order, so the error for the first
+// missing address is deterministic.
+func (v *Vault) SetLabels(labels map[string]string) error {
+	addrs := make([]string, 0, len(labels))
+	for addr := range labels {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+
+	normalized := make(map[string]string, len(labels))
+	for _, addr := range addrs {
+		if !v.Contains(addr) {
+			return NewErrAddressNotFound(addr)
+		}
+
+		label, err := validateLabel(labels[addr])
+		if err != nil {
+			return err
+		}
+		normalized[addr] = label
+	}
+
+	for _, addr := range addrs {
+		info := v.Addresses[addr]
+		if info.Label == normalized[addr] {
+			continue
+		}
+
+		info.Label = normalized[addr]
+		v.Addresses[addr] = info
+		v.notify(EventLabelChanged, addr)
+	}
+
+	return nil
+}
+
+// ExportLabels exports the labels of the addresses as a JSON object that maps
+// each address to its label.
+// Addresses without label are not exported. No secret is exported.
+func (v *Vault) ExportLabels() ([]byte, error) {
+	labels := make(map[string]string, len(v.Addresses))
+	for addr, info := range v.Addresses {
+		if info.Label != "" {
+			labels[addr] = info.Label
+		}
+	}
+
+	return json.Marshal(labels)
+}
+
+// ImportLabels imports the labels exported by ExportLabels.
+// Addresses that are not in the vault are skipped.
+// Existing non-empty labels are replaced only if overwrite is set.
+// All the labels are validated before applying any change.
+func (v *Vault) ImportLabels(data []byte, overwrite bool) error {
+	labels := make(map[string]string)
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return err
+	}
+
+	toSet := make(map[string]string, len(labels))
+	for addr, label := range labels {
+		info, ok := v.Addresses[addr]
+		if !ok {
+			continue
+		}
+
+		if info.Label != "" && !overwrite {
+			continue
+		}
+		toSet[addr] = label
+	}
+
+	return v.SetLabels(toSet)
+}
+
+// ImportLabelsCSV imports the labels from a two-column CSV: address,label.
+// An optional header row "address,label" is ignored.
+// The labels are normalized and set only for the addresses in the vault.
+// Existing non-empty labels are replaced only if overwrite is set.
+// It returns the number of applied labels and the addresses that are skipped
+// because they are not in the vault.
+// A malformed row aborts the import with an error that has the line number,
+// and the vault remains unchanged.
+func (v *Vault) ImportLabelsCSV(r io.Reader, overwrite bool) (int, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	toSet := make(map[string]string)
+	skipped := make([]string, 0)
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: %w", ErrInvalidLabelsCSV, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		addr := strings.TrimSpace(record[0])
+		if row == 0 && strings.EqualFold(addr, "address") &&
+			strings.EqualFold(strings.TrimSpace(record[1]), "label") {
+			continue
+		}
+
+		label, err := validateLabel(record[1])
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: line %d: %w", ErrInvalidLabelsCSV, line, err)
+		}
+
+		info, ok := v.Addresses[addr]
+		if !ok {
+			skipped = append(skipped, addr)
+
+			continue
+		}
+
+		if info.Label != "" && !overwrite {
+			continue
+		}
+		toSet[addr] = label
+	}
+
+	if err := v.SetLabels(toSet); err != nil {
+		return 0, nil, err
+	}
+
+	return len(toSet), skipped, nil
+}
+
+// GroupSeparator separates the segments of a group, like "Treasury/Cold".
+const GroupSeparator = "/"
+
+// validateGroup normalizes the group and checks it.
+// Each segment is normalized like a label and the spaces around it are trimmed.
+// The separators at both ends are removed, and empty segments in the middle,
+// like "Ops//Hot", are rejected.
+func validateGroup(group string) (string, error) {
+	group = strings.Trim(normalizeLabel(group), " "+GroupSeparator)
+	if group == "" {
+		return "", nil
+	}
+
+	segments := strings.Split(group, GroupSeparator)
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(segment)
+		if segments[i] == "" {
+			return "", ErrInvalidGroup
+		}
+	}
+
+	group = strings.Join(segments, GroupSeparator)
+	if len(group) > MaxLabelLength {
+		return "", ErrInvalidGroup
+	}
+
+	return group, nil
+}
+
+// SetGroup sets the group of the address. An empty group removes the address
+// from its group.
+// Like the label, the group is only metadata and it doesn't change the address.
+// It returns ErrInvalidGroup if the group has an empty segment or it is too long.
+func (v *Vault) SetGroup(addr, group string) error {
+	info, ok := v.Addresses[addr]
+	if !ok {
+		return NewErrAddressNotFound(addr)
+	}
+
+	group, err := validateGroup(group)
+	if err != nil {
+		return err
+	}
+
+	if info.Group == group {
+		return nil
+	}
+
+	info.Group = group
+	v.Addresses[addr] = info
+	v.notify(EventLabelChanged, addr)
+
+	return nil
+}
+
+// AddressesByGroup returns the addresses in the given group and in all of its
+// subgroups. For example, "Treasury" matches "Treasury" and "Treasury/Cold",
+// but not "TreasuryOld". An empty group returns the addresses without group.
+// The addresses are sorted in the same order as AddressInfos.
+func (v *Vault) AddressesByGroup(group string) []AddressInfo {
+	group, err := validateGroup(group)
+	if err != nil {
+		return []AddressInfo{}
+	}
+
+	addrs := make([]AddressInfo, 0)
+	for _, info := range v.AddressInfos() {
+		if info.Group == group ||
+			(group != "" && strings.HasPrefix(info.Group, group+GroupSeparator)) {
+			addrs = append(addrs, info)
+		}
+	}
+
+	return addrs
+}
@@ -0,0 +1,428 @@
+package vault
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressesByLabel(t *testing.T) {
+	td := setup(t)
+
+	infos := td.vault.AddressesByLabel("validator-address")
+	require.Len(t, infos, 1)
+	assert.Equal(t, "m/12381'/21888'/1'/0", infos[0].Path)
+
+	assert.Empty(t, td.vault.AddressesByLabel("validator"))
+	assert.Empty(t, td.vault.AddressesByLabel("Validator-Address"))
+
+	t.Run("Empty label", func(t *testing.T) {
+		assert.Empty(t, td.vault.AddressesByLabel(""))
+
+		infos := td.vault.AddressesByLabel("validator-address")
+		require.NoError(t, td.vault.SetLabel(infos[0].Address, ""))
+		assert.Len(t, td.vault.AddressesByLabel(""), 1)
+	})
+}
+
+func TestSearchLabels(t *testing.T) {
+	td := setup(t)
+
+	assert.Len(t, td.vault.SearchLabels("ACCOUNT"), 4)
+
+	infos := td.vault.SearchLabels("-ACCOUNT-")
+	require.Len(t, infos, 2)
+	assert.Equal(t, "ed25519-account-address", infos[0].Label)
+	assert.Equal(t, "bls-account-address", infos[1].Label)
+
+	assert.Len(t, td.vault.SearchLabels(""), td.vault.AddressCount())
+	assert.Empty(t, td.vault.SearchLabels("exchange"))
+
+	t.Run("Relabeling updates the results", func(t *testing.T) {
+		infos := td.vault.AddressesByLabel("validator-address")
+		require.Len(t, infos, 1)
+
+		err := td.vault.SetLabel(infos[0].Address, "exchange-hot")
+		require.NoError(t, err)
+
+		assert.Empty(t, td.vault.AddressesByLabel("validator-address"))
+		found := td.vault.SearchLabels("Exchange")
+		require.Len(t, found, 1)
+		assert.Equal(t, infos[0].Address, found[0].Address)
+	})
+}
+
+func TestSetLabelNormalization(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressesByLabel("validator-address")[0].Address
+
+	t.Run("Combining characters are composed", func(t *testing.T) {
+		// "e" followed by the combining acute accent.
+		require.NoError(t, td.vault.SetLabel(addr, "cafe\u0301"))
+		assert.Equal(t, "caf\u00e9", td.vault.Label(addr))
+		assert.Len(t, td.vault.AddressesByLabel("caf\u00e9"), 1)
+	})
+
+	t.Run("Zero-width joiners are kept", func(t *testing.T) {
+		// Family emoji, joined by zero-width joiners.
+		family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+		require.NoError(t, td.vault.SetLabel(addr, family))
+		assert.Equal(t, family, td.vault.Label(addr))
+	})
+
+	t.Run("Control characters are removed", func(t *testing.T) {
+		require.NoError(t, td.vault.SetLabel(addr, "hot\x00\x1b[31m\u202ewallet\tmain\n"))
+		assert.Equal(t, "hot[31mwallet main ", td.vault.Label(addr))
+	})
+
+	t.Run("Invalid UTF-8 is removed", func(t *testing.T) {
+		require.NoError(t, td.vault.SetLabel(addr, "abc\xff"))
+		assert.Equal(t, "abc", td.vault.Label(addr))
+	})
+
+	t.Run("Label is too long", func(t *testing.T) {
+		// Each emoji is 4 bytes.
+		label := strings.Repeat("\U0001F600", MaxLabelLength/4)
+		require.NoError(t, td.vault.SetLabel(addr, label))
+		assert.Equal(t, label, td.vault.Label(addr))
+
+		err := td.vault.SetLabel(addr, label+"a")
+		require.ErrorIs(t, err, ErrLabelTooLong)
+		assert.Equal(t, label, td.vault.Label(addr))
+	})
+
+	t.Run("New addresses have normalized labels", func(t *testing.T) {
+		info, err := td.vault.NewBLSAccountAddress("cafe\u0301\n")
+		require.NoError(t, err)
+		assert.Equal(t, "caf\u00e9 ", info.Label)
+	})
+}
+
+func TestNewAddressLabelTooLong(t *testing.T) {
+	td := setup(t)
+
+	label := strings.Repeat("a", MaxLabelLength+1)
+	blsPub, _ := td.RandBLSKeyPair()
+	ed25519Pub, _ := td.RandEd25519KeyPair()
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"NewValidatorAddress", func() error {
+			_, err := td.vault.NewValidatorAddress(label)
+
+			return err
+		}},
+		{"NewBLSAccountAddress", func() error {
+			_, err := td.vault.NewBLSAccountAddress(label)
+
+			return err
+		}},
+		{"NewEd25519AccountAddress", func() error {
+			_, err := td.vault.NewEd25519AccountAddress(label, tPassword)
+
+			return err
+		}},
+		{"DeriveAddressesRange", func() error {
+			_, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 2, label)
+
+			return err
+		}},
+		{"NeuteredDeriveNext", func() error {
+			_, err := td.vault.NeuteredDeriveNext(PurposeBLS12381, crypto.AddressTypeBLSAccount, label)
+
+			return err
+		}},
+		{"DeriveAtPath", func() error {
+			_, err := td.vault.DeriveAtPath("m/12381'/21888'/2'/10", label)
+
+			return err
+		}},
+		{"ImportWatchOnlyPublicKey BLS", func() error {
+			_, err := td.vault.ImportWatchOnlyPublicKey(blsPub, label)
+
+			return err
+		}},
+		{"ImportWatchOnlyPublicKey Ed25519", func() error {
+			_, err := td.vault.ImportWatchOnlyPublicKey(ed25519Pub, label)
+
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := td.vault.Clone()
+
+			err := tt.fn()
+			require.ErrorIs(t, err, ErrLabelTooLong)
+			assert.Equal(t, before.Addresses, td.vault.Addresses)
+			assert.Equal(t, before.Purposes, td.vault.Purposes)
+		})
+	}
+}
+
+func TestNormalizeLabelsOnRead(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressesByLabel("validator-address")[0].Address
+	info := td.vault.Addresses[addr]
+	info.Label = "cafe\u0301\u200e"
+	td.vault.Addresses[addr] = info
+	td.vault.Version = VaultVersion2
+
+	data, err := json.Marshal(td.vault)
+	require.NoError(t, err)
+
+	restored := new(Vault)
+	require.NoError(t, json.Unmarshal(data, restored))
+	assert.True(t, restored.IsMigrated())
+	assert.Equal(t, CurrentVaultVersion, restored.Version)
+	assert.Equal(t, "caf\u00e9", restored.Label(addr))
+}
+
+func TestSetLabels(t *testing.T) {
+	td := setup(t)
+
+	validatorAddr := td.vault.AddressesByLabel("validator-address")[0].Address
+	accountAddr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+
+	t.Run("Unknown address aborts the batch", func(t *testing.T) {
+		unknownAddr := td.RandAccAddress().String()
+		err := td.vault.SetLabels(map[string]string{
+			validatorAddr: "validator-1",
+			unknownAddr:   "unknown",
+			accountAddr:   "account-1",
+		})
+		require.ErrorIs(t, err, NewErrAddressNotFound(unknownAddr))
+
+		assert.Equal(t, "validator-address", td.vault.Label(validatorAddr))
+		assert.Equal(t, "bls-account-address", td.vault.Label(accountAddr))
+	})
+
+	t.Run("Too long label aborts the batch", func(t *testing.T) {
+		err := td.vault.SetLabels(map[string]string{
+			validatorAddr: "validator-1",
+			accountAddr:   strings.Repeat("a", MaxLabelLength+1),
+		})
+		require.ErrorIs(t, err, ErrLabelTooLong)
+
+		assert.Equal(t, "validator-address", td.vault.Label(validatorAddr))
+		assert.Equal(t, "bls-account-address", td.vault.Label(accountAddr))
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		err := td.vault.SetLabels(map[string]string{
+			validatorAddr: "validator-1",
+			accountAddr:   "account-1\n",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "validator-1", td.vault.Label(validatorAddr))
+		assert.Equal(t, "account-1 ", td.vault.Label(accountAddr))
+	})
+
+	t.Run("Empty batch", func(t *testing.T) {
+		require.NoError(t, td.vault.SetLabels(nil))
+	})
+}
+
+func TestExportImportLabels(t *testing.T) {
+	td := setup(t)
+
+	validatorAddr := td.vault.AddressesByLabel("validator-address")[0].Address
+	accountAddr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+
+	data, err := td.vault.ExportLabels()
+	require.NoError(t, err)
+
+	labels := make(map[string]string)
+	require.NoError(t, json.Unmarshal(data, &labels))
+	assert.Len(t, labels, td.vault.AddressCount())
+	assert.Equal(t, "validator-address", labels[validatorAddr])
+	assert.NotContains(t, string(data), td.vault.KeyStore)
+
+	t.Run("Import into a neutered vault", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+		require.NoError(t, neutered.SetLabels(map[string]string{
+			validatorAddr: "",
+			accountAddr:   "my-account",
+		}))
+
+		require.NoError(t, neutered.ImportLabels(data, false))
+		assert.Equal(t, "validator-address", neutered.Label(validatorAddr))
+		assert.Equal(t, "my-account", neutered.Label(accountAddr))
+
+		require.NoError(t, neutered.ImportLabels(data, true))
+		assert.Equal(t, "bls-account-address", neutered.Label(accountAddr))
+	})
+
+	t.Run("Unknown addresses are skipped", func(t *testing.T) {
+		unknownAddr := td.RandAccAddress().String()
+		data := []byte(`{"` + unknownAddr + `": "unknown", "` + validatorAddr + `": "validator-1"}`)
+
+		require.NoError(t, td.vault.ImportLabels(data, true))
+		assert.Equal(t, "validator-1", td.vault.Label(validatorAddr))
+		assert.False(t, td.vault.Contains(unknownAddr))
+	})
+
+	t.Run("Invalid data", func(t *testing.T) {
+		err := td.vault.ImportLabels([]byte("invalid"), true)
+		require.Error(t, err)
+	})
+
+	t.Run("Too long label aborts the import", func(t *testing.T) {
+		data := []byte(`{"` + accountAddr + `": "account-1", "` + validatorAddr + `": "` +
+			strings.Repeat("a", MaxLabelLength+1) + `"}`)
+
+		err := td.vault.ImportLabels(data, true)
+		require.ErrorIs(t, err, ErrLabelTooLong)
+		assert.Equal(t, "bls-account-address", td.vault.Label(accountAddr))
+	})
+}
+
+func TestImportLabelsCSV(t *testing.T) {
+	td := setup(t)
+
+	validatorAddr := td.vault.AddressesByLabel("validator-address")[0].Address
+	accountAddr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+	unknownAddr := td.RandAccAddress().String()
+
+	t.Run("Keep existing labels", func(t *testing.T) {
+		require.NoError(t, td.vault.SetLabel(accountAddr, ""))
+
+		data := "address,label\n" +
+			validatorAddr + ",validator-1\n" +
+			accountAddr + ", \"account,\tmain\"\n" +
+			unknownAddr + ",unknown\n"
+
+		applied, skipped, err := td.vault.ImportLabelsCSV(strings.NewReader(data), false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+		assert.Equal(t, []string{unknownAddr}, skipped)
+		assert.Equal(t, "validator-address", td.vault.Label(validatorAddr))
+		assert.Equal(t, "account, main", td.vault.Label(accountAddr))
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		data := validatorAddr + ",validator-1\n"
+
+		applied, skipped, err := td.vault.ImportLabelsCSV(strings.NewReader(data), true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+		assert.Empty(t, skipped)
+		assert.Equal(t, "validator-1", td.vault.Label(validatorAddr))
+	})
+
+	t.Run("Malformed row", func(t *testing.T) {
+		data := validatorAddr + ",validator-2\n" +
+			accountAddr + ",account,extra\n"
+
+		_, _, err := td.vault.ImportLabelsCSV(strings.NewReader(data), true)
+		require.ErrorIs(t, err, ErrInvalidLabelsCSV)
+		assert.Contains(t, err.Error(), "line 2")
+		assert.Equal(t, "validator-1", td.vault.Label(validatorAddr))
+	})
+
+	t.Run("Too long label", func(t *testing.T) {
+		data := validatorAddr + ",validator-2\n" +
+			accountAddr + "," + strings.Repeat("a", MaxLabelLength+1) + "\n"
+
+		_, _, err := td.vault.ImportLabelsCSV(strings.NewReader(data), true)
+		require.ErrorIs(t, err, ErrLabelTooLong)
+		assert.Contains(t, err.Error(), "line 2")
+		assert.Equal(t, "validator-1", td.vault.Label(validatorAddr))
+	})
+}
+
+func TestSetGroup(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressesByLabel("validator-address")[0].Address
+
+	t.Run("Unknown address", func(t *testing.T) {
+		unknown := td.RandAccAddress().String()
+		err := td.vault.SetGroup(unknown, "Ops")
+		assert.ErrorIs(t, err, NewErrAddressNotFound(unknown))
+	})
+
+	t.Run("Invalid group", func(t *testing.T) {
+		invalids := []string{
+			"Ops//Hot",
+			"Ops/ /Hot",
+			strings.Repeat("a", MaxLabelLength+1),
+		}
+		for _, group := range invalids {
+			err := td.vault.SetGroup(addr, group)
+			assert.ErrorIs(t, err, ErrInvalidGroup, "group: %s", group)
+		}
+		assert.Empty(t, td.vault.Addresses[addr].Group)
+	})
+
+	t.Run("Normalization", func(t *testing.T) {
+		require.NoError(t, td.vault.SetGroup(addr, " /Treasury / Cold\t/"))
+		assert.Equal(t, "Treasury/Cold", td.vault.Addresses[addr].Group)
+		assert.Equal(t, "validator-address", td.vault.Label(addr))
+	})
+
+	t.Run("Remove from group", func(t *testing.T) {
+		require.NoError(t, td.vault.SetGroup(addr, ""))
+		assert.Empty(t, td.vault.Addresses[addr].Group)
+	})
+
+	t.Run("Survives serialization and migration", func(t *testing.T) {
+		require.NoError(t, td.vault.SetGroup(addr, "Treasury/Cold"))
+		td.vault.Version = VaultVersion2
+
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.True(t, restored.IsMigrated())
+		assert.Equal(t, "Treasury/Cold", restored.Addresses[addr].Group)
+	})
+}
+
+func TestAddressesByGroup(t *testing.T) {
+	td := setup(t)
+
+	groups := map[string]string{
+		"validator-address":       "Treasury/Cold",
+		"bls-account-address":     "Treasury/Hot/Daily",
+		"ed25519-account-address": "TreasuryOld",
+	}
+	for label, group := range groups {
+		addr := td.vault.AddressesByLabel(label)[0].Address
+		require.NoError(t, td.vault.SetGroup(addr, group))
+	}
+
+	labels := func(infos []AddressInfo) []string {
+		res := make([]string, 0, len(infos))
+		for _, info := range infos {
+			res = append(res, info.Label)
+		}
+
+		return res
+	}
+
+	assert.Equal(t, []string{"validator-address", "bls-account-address"},
+		labels(td.vault.AddressesByGroup("Treasury")))
+	assert.Equal(t, []string{"validator-address", "bls-account-address"},
+		labels(td.vault.AddressesByGroup("/Treasury/")))
+	assert.Equal(t, []string{"bls-account-address"},
+		labels(td.vault.AddressesByGroup("Treasury/Hot")))
+	assert.Equal(t, []string{"validator-address"},
+		labels(td.vault.AddressesByGroup("Treasury/Cold")))
+	assert.Equal(t, []string{"ed25519-account-address"},
+		labels(td.vault.AddressesByGroup("TreasuryOld")))
+	assert.Empty(t, td.vault.AddressesByGroup("Treasury/Warm"))
+	assert.Empty(t, td.vault.AddressesByGroup("Ops//Hot"))
+	assert.Len(t, td.vault.AddressesByGroup(""), td.vault.AddressCount()-3)
+}
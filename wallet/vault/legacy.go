@@ -0,0 +1,151 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+// LegacyFormat defines the format of a vault saved by an older wallet.
+type LegacyFormat int
+
+const (
+	// LegacyFormatWalletV1 is the wallet file of version 1, the initial version.
+	// It supports BLS addresses only, and some HD addresses are saved without
+	// the public key.
+	LegacyFormatWalletV1 = LegacyFormat(1)
+
+	// LegacyFormatWalletV2 is the wallet file of version 2, that supports Ed25519
+	// addresses. The key length of the password hasher is not saved, and it is
+	// 32 bytes for the encrypted vaults.
+	LegacyFormatWalletV2 = LegacyFormat(2)
+)
+
+func (f LegacyFormat) String() string {
+	switch f {
+	case LegacyFormatWalletV1:
+		return "wallet-v1"
+	case LegacyFormatWalletV2:
+		return "wallet-v2"
+	default:
+		return fmt.Sprintf("unknown legacy format: %d", int(f))
+	}
+}
+
+// legacyWallet is the wallet file of the legacy formats.
+// Only the vault is imported, the other fields, like the history, are ignored.
+type legacyWallet struct {
+	Version int             `json:"version"`
+	Vault   json.RawMessage `json:"vault"`
+}
+
+// ImportLegacyVault decodes the vault from a wallet file saved by an older
+// wallet, and upgrades it to the current format.
+// The supported formats are the wallet files of version 1 and 2, see
+// LegacyFormatWalletV1 and LegacyFormatWalletV2.
+// The vault is upgraded by UpgradeLegacy, then the addresses are checked to match
+// what the legacy wallet showed, otherwise ErrInvalidLegacyVault is returned.
+// Like opening these wallet files, the CRC of the vault is not checked, since it
+// can't be recalculated from the legacy encoding.
+// The password is used to verify the addresses against the key store, and it
+// is not used for neutered vaults.
+func ImportLegacyVault(data []byte, format LegacyFormat, password string) (*Vault, error) {
+	if format != LegacyFormatWalletV1 && format != LegacyFormatWalletV2 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLegacyVault, format)
+	}
+
+	wallet := new(legacyWallet)
+	if err := json.Unmarshal(data, wallet); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLegacyVault, err)
+	}
+
+	if wallet.Version != int(format) {
+		return nil, fmt.Errorf("%w: wallet version %d doesn't match %s",
+			ErrInvalidLegacyVault, wallet.Version, format)
+	}
+
+	vlt := new(Vault)
+	if err := json.Unmarshal(wallet.Vault, vlt); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLegacyVault, err)
+	}
+
+	if err := vlt.UpgradeLegacy(format); err != nil {
+		return nil, err
+	}
+
+	issues, err := vlt.Verify(password)
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) > 0 {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidLegacyVault, issues[0].Address, issues[0].Reason)
+	}
+
+	return vlt, nil
+}
+
+// UpgradeLegacy upgrades the vault decoded from a wallet file of the given legacy
+// format to the current wallet format:
+//   - Version 1: the missing public keys of the BLS HD addresses are derived
+//     from the extended public keys.
+//   - Version 2: the key length of the password hasher is set to 32 bytes, if the
+//     vault is encrypted.
+//
+// The upgrades are applied in order, so a vault of version 1 gets both of them.
+// It is used by ImportLegacyVault, and by the wallet when it opens a legacy wallet file.
+func (v *Vault) UpgradeLegacy(format LegacyFormat) error {
+	switch format {
+	case LegacyFormatWalletV1:
+		if err := v.setLegacyPublicKeys(); err != nil {
+			return err
+		}
+
+		fallthrough
+
+	case LegacyFormatWalletV2:
+		if v.IsEncrypted() {
+			v.Encrypter.Params.SetUint32("keylen", 32)
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidLegacyVault, format)
+	}
+}
+
+// setLegacyPublicKeys derives the public keys of the BLS HD addresses that are
+// saved without the public key, and checks that they derive the same address.
+func (v *Vault) setLegacyPublicKeys() error {
+	for addr, info := range v.Addresses {
+		if info.PublicKey != "" {
+			continue
+		}
+
+		addrPath, err := addresspath.FromString(info.Path)
+		if err != nil || len(addrPath) != 4 {
+			return fmt.Errorf("%w: %s: invalid path: %s", ErrInvalidLegacyVault, addr, info.Path)
+		}
+
+		addressType := crypto.AddressType(_N(addrPath.AddressType()))
+		ext, _, err := v.blsExtendedKey(_N(addrPath.Purpose()), addressType)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidLegacyVault, addr, err)
+		}
+
+		derived, err := deriveBLSAddressInfo(ext, addressType, addrPath.AddressIndex())
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidLegacyVault, addr, err)
+		}
+		if derived.Address != addr {
+			return fmt.Errorf("%w: %s: path derives to %s", ErrInvalidLegacyVault, addr, derived.Address)
+		}
+
+		info.PublicKey = derived.PublicKey
+		v.Addresses[addr] = info
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package vault
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pactus-project/pactus/util"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportLegacyVault(t *testing.T) {
+	// password is: "password"
+	tests := []struct {
+		walletPath string
+		format     LegacyFormat
+		addresses  map[string]string
+	}{
+		{
+			"../testdata/wallet_version_1", LegacyFormatWalletV1,
+			map[string]string{
+				"pc1p4xuja689hg2434yhr32clhn97x6afw58qlrcyd": "m/65535'/21888'/1'/0'",
+				"pc1z4xuja689hg2434yhr32clhn97x6afw58a5n9ns": "m/65535'/21888'/2'/0'",
+				"pc1pjneygutecly9gtandrdt8j36v8g4fl42k4y5xp": "m/12381'/21888'/1'/0",
+				"pc1z0m0vw8sjfgv7f2zgq2hfxutg8rwn7gpffhe8tf": "m/12381'/21888'/2'/0",
+			},
+		},
+		{
+			"../testdata/wallet_version_2", LegacyFormatWalletV2,
+			map[string]string{
+				"pc1p4xuja689hg2434yhr32clhn97x6afw58qlrcyd": "m/65535'/21888'/1'/0'",
+				"pc1z4xuja689hg2434yhr32clhn97x6afw58a5n9ns": "m/65535'/21888'/2'/0'",
+				"pc1pjneygutecly9gtandrdt8j36v8g4fl42k4y5xp": "m/12381'/21888'/1'/0",
+				"pc1z0m0vw8sjfgv7f2zgq2hfxutg8rwn7gpffhe8tf": "m/12381'/21888'/2'/0",
+				"pc1rcx9x55nfme5juwdgxd2ksjdcmhvmvkrygmxpa3": "m/44'/21888'/3'/0'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			data, err := util.ReadFile(tt.walletPath)
+			require.NoError(t, err)
+
+			vlt, err := ImportLegacyVault(data, tt.format, "password")
+			require.NoError(t, err)
+
+			assert.Equal(t, CurrentVaultVersion, vlt.Version)
+			assert.Equal(t, uint32(32), vlt.Encrypter.Params.GetUint32("keylen"))
+			assert.Equal(t, len(tt.addresses), vlt.AddressCount())
+			for addr, path := range tt.addresses {
+				info := vlt.AddressInfo(addr)
+				require.NotNil(t, info, "address %s is not imported", addr)
+				assert.Equal(t, path, info.Path)
+				assert.NotEmpty(t, info.PublicKey)
+
+				pub, err := vlt.PublicKey(addr)
+				require.NoError(t, err)
+				assert.Equal(t, info.PublicKey, pub.String())
+			}
+
+			mnemonic, err := vlt.Mnemonic("password")
+			require.NoError(t, err)
+			//nolint:dupword // duplicated seed phrase words
+			assert.Equal(t,
+				"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon cactus", mnemonic)
+
+			// The imported vault keeps deriving the same addresses as the legacy wallet.
+			info, err := vlt.NewBLSAccountAddress("")
+			require.NoError(t, err)
+			assert.Equal(t, "m/12381'/21888'/2'/1", info.Path)
+		})
+	}
+
+	t.Run("Invalid password", func(t *testing.T) {
+		data, err := util.ReadFile("../testdata/wallet_version_1")
+		require.NoError(t, err)
+
+		_, err = ImportLegacyVault(data, LegacyFormatWalletV1, "invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Mismatched format", func(t *testing.T) {
+		data, err := util.ReadFile("../testdata/wallet_version_2")
+		require.NoError(t, err)
+
+		_, err = ImportLegacyVault(data, LegacyFormatWalletV1, "password")
+		assert.ErrorIs(t, err, ErrInvalidLegacyVault)
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		data, err := util.ReadFile("../testdata/wallet_version_1")
+		require.NoError(t, err)
+
+		_, err = ImportLegacyVault(data, LegacyFormat(3), "password")
+		assert.ErrorIs(t, err, ErrInvalidLegacyVault)
+	})
+
+	t.Run("Address doesn't match the path", func(t *testing.T) {
+		data, err := util.ReadFile("../testdata/wallet_version_1")
+		require.NoError(t, err)
+		data = bytes.Replace(data,
+			[]byte(`"path": "m/12381'/21888'/2'/0"`), []byte(`"path": "m/12381'/21888'/2'/5"`), 1)
+
+		_, err = ImportLegacyVault(data, LegacyFormatWalletV1, "password")
+		assert.ErrorIs(t, err, ErrInvalidLegacyVault)
+		assert.ErrorContains(t, err, "path derives to")
+	})
+
+	t.Run("Malformed data", func(t *testing.T) {
+		_, err := ImportLegacyVault([]byte("not a wallet"), LegacyFormatWalletV1, "password")
+		assert.ErrorIs(t, err, ErrInvalidLegacyVault)
+	})
+}
@@ -0,0 +1,202 @@
+package vault
+
+import (
+	"github.com/pactus-project/pactus/wallet/addresspath"
+	"golang.org/x/exp/slices"
+)
+
+// LabelResolver resolves the conflict between the labels of an address in two vaults.
+// It returns the label that should be kept.
+type LabelResolver func(addr, label, otherLabel string) string
+
+// DiffAddresses compares the addresses of this vault with the other vault,
+// like a watch-only copy, by the address strings.
+// It returns the addresses that exist only in this vault and only in the
+// other vault, both sorted. The labels and other metadata are not compared.
+// No password is needed, so it can be used to check two vaults before Merge.
+func (v *Vault) DiffAddresses(other *Vault) ([]string, []string) {
+	onlyHere := make([]string, 0)
+	for addr := range v.Addresses {
+		if !other.Contains(addr) {
+			onlyHere = append(onlyHere, addr)
+		}
+	}
+
+	onlyThere := make([]string, 0)
+	for addr := range other.Addresses {
+		if !v.Contains(addr) {
+			onlyThere = append(onlyThere, addr)
+		}
+	}
+
+	slices.Sort(onlyHere)
+	slices.Sort(onlyThere)
+
+	return onlyHere, onlyThere
+}
+
+// Merge combines the addresses, imported private keys and labels of the other vault
+// into this vault. Both vaults should be created from the same seed and be encrypted
+// with the same password.
+// The next index of each purpose is advanced to the maximum of the two vaults.
+// For an address with different labels, the non-empty label is kept.
+// If both labels are set, the label of this vault is kept.
+// Use MergeWithResolver to resolve the conflicting labels.
+func (v *Vault) Merge(other *Vault, password string) error {
+	return v.MergeWithResolver(other, password, nil)
+}
+
+// MergeWithResolver works like Merge, but calls the resolver when an address has
+// different non-empty labels in the two vaults.
+//
+// The merge is atomic: if it fails, this vault remains unchanged.
+func (v *Vault) MergeWithResolver(other *Vault, password string, resolve LabelResolver) error {
+	if v.IsNeutered() {
+		return ErrNeutered
+	}
+
+	if v.CoinType != other.CoinType {
+		return ErrInvalidCoinType
+	}
+
+	if !v.hasSameSeed(other) {
+		return FingerprintMismatchError{
+			Fingerprint:      v.Fingerprint,
+			OtherFingerprint: other.Fingerprint,
+		}
+	}
+
+	merged := make(map[string]AddressInfo, len(v.Addresses)+len(other.Addresses))
+	for addr, info := range v.Addresses {
+		merged[addr] = info
+	}
+
+	var keyStore, otherKeyStore *keyStore
+	importedIndexes := make(map[uint32]uint32)
+	watchOnlyIndexes := make(map[uint32]uint32)
+	nextWatchOnlyIndex := v.nextPurposeIndex(PurposeWatchOnly)
+
+	for _, otherInfo := range other.AddressInfos() {
+		if info, ok := merged[otherInfo.Address]; ok {
+			info.Label = normalizeLabel(mergeLabel(info.Address, info.Label, otherInfo.Label, resolve))
+			merged[info.Address] = info
+
+			continue
+		}
+
+		addrPath, err := addresspath.FromString(otherInfo.Path)
+		if err != nil {
+			return err
+		}
+
+		switch addrPath.Purpose() {
+		case _H(PurposeImportPrivateKey):
+			if keyStore == nil {
+				keyStore, err = v.decryptKeyStore(password)
+				if err != nil {
+					return err
+				}
+				otherKeyStore, err = other.decryptKeyStore(password)
+				if err != nil {
+					return err
+				}
+			}
+
+			otherIndex := _N(addrPath.AddressIndex())
+			index, ok := importedIndexes[otherIndex]
+			if !ok {
+				if int(otherIndex) >= len(otherKeyStore.ImportedKeys) {
+					return ErrInvalidPath
+				}
+				index = importKeyIndex(keyStore, otherKeyStore.ImportedKeys[otherIndex])
+				importedIndexes[otherIndex] = index
+			}
+			otherInfo.Path = addresspath.NewPath(addrPath[0], addrPath[1], addrPath[2], _H(index)).String()
+
+		case _H(PurposeWatchOnly):
+			// Watch-only addresses with the same index share the same public key.
+			otherIndex := _N(addrPath.AddressIndex())
+			index, ok := watchOnlyIndexes[otherIndex]
+			if !ok {
+				index = nextWatchOnlyIndex
+				nextWatchOnlyIndex++
+				watchOnlyIndexes[otherIndex] = index
+			}
+			otherInfo.Path = addresspath.NewPath(addrPath[0], addrPath[1], addrPath[2], _H(index)).String()
+		}
+
+		merged[otherInfo.Address] = otherInfo
+	}
+
+	if keyStore != nil {
+		err := v.encryptKeyStore(keyStore, password)
+		if err != nil {
+			return err
+		}
+	}
+
+	previous := v.Addresses
+	v.Addresses = merged
+	v.Purposes.PurposeBLS.NextAccountIndex = max(v.Purposes.PurposeBLS.NextAccountIndex,
+		other.Purposes.PurposeBLS.NextAccountIndex)
+	v.Purposes.PurposeBLS.NextValidatorIndex = max(v.Purposes.PurposeBLS.NextValidatorIndex,
+		other.Purposes.PurposeBLS.NextValidatorIndex)
+	v.Purposes.PurposeBIP44.NextEd25519Index = max(v.Purposes.PurposeBIP44.NextEd25519Index,
+		other.Purposes.PurposeBIP44.NextEd25519Index)
+	if v.Fingerprint == "" {
+		v.Fingerprint = other.Fingerprint
+	}
+
+	addrs := make([]string, 0, len(merged))
+	for addr := range merged {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+	for _, addr := range addrs {
+		previousInfo, ok := previous[addr]
+		switch {
+		case !ok:
+			v.notify(EventAddressAdded, addr)
+		case previousInfo.Label != merged[addr].Label:
+			v.notify(EventLabelChanged, addr)
+		}
+	}
+
+	return nil
+}
+
+// hasSameSeed checks if both vaults are created from the same seed.
+// Old vaults don't have the fingerprint, so the extended public keys are compared.
+func (v *Vault) hasSameSeed(other *Vault) bool {
+	if v.MasterFingerprint() != 0 && other.MasterFingerprint() != 0 {
+		return v.MasterFingerprint() == other.MasterFingerprint()
+	}
+
+	return v.Purposes.PurposeBLS.XPubAccount == other.Purposes.PurposeBLS.XPubAccount
+}
+
+// importKeyIndex returns the index of the imported key in the key store.
+// If the key doesn't exist, it is appended to the key store.
+func importKeyIndex(keyStore *keyStore, key string) uint32 {
+	for i, importedKey := range keyStore.ImportedKeys {
+		if importedKey == key {
+			return uint32(i)
+		}
+	}
+	keyStore.ImportedKeys = append(keyStore.ImportedKeys, key)
+
+	return uint32(len(keyStore.ImportedKeys) - 1)
+}
+
+func mergeLabel(addr, label, otherLabel string, resolve LabelResolver) string {
+	switch {
+	case label == otherLabel, otherLabel == "":
+		return label
+	case label == "":
+		return otherLabel
+	case resolve != nil:
+		return resolve(addr, label, otherLabel)
+	default:
+		return label
+	}
+}
@@ -0,0 +1,176 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+)
+
+func newVaultFromMnemonic(t *testing.T, mnemonic string) *Vault {
+	t.Helper()
+
+	vlt, err := CreateVaultFromMnemonic(mnemonic, 21888)
+	require.NoError(t, err)
+	require.NoError(t, vlt.UpdatePassword("", tPassword,
+		encrypter.OptionIteration(1),
+		encrypter.OptionMemory(8),
+		encrypter.OptionParallelism(1)))
+
+	return vlt
+}
+
+func TestDiffAddresses(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Same addresses", func(t *testing.T) {
+		watchOnly := td.vault.Neuter()
+		addr := td.vault.AddressesByLabel("validator-address")[0].Address
+		require.NoError(t, watchOnly.SetLabel(addr, "other-label"))
+
+		onlyHere, onlyThere := td.vault.DiffAddresses(watchOnly)
+		assert.Empty(t, onlyHere)
+		assert.Empty(t, onlyThere)
+	})
+
+	t.Run("Drifted vaults", func(t *testing.T) {
+		watchOnly := td.vault.Neuter()
+
+		info1, err := td.vault.NewBLSAccountAddress("")
+		require.NoError(t, err)
+		info2, err := td.vault.NewValidatorAddress("")
+		require.NoError(t, err)
+
+		pub, _ := td.RandEd25519KeyPair()
+		info3, err := watchOnly.ImportWatchOnlyPublicKey(pub, "")
+		require.NoError(t, err)
+
+		removed := td.vault.AddressesByLabel("ed25519-account-address")[0].Address
+		require.NoError(t, watchOnly.RemoveAddress(removed, "", true))
+
+		onlyHere, onlyThere := td.vault.DiffAddresses(watchOnly)
+		wantHere := []string{info1.Address, info2.Address, removed}
+		slices.Sort(wantHere)
+		assert.Equal(t, wantHere, onlyHere)
+		assert.Equal(t, []string{info3.Address}, onlyThere)
+
+		onlyHere, onlyThere = watchOnly.DiffAddresses(td.vault)
+		assert.Equal(t, []string{info3.Address}, onlyHere)
+		assert.Equal(t, wantHere, onlyThere)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	td := setup(t)
+
+	other := newVaultFromMnemonic(t, td.mnemonic)
+
+	// Same addresses with different labels
+	info1, err := other.NewBLSAccountAddress("")
+	require.NoError(t, err)
+	info2, err := other.NewValidatorAddress("other-validator")
+	require.NoError(t, err)
+	_, err = other.NewEd25519AccountAddress("other-ed25519", tPassword)
+	require.NoError(t, err)
+
+	// New addresses
+	infos, err := other.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 2, "other-")
+	require.NoError(t, err)
+
+	// Imported keys, one is already imported in the vault
+	require.NoError(t, other.ImportEd25519PrivateKey(tPassword, td.importedEd25519Prv))
+	_, otherPrv := td.RandBLSKeyPair()
+	require.NoError(t, other.ImportBLSPrivateKey(tPassword, otherPrv))
+
+	// Watch-only key
+	otherPub, _ := td.RandEd25519KeyPair()
+	watchOnlyInfo, err := other.ImportWatchOnlyPublicKey(otherPub, "watch-only")
+	require.NoError(t, err)
+
+	addressCount := td.vault.AddressCount()
+	require.NoError(t, td.vault.Merge(other, tPassword))
+
+	// 2 derived + 2 imported BLS + 1 watch-only
+	assert.Equal(t, addressCount+5, td.vault.AddressCount())
+	assert.Equal(t, uint32(3), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeBLSAccount))
+
+	assert.Equal(t, "bls-account-address", td.vault.Label(info1.Address))
+	assert.Equal(t, "validator-address", td.vault.Label(info2.Address))
+	for _, info := range infos {
+		assert.Equal(t, info, *td.vault.AddressInfo(info.Address))
+	}
+
+	// The imported key is re-indexed.
+	otherAddr := otherPrv.PublicKeyNative().AccountAddress().String()
+	assert.Equal(t, "m/65535'/21888'/2'/2'", td.vault.AddressInfo(otherAddr).Path)
+	prvs, err := td.vault.PrivateKeys(tPassword, []string{otherAddr})
+	assert.NoError(t, err)
+	assert.Equal(t, otherPrv, prvs[0])
+
+	keyStore, err := td.vault.decryptKeyStore(tPassword)
+	require.NoError(t, err)
+	assert.Len(t, keyStore.ImportedKeys, 3, "duplicated key should not be imported")
+
+	assert.Equal(t, watchOnlyInfo.Path, td.vault.AddressInfo(watchOnlyInfo.Address).Path)
+}
+
+func TestMergeWithResolver(t *testing.T) {
+	td := setup(t)
+
+	other := newVaultFromMnemonic(t, td.mnemonic)
+	info, err := other.NewBLSAccountAddress("other-label")
+	require.NoError(t, err)
+
+	err = td.vault.MergeWithResolver(other, tPassword, func(addr, label, otherLabel string) string {
+		assert.Equal(t, info.Address, addr)
+		assert.Equal(t, "bls-account-address", label)
+		assert.Equal(t, "other-label", otherLabel)
+
+		return otherLabel
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "other-label", td.vault.Label(info.Address))
+}
+
+func TestMergeErrors(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Different seeds", func(t *testing.T) {
+		mnemonic, _ := GenerateMnemonic(128)
+		other := newVaultFromMnemonic(t, mnemonic)
+
+		err := td.vault.Merge(other, tPassword)
+		var mismatchErr FingerprintMismatchError
+		assert.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, td.vault.Fingerprint, mismatchErr.Fingerprint)
+	})
+
+	t.Run("Different coin types", func(t *testing.T) {
+		other, err := CreateVaultFromMnemonic(td.mnemonic, 21777)
+		require.NoError(t, err)
+
+		err = td.vault.Merge(other, tPassword)
+		assert.ErrorIs(t, err, ErrInvalidCoinType)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		other := newVaultFromMnemonic(t, td.mnemonic)
+
+		err := td.vault.Neuter().Merge(other, tPassword)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		other := newVaultFromMnemonic(t, td.mnemonic)
+		_, prv := td.RandBLSKeyPair()
+		require.NoError(t, other.ImportBLSPrivateKey(tPassword, prv))
+
+		addressCount := td.vault.AddressCount()
+		err := td.vault.Merge(other, "invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.Equal(t, addressCount, td.vault.AddressCount())
+	})
+}
@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"strings"
+
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+const (
+	VaultVersion1 = 1 // Initial format, without the version field
+	VaultVersion2 = 2 // Explicit version and password hasher in the encryption method
+	VaultVersion3 = 3 // Normalized labels
+	VaultVersion4 = 4 // Origin of the address keys
+
+	CurrentVaultVersion = VaultVersion4
+)
+
+// IsMigrated returns true if the vault was decoded from an older format and
+// upgraded to the current version.
+// A migrated vault should be saved again to persist the new format.
+func (v *Vault) IsMigrated() bool {
+	return v.migratedFrom != 0
+}
+
+// migrate upgrades a decoded vault from older formats to the current version.
+// Each step upgrades the vault by one version, so old vaults pass through all
+// the steps in order.
+func migrate(v *Vault) error {
+	if v.Version == 0 {
+		v.Version = VaultVersion1
+	}
+
+	if v.Version > CurrentVaultVersion {
+		return UnsupportedVaultVersionError{
+			Version:          v.Version,
+			SupportedVersion: CurrentVaultVersion,
+		}
+	}
+
+	if v.Version == CurrentVaultVersion {
+		return nil
+	}
+
+	v.migratedFrom = v.Version
+
+	if v.Version == VaultVersion1 {
+		migrateV1ToV2(v)
+		v.Version = VaultVersion2
+	}
+
+	if v.Version == VaultVersion2 {
+		migrateV2ToV3(v)
+		v.Version = VaultVersion3
+	}
+
+	if v.Version == VaultVersion3 {
+		migrateV3ToV4(v)
+		v.Version = VaultVersion4
+	}
+
+	return nil
+}
+
+// migrateV1ToV2 upgrades the vault from version 1 to version 2.
+func migrateV1ToV2(v *Vault) {
+	if v.Addresses == nil {
+		v.Addresses = make(map[string]AddressInfo)
+	}
+
+	// Early encrypted vaults didn't name the password hasher in the encryption
+	// method, and Argon2id was the only supported hasher.
+	if v.IsEncrypted() && strings.Count(v.Encrypter.Method, "-") == 1 {
+		v.Encrypter.Method = "ARGON2ID-" + v.Encrypter.Method
+	}
+
+	// Watch-only addresses were recognized by their purpose only.
+	for addr, info := range v.Addresses {
+		path, err := addresspath.FromString(info.Path)
+		if err != nil {
+			continue
+		}
+
+		if path.Purpose() == PurposeWatchOnlyHardened && !info.IsWatchOnly {
+			info.IsWatchOnly = true
+			v.Addresses[addr] = info
+		}
+	}
+}
+
+// migrateV2ToV3 upgrades the vault from version 2 to version 3.
+func migrateV2ToV3(v *Vault) {
+	// Labels were stored as given, without normalization.
+	for addr, info := range v.Addresses {
+		info.Label = normalizeLabel(info.Label)
+		v.Addresses[addr] = info
+	}
+}
+
+// migrateV3ToV4 upgrades the vault from version 3 to version 4.
+func migrateV3ToV4(v *Vault) {
+	// The origin of the keys was only known from the purpose of the path.
+	for addr, info := range v.Addresses {
+		path, err := addresspath.FromString(info.Path)
+		if err != nil || len(path) == 0 {
+			continue
+		}
+
+		switch path.Purpose() {
+		case PurposeImportPrivateKeyHardened:
+			info.Origin = OriginImported
+		case PurposeWatchOnlyHardened:
+			info.Origin = OriginWatchOnly
+		default:
+			info.Origin = OriginHDDerived
+		}
+		v.Addresses[addr] = info
+	}
+}
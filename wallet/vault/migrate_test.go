@@ -0,0 +1,96 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pactus-project/pactus/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateVersion1(t *testing.T) {
+	// password is: "password"
+	data, err := util.ReadFile("./testdata/vault_version_1")
+	require.NoError(t, err)
+
+	vlt := new(Vault)
+	require.NoError(t, json.Unmarshal(data, vlt))
+
+	assert.Equal(t, CurrentVaultVersion, vlt.Version)
+	assert.True(t, vlt.IsMigrated())
+	assert.NotEqual(t, vlt.calcCRC(), vlt.DecodedCRC(), "CRC is calculated before the migration")
+	assert.Equal(t, "ARGON2ID-AES_256_CTR-MACV1", vlt.Encrypter.Method)
+
+	labels := map[string]string{
+		"pc1z0m0vw8sjfgv7f2zgq2hfxutg8rwn7gpffhe8tf": "bls-account",
+		"pc1rcx9x55nfme5juwdgxd2ksjdcmhvmvkrygmxpa3": "ed25519-account",
+		"pc1pjneygutecly9gtandrdt8j36v8g4fl42k4y5xp": "validator",
+		"pc1zq2rh8928wzduk0mcgytjv8kt8t33su3djtw8uh": "watch-only",
+		"pc1pq2rh8928wzduk0mcgytjv8kt8t33su3d0q76t2": "watch-only",
+	}
+	assert.Equal(t, len(labels), vlt.AddressCount())
+	for addr, label := range labels {
+		info := vlt.AddressInfo(addr)
+		require.NotNil(t, info)
+		assert.Equal(t, label, info.Label)
+		assert.Equal(t, label == "watch-only", info.IsWatchOnly)
+		if label == "watch-only" {
+			assert.Equal(t, OriginWatchOnly, info.Origin)
+		} else {
+			assert.Equal(t, OriginHDDerived, info.Origin)
+		}
+	}
+
+	mnemonic, err := vlt.Mnemonic("password")
+	require.NoError(t, err)
+	//nolint:dupword // duplicated seed phrase words
+	assert.Equal(t,
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon cactus", mnemonic)
+
+	addr := "pc1z0m0vw8sjfgv7f2zgq2hfxutg8rwn7gpffhe8tf"
+	prvs, err := vlt.PrivateKeys("password", []string{addr})
+	require.NoError(t, err)
+	assert.Equal(t, vlt.AddressInfo(addr).PublicKey, prvs[0].PublicKey().String())
+
+	t.Run("Migrated vault is saved in the current format", func(t *testing.T) {
+		data, err := json.Marshal(vlt)
+		require.NoError(t, err)
+
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+
+		assert.False(t, restored.IsMigrated())
+		assert.Equal(t, CurrentVaultVersion, restored.Version)
+		assert.Equal(t, vlt.Addresses, restored.Addresses)
+		assert.True(t, vlt.Equal(restored))
+	})
+}
+
+func TestMigrateCurrentVersion(t *testing.T) {
+	td := setup(t)
+
+	assert.Equal(t, CurrentVaultVersion, td.vault.Version)
+
+	data, err := json.Marshal(td.vault)
+	require.NoError(t, err)
+
+	restored := new(Vault)
+	require.NoError(t, json.Unmarshal(data, restored))
+	assert.False(t, restored.IsMigrated())
+	assert.Equal(t, CurrentVaultVersion, restored.Version)
+}
+
+func TestMigrateUnsupportedVersion(t *testing.T) {
+	td := setup(t)
+
+	td.vault.Version = CurrentVaultVersion + 1
+	data, err := json.Marshal(td.vault)
+	require.NoError(t, err)
+
+	err = json.Unmarshal(data, new(Vault))
+	require.ErrorIs(t, err, UnsupportedVaultVersionError{
+		Version:          CurrentVaultVersion + 1,
+		SupportedVersion: CurrentVaultVersion,
+	})
+}
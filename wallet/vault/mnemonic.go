@@ -0,0 +1,357 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language defines the wordlist that is used to encode a mnemonic based on BIP-39.
+// https://github.com/bitcoin/bips/blob/master/bip-0039/bip-0039-wordlists.md
+type Language int
+
+const (
+	LanguageEnglish            = Language(0)
+	LanguageChineseSimplified  = Language(1)
+	LanguageChineseTraditional = Language(2)
+	LanguageCzech              = Language(3)
+	LanguageFrench             = Language(4)
+	LanguageItalian            = Language(5)
+	LanguageJapanese           = Language(6)
+	LanguageKorean             = Language(7)
+	LanguageSpanish            = Language(8)
+)
+
+// MnemonicStrength defines the entropy size of a mnemonic in bits.
+type MnemonicStrength int
+
+const (
+	Words12 = MnemonicStrength(128)
+	Words15 = MnemonicStrength(160)
+	Words18 = MnemonicStrength(192)
+	Words21 = MnemonicStrength(224)
+	Words24 = MnemonicStrength(256)
+)
+
+// WordCount returns the number of words in a mnemonic with this strength.
+func (s MnemonicStrength) WordCount() int {
+	// Each word encodes 11 bits, and the checksum is one bit per 32 bits of entropy.
+	return (int(s) + int(s)/32) / 11
+}
+
+func (s MnemonicStrength) validate() error {
+	if s%32 != 0 || s < Words12 || s > Words24 {
+		return InvalidEntropySizeError(s)
+	}
+
+	return nil
+}
+
+// GenerateMnemonicStrength generates a new English mnemonic (seed phrase)
+// with the given strength.
+func GenerateMnemonicStrength(strength MnemonicStrength) (string, error) {
+	return GenerateMnemonicWithLanguage(int(strength), LanguageEnglish)
+}
+
+// languages keeps the order used for detecting the language of a mnemonic.
+var languages = []Language{
+	LanguageEnglish,
+	LanguageChineseSimplified,
+	LanguageChineseTraditional,
+	LanguageCzech,
+	LanguageFrench,
+	LanguageItalian,
+	LanguageJapanese,
+	LanguageKorean,
+	LanguageSpanish,
+}
+
+// wordList keeps the NFKD normalized words of a language.
+type wordList struct {
+	words   []string
+	wordSet map[string]struct{}
+}
+
+var (
+	// wordListLock guards the bip39 package, which keeps the wordlist as a global variable.
+	wordListLock        sync.Mutex
+	normalizedWordLists = make(map[Language]*wordList)
+)
+
+func (l Language) String() string {
+	switch l {
+	case LanguageEnglish:
+		return "english"
+	case LanguageChineseSimplified:
+		return "chinese_simplified"
+	case LanguageChineseTraditional:
+		return "chinese_traditional"
+	case LanguageCzech:
+		return "czech"
+	case LanguageFrench:
+		return "french"
+	case LanguageItalian:
+		return "italian"
+	case LanguageJapanese:
+		return "japanese"
+	case LanguageKorean:
+		return "korean"
+	case LanguageSpanish:
+		return "spanish"
+
+	default:
+		return "unknown-language"
+	}
+}
+
+func (l Language) wordList() ([]string, error) {
+	switch l {
+	case LanguageEnglish:
+		return wordlists.English, nil
+	case LanguageChineseSimplified:
+		return wordlists.ChineseSimplified, nil
+	case LanguageChineseTraditional:
+		return wordlists.ChineseTraditional, nil
+	case LanguageCzech:
+		return wordlists.Czech, nil
+	case LanguageFrench:
+		return wordlists.French, nil
+	case LanguageItalian:
+		return wordlists.Italian, nil
+	case LanguageJapanese:
+		return wordlists.Japanese, nil
+	case LanguageKorean:
+		return wordlists.Korean, nil
+	case LanguageSpanish:
+		return wordlists.Spanish, nil
+
+	default:
+		return nil, ErrUnsupportedLanguage
+	}
+}
+
+// normalizedWordList returns the NFKD normalized wordlist of the language.
+// It should be called while holding wordListLock.
+func (l Language) normalizedWordList() (*wordList, error) {
+	normalized, ok := normalizedWordLists[l]
+	if ok {
+		return normalized, nil
+	}
+
+	words, err := l.wordList()
+	if err != nil {
+		return nil, err
+	}
+
+	normalized = &wordList{
+		words:   make([]string, len(words)),
+		wordSet: make(map[string]struct{}, len(words)),
+	}
+	for i, word := range words {
+		normalized.words[i] = norm.NFKD.String(word)
+		normalized.wordSet[normalized.words[i]] = struct{}{}
+	}
+	normalizedWordLists[l] = normalized
+
+	return normalized, nil
+}
+
+// GenerateMnemonicWithLanguage generates a new mnemonic (seed phrase) using
+// the wordlist of the given language.
+func GenerateMnemonicWithLanguage(bitSize int, lang Language) (string, error) {
+	if err := MnemonicStrength(bitSize).validate(); err != nil {
+		return "", err
+	}
+
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+
+	return mnemonicFromEntropy(entropy, lang)
+}
+
+// mnemonicFromEntropy encodes the entropy to a mnemonic using the wordlist of
+// the given language.
+func mnemonicFromEntropy(entropy []byte, lang Language) (string, error) {
+	words, err := lang.wordList()
+	if err != nil {
+		return "", err
+	}
+
+	wordListLock.Lock()
+	defer wordListLock.Unlock()
+
+	bip39.SetWordList(words)
+	defer bip39.SetWordList(wordlists.English)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", err
+	}
+
+	if lang == LanguageJapanese {
+		// Japanese mnemonics are separated by the ideographic space.
+		mnemonic = strings.ReplaceAll(mnemonic, " ", "\u3000")
+	}
+
+	return mnemonic, nil
+}
+
+// DetectMnemonicLanguage returns the language of the wordlist that contains
+// all the words of the mnemonic.
+func DetectMnemonicLanguage(mnemonic string) (Language, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) == 0 {
+		return 0, ErrUnsupportedLanguage
+	}
+
+	wordListLock.Lock()
+	defer wordListLock.Unlock()
+
+	for _, lang := range languages {
+		normalized, _ := lang.normalizedWordList()
+
+		found := true
+		for _, word := range words {
+			if _, ok := normalized.wordSet[word]; !ok {
+				found = false
+
+				break
+			}
+		}
+
+		if found {
+			return lang, nil
+		}
+	}
+
+	return 0, ErrUnsupportedLanguage
+}
+
+// ValidateMnemonic validates the mnemonic (seed phrase) and returns a typed
+// error that describes the problem:
+//   - InvalidWordCountError if the number of words is not 12, 15, 18, 21 or 24.
+//   - UnknownWordError if a word is not in the wordlist.
+//   - ErrInvalidChecksum if the checksum doesn't match.
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return InvalidWordCountError{Count: len(words)}
+	}
+
+	originalWords := strings.Fields(mnemonic)
+
+	wordListLock.Lock()
+	defer wordListLock.Unlock()
+
+	normalized := closestWordList(words)
+	for i, word := range words {
+		if _, ok := normalized.wordSet[word]; !ok {
+			// NFKD can split a word, e.g. '¨' is decomposed to a space and a
+			// combining mark, so the original word is reported only if the words
+			// are not split.
+			if len(originalWords) == len(words) {
+				word = originalWords[i]
+			}
+
+			return UnknownWordError{Index: i + 1, Word: word}
+		}
+	}
+
+	bip39.SetWordList(normalized.words)
+	defer bip39.SetWordList(wordlists.English)
+
+	_, err := bip39.EntropyFromMnemonic(strings.Join(words, " "))
+	if err != nil {
+		if errors.Is(err, bip39.ErrChecksumIncorrect) {
+			return fmt.Errorf("%w: %w", ErrInvalidChecksum, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ValidateMnemonicStrength validates the mnemonic like ValidateMnemonic, and
+// checks that it has the number of words of the given strength.
+// It should be used on restore when the strength of the backup is known, since
+// a mnemonic with fewer words, like the first 12 words of a 24-word mnemonic, can
+// still have a valid checksum and would silently derive other addresses.
+// It returns WordCountMismatchError if the number of words doesn't match.
+func ValidateMnemonicStrength(mnemonic string, strength MnemonicStrength) error {
+	if err := strength.validate(); err != nil {
+		return err
+	}
+
+	count := len(strings.Fields(norm.NFKD.String(mnemonic)))
+	if count != strength.WordCount() {
+		return WordCountMismatchError{Expected: strength.WordCount(), Got: count}
+	}
+
+	return ValidateMnemonic(mnemonic)
+}
+
+// closestWordList returns the wordlist that contains the most words of the mnemonic.
+// It should be called while holding wordListLock.
+func closestWordList(words []string) *wordList {
+	closest, _ := LanguageEnglish.normalizedWordList()
+	maxFound := 0
+	for _, lang := range languages {
+		normalized, _ := lang.normalizedWordList()
+
+		found := 0
+		for _, word := range words {
+			if _, ok := normalized.wordSet[word]; ok {
+				found++
+			}
+		}
+
+		if found > maxFound {
+			closest = normalized
+			maxFound = found
+		}
+	}
+
+	return closest
+}
+
+// entropyFromMnemonic detects the language of the mnemonic and returns the
+// entropy used to generate it.
+// If the language can't be detected, the English wordlist is used
+// to report the error.
+func entropyFromMnemonic(mnemonic string) ([]byte, error) {
+	lang, err := DetectMnemonicLanguage(mnemonic)
+	if err != nil {
+		lang = LanguageEnglish
+	}
+
+	wordListLock.Lock()
+	defer wordListLock.Unlock()
+
+	normalized, err := lang.normalizedWordList()
+	if err != nil {
+		return nil, err
+	}
+
+	bip39.SetWordList(normalized.words)
+	defer bip39.SetWordList(wordlists.English)
+
+	return bip39.EntropyFromMnemonic(norm.NFKD.String(mnemonic))
+}
+
+// newSeed creates the BIP-39 seed from the mnemonic and the passphrase.
+// Both the mnemonic and the passphrase are normalized using NFKD, as
+// defined in the BIP-39 specification.
+// It doesn't check if the mnemonic is valid.
+func newSeed(mnemonic, passphrase string) []byte {
+	return bip39.NewSeed(norm.NFKD.String(mnemonic), norm.NFKD.String(passphrase))
+}
@@ -0,0 +1,272 @@
+package vault
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestGenerateMnemonicWithLanguage(t *testing.T) {
+	for _, lang := range languages {
+		mnemonic, err := GenerateMnemonicWithLanguage(128, lang)
+		assert.NoError(t, err)
+		assert.NoError(t, CheckMnemonic(mnemonic), "language %s", lang)
+
+		detected, err := DetectMnemonicLanguage(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, lang, detected)
+	}
+
+	_, err := GenerateMnemonicWithLanguage(128, Language(99))
+	assert.ErrorIs(t, err, ErrUnsupportedLanguage)
+
+	// The default wordlist should remain English.
+	mnemonic, err := GenerateMnemonic(128)
+	assert.NoError(t, err)
+	assert.True(t, bip39.IsMnemonicValid(mnemonic))
+}
+
+func TestDetectMnemonicLanguage(t *testing.T) {
+	_, err := DetectMnemonicLanguage("")
+	assert.ErrorIs(t, err, ErrUnsupportedLanguage)
+
+	_, err = DetectMnemonicLanguage("abandon ability able about above absent foo")
+	assert.ErrorIs(t, err, ErrUnsupportedLanguage)
+
+	lang, err := DetectMnemonicLanguage(
+		"abandon ability able about above absent absorb abstract absurd abuse access ability")
+	assert.NoError(t, err)
+	assert.Equal(t, LanguageEnglish, lang)
+}
+
+func TestJapaneseMnemonic(t *testing.T) {
+	// Test vector from https://github.com/bip32JP/bip32JP.github.io/blob/master/test_JP_BIP39.json
+	mnemonic := "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　" +
+		"あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら"
+	passphrase := "㍍ガバヴァぱばぐゞちぢ十人十色"
+	seed := "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c" +
+		"467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55"
+
+	assert.NoError(t, CheckMnemonic(mnemonic))
+	assert.Equal(t, seed, hex.EncodeToString(newSeed(mnemonic, passphrase)))
+
+	entropy, err := entropyFromMnemonic(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 16), entropy)
+
+	// The English mnemonic with the same entropy.
+	englishMnemonic, _ := bip39.NewMnemonic(entropy)
+	englishEntropy, err := entropyFromMnemonic(englishMnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, entropy, englishEntropy)
+
+	// The vault uses the seed of the test vector.
+	vlt, err := CreateVaultFromMnemonicWithPassphrase(mnemonic, passphrase, 21888)
+	require.NoError(t, err)
+	vaultSeed, err := vlt.MnemonicSeed("")
+	require.NoError(t, err)
+	assert.Equal(t, seed, hex.EncodeToString(vaultSeed))
+
+	// The first BLS account address, derived directly from the seed of the test vector.
+	seedBytes, _ := hex.DecodeString(seed)
+	masterKey, err := blshdkeychain.NewMaster(seedBytes, false)
+	require.NoError(t, err)
+	ext, err := masterKey.DerivePath([]uint32{_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount), 0})
+	require.NoError(t, err)
+	pub, err := bls.PublicKeyFromBytes(ext.RawPublicKey())
+	require.NoError(t, err)
+
+	info, err := vlt.NewBLSAccountAddress("")
+	require.NoError(t, err)
+	assert.Equal(t, "pc1z2lf304uwcvc6cxam5409c39xjpj0thekmjav6r", info.Address)
+	assert.Equal(t, pub.AccountAddress().String(), info.Address)
+
+	// Based on BIP-39, the seed is derived from the mnemonic sentence, not the entropy.
+	// Therefore, the same entropy in different languages derives different addresses.
+	japaneseVlt, err := CreateVaultFromMnemonic(mnemonic, 21888)
+	require.NoError(t, err)
+	japaneseInfo, err := japaneseVlt.NewBLSAccountAddress("")
+	require.NoError(t, err)
+	assert.Equal(t, "pc1zwhvlvh9xraa7vrja6ftkw7austsspa905xe4gp", japaneseInfo.Address)
+
+	englishVlt, err := CreateVaultFromMnemonic(englishMnemonic, 21888)
+	require.NoError(t, err)
+	englishInfo, err := englishVlt.NewBLSAccountAddress("")
+	require.NoError(t, err)
+	assert.NotEqual(t, japaneseInfo.Address, englishInfo.Address)
+}
+
+func TestValidateMnemonicErrors(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		wantErr  error
+	}{
+		{
+			"",
+			InvalidWordCountError{Count: 0},
+		},
+		{
+			"abandon ability able about above absent absorb abstract absurd abuse access",
+			InvalidWordCountError{Count: 11},
+		},
+		{
+			"abandon ability able about above absent abandonn abstract absurd abuse access ability",
+			UnknownWordError{Index: 7, Word: "abandonn"},
+		},
+		{
+			"abandon ability able about above absent absorb abstract absurd abuse access accident",
+			ErrInvalidChecksum,
+		},
+		{
+			// NFKD decomposes '¨' to a space and a combining diaeresis.
+			"abandon ability able about above absent absorb abstract absurd abuse abandon¨",
+			UnknownWordError{Index: 12, Word: "\u0308"},
+		},
+		{
+			"abandon ability able about above absent absorb abstract absurd abuse access ability",
+			nil,
+		},
+	}
+	for no, tt := range tests {
+		err := ValidateMnemonic(tt.mnemonic)
+		assert.ErrorIs(t, err, tt.wantErr, "test %v failed", no)
+	}
+
+	t.Run("Unknown word message", func(t *testing.T) {
+		err := ValidateMnemonic(
+			"abandon ability able about above absent abandonn abstract absurd abuse access ability")
+		assert.EqualError(t, err, "word 7 'abandonn' is not in the wordlist")
+	})
+
+	t.Run("Supported entropy sizes", func(t *testing.T) {
+		for _, bitSize := range []int{128, 160, 192, 224, 256} {
+			mnemonic, err := GenerateMnemonic(bitSize)
+			assert.NoError(t, err)
+			assert.NoError(t, ValidateMnemonic(mnemonic), "bit size %d", bitSize)
+		}
+	})
+
+	t.Run("Non-English mnemonic", func(t *testing.T) {
+		mnemonic, err := GenerateMnemonicWithLanguage(128, LanguageSpanish)
+		assert.NoError(t, err)
+		assert.NoError(t, ValidateMnemonic(mnemonic))
+	})
+}
+
+func TestGenerateMnemonicStrength(t *testing.T) {
+	tests := []struct {
+		strength  MnemonicStrength
+		wordCount int
+	}{
+		{Words12, 12},
+		{Words15, 15},
+		{Words18, 18},
+		{Words21, 21},
+		{Words24, 24},
+	}
+	for _, tt := range tests {
+		mnemonic, err := GenerateMnemonicStrength(tt.strength)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.wordCount, tt.strength.WordCount())
+		assert.Len(t, strings.Fields(mnemonic), tt.wordCount)
+	}
+
+	for _, bitSize := range []int{0, 96, 127, 129, 288} {
+		_, err := GenerateMnemonicStrength(MnemonicStrength(bitSize))
+		assert.ErrorIs(t, err, InvalidEntropySizeError(bitSize))
+
+		_, err = GenerateMnemonic(bitSize)
+		assert.ErrorIs(t, err, InvalidEntropySizeError(bitSize))
+	}
+}
+
+func TestMnemonic24Words(t *testing.T) {
+	mnemonic, err := GenerateMnemonic(256)
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	assert.NoError(t, ValidateMnemonicStrength(mnemonic, Words24))
+
+	t.Run("Recovered vault derives the known addresses", func(t *testing.T) {
+		// Test vector from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+		//nolint:dupword // duplicated seed phrase words
+		vector := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+		seed := "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3" +
+			"de6f5d4a10be8ed2a5e608d68f92fcc8"
+
+		require.NoError(t, ValidateMnemonicStrength(vector, Words24))
+
+		withPassphrase, err := CreateVaultFromMnemonicWithPassphrase(vector, "TREZOR", 21888)
+		require.NoError(t, err)
+		vaultSeed, err := withPassphrase.MnemonicSeed("")
+		require.NoError(t, err)
+		assert.Equal(t, seed, hex.EncodeToString(vaultSeed))
+
+		// Save and load the vault, then restore from the mnemonic read back from it.
+		vlt, err := CreateVaultFromMnemonic(vector, 21888)
+		require.NoError(t, err)
+		data, err := json.Marshal(vlt)
+		require.NoError(t, err)
+		loaded := new(Vault)
+		require.NoError(t, json.Unmarshal(data, loaded))
+		backup, err := loaded.Mnemonic("")
+		require.NoError(t, err)
+		require.NoError(t, ValidateMnemonicStrength(backup, Words24))
+
+		recovered, err := CreateVaultFromMnemonic(backup, 21888)
+		require.NoError(t, err)
+		validatorInfo, err := recovered.NewValidatorAddress("")
+		require.NoError(t, err)
+		blsAccountInfo, err := recovered.NewBLSAccountAddress("")
+		require.NoError(t, err)
+		ed25519AccountInfo, err := recovered.NewEd25519AccountAddress("", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "pc1pl3x9k6ud2372n5vytf8t00d20ku2hnvexwr02y", validatorInfo.Address)
+		assert.Equal(t, "pc1zc3z56fkxnea2y2uud02y873nysmlezccgyq5xv", blsAccountInfo.Address)
+		assert.Equal(t, "pc1r8rel7ctk0p4cs49wlhdccvkk27rpllwhrv3g6z", ed25519AccountInfo.Address)
+	})
+
+	t.Run("Mismatched word count", func(t *testing.T) {
+		err := ValidateMnemonicStrength(mnemonic, Words12)
+		assert.ErrorIs(t, err, WordCountMismatchError{Expected: 12, Got: 24})
+		assert.EqualError(t, err, "word count mismatch, expected 12, got 24")
+
+		mnemonic12, err := GenerateMnemonic(128)
+		require.NoError(t, err)
+		err = ValidateMnemonicStrength(mnemonic12, Words24)
+		assert.ErrorIs(t, err, WordCountMismatchError{Expected: 24, Got: 12})
+	})
+
+	t.Run("Truncated mnemonic with a valid checksum", func(t *testing.T) {
+		// The checksum of a 12-word mnemonic has 4 bits, so the first 12 words of
+		// about one in 16 of the 24-word mnemonics is a valid mnemonic on its own.
+		var truncated string
+		for i := 0; i < 1000 && truncated == ""; i++ {
+			words, err := GenerateMnemonic(256)
+			require.NoError(t, err)
+
+			first12 := strings.Join(strings.Fields(words)[:12], " ")
+			if ValidateMnemonic(first12) == nil {
+				truncated = first12
+			}
+		}
+		require.NotEmpty(t, truncated)
+
+		err := ValidateMnemonicStrength(truncated, Words24)
+		assert.ErrorIs(t, err, WordCountMismatchError{Expected: 24, Got: 12})
+	})
+
+	t.Run("Invalid strength", func(t *testing.T) {
+		err := ValidateMnemonicStrength(mnemonic, MnemonicStrength(100))
+		assert.ErrorIs(t, err, InvalidEntropySizeError(100))
+	})
+}
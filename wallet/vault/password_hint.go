@@ -0,0 +1,44 @@
+package vault
+
+import "strings"
+
+// SetPasswordHint sets the password hint of the vault. An empty hint clears it.
+// The hint is not secret: it is stored in plain and it can be read even if
+// the vault is locked, so it must not reveal the password.
+// The hint is normalized like a label, and it returns ErrLabelTooLong if it is
+// longer than MaxLabelLength bytes.
+//
+// The password is not known here, so the hint is checked against the password
+// when the password is updated, see UpdatePassword.
+func (v *Vault) SetPasswordHint(hint string) error {
+	hint, err := validateLabel(hint)
+	if err != nil {
+		return err
+	}
+
+	v.Hint = hint
+
+	return nil
+}
+
+// PasswordHint returns the password hint of the vault, or an empty string if
+// no hint is set. No password is needed.
+func (v *Vault) PasswordHint() string {
+	return v.Hint
+}
+
+// checkPasswordHint returns ErrPasswordInHint if the hint contains the password.
+// It is a best-effort check: the comparison ignores the case and the spaces
+// around the password, but a hint can still reveal the password in other ways.
+func checkPasswordHint(hint, password string) error {
+	password = strings.ToLower(strings.TrimSpace(password))
+	if hint == "" || password == "" {
+		return nil
+	}
+
+	if strings.Contains(strings.ToLower(hint), password) {
+		return ErrPasswordInHint
+	}
+
+	return nil
+}
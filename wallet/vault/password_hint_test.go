@@ -0,0 +1,96 @@
+package vault
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordHint(t *testing.T) {
+	td := setup(t)
+
+	assert.Empty(t, td.vault.PasswordHint())
+
+	t.Run("Too long hint", func(t *testing.T) {
+		err := td.vault.SetPasswordHint(strings.Repeat("a", MaxLabelLength+1))
+		assert.ErrorIs(t, err, ErrLabelTooLong)
+	})
+
+	t.Run("Readable on a locked vault", func(t *testing.T) {
+		require.NoError(t, td.vault.SetPasswordHint("my favorite \tbook"))
+		td.vault.Lock()
+
+		assert.Equal(t, "my favorite  book", td.vault.PasswordHint())
+	})
+
+	t.Run("Survives serialization", func(t *testing.T) {
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, td.vault.PasswordHint(), restored.PasswordHint())
+
+		data, err = td.vault.MarshalBinary()
+		require.NoError(t, err)
+		restored = new(Vault)
+		require.NoError(t, restored.UnmarshalBinary(data))
+		assert.Equal(t, td.vault.PasswordHint(), restored.PasswordHint())
+
+		assert.Empty(t, td.vault.Neuter().PasswordHint())
+	})
+
+	t.Run("Cleared when encryption is disabled", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		require.NoError(t, vlt.UpdatePassword(tPassword, ""))
+
+		assert.False(t, vlt.IsEncrypted())
+		assert.Empty(t, vlt.PasswordHint())
+	})
+
+	t.Run("Cleared when neutered in place", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		vlt.NeuterInPlace()
+
+		assert.Empty(t, vlt.PasswordHint())
+	})
+}
+
+func TestPasswordHintContainment(t *testing.T) {
+	td := setup(t)
+
+	opts := []encrypter.Option{
+		encrypter.OptionIteration(1),
+		encrypter.OptionMemory(8),
+		encrypter.OptionParallelism(1),
+	}
+
+	tests := []struct {
+		hint     string
+		password string
+		wantErr  error
+	}{
+		{"", "secret", nil},
+		{"the name of my cat", "secret", nil},
+		{"secret", "secret", ErrPasswordInHint},
+		{"it is secret123!", "secret123", ErrPasswordInHint},
+		{"IT IS SECRET123!", "secret123", ErrPasswordInHint},
+		{"it is secret123!", " Secret123 ", ErrPasswordInHint},
+		{"it is secret", "secret123", nil},
+	}
+	for no, tt := range tests {
+		vlt := td.vault.Clone()
+		require.NoError(t, vlt.SetPasswordHint(tt.hint))
+
+		count := encrypter.KDFRunCount()
+		err := vlt.UpdatePassword(tPassword, tt.password, opts...)
+		assert.ErrorIs(t, err, tt.wantErr, "test %v failed", no)
+		if tt.wantErr != nil {
+			assert.Equal(t, count, encrypter.KDFRunCount(), "test %v failed", no)
+			assert.NoError(t, vlt.VerifyPassword(tPassword), "test %v failed", no)
+		}
+	}
+}
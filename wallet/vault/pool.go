@@ -0,0 +1,170 @@
+package vault
+
+import (
+	"sync"
+
+	"github.com/pactus-project/pactus/crypto"
+	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
+)
+
+// addressPool keeps the addresses derived ahead of time, see EnablePool.
+// The pooled addresses have consecutive indexes, starting at the next index
+// of the vault. They are derived in the background from the extended public
+// key, without accessing the vault.
+type addressPool struct {
+	lk sync.Mutex
+
+	addressType crypto.AddressType
+	size        int
+	ext         *blshdkeychain.ExtendedKey
+	nextIndex   *uint32       // Next index of the vault for the address type
+	first       uint32        // Index of the first pooled address
+	infos       []AddressInfo // Pooled addresses, not added to the vault yet
+	refilling   bool          // A refill is running for the current generation
+	generation  int           // Increased when the pooled addresses are dropped
+}
+
+// EnablePool enables a pool of addresses for the given purpose and address
+// type, that are derived ahead of time in the background, so NextFromPool
+// returns a new address without deriving it.
+// Up to size addresses are kept in the pool. Enabling the pool again replaces
+// the previous pool.
+//
+// Like NeuteredDeriveNext, only the BLS purpose is supported.
+func (v *Vault) EnablePool(purpose uint32, addressType crypto.AddressType, size int) error {
+	if size <= 0 {
+		return ErrInvalidCount
+	}
+
+	ext, nextIndex, err := v.blsExtendedKey(purpose, addressType)
+	if err != nil {
+		return err
+	}
+
+	pool := &addressPool{
+		addressType: addressType,
+		size:        size,
+		ext:         ext,
+		nextIndex:   nextIndex,
+		first:       *nextIndex,
+	}
+
+	pool.lk.Lock()
+	defer pool.lk.Unlock()
+
+	v.pool = pool
+	pool.refill()
+
+	return nil
+}
+
+// DisablePool disables the pool of addresses. The pooled addresses are dropped,
+// and since they are not added to the vault, no index is skipped.
+func (v *Vault) DisablePool() {
+	v.pool = nil
+}
+
+// NextFromPool adds the next address of the pool to the vault, advances the
+// next index and returns the address. The pool is refilled in the background.
+// If the pool is empty, the address is derived immediately.
+//
+// The pool follows the next index of the vault: if the addresses are derived
+// by other methods in the meantime, the pooled addresses at the used indexes
+// are dropped, so no address is returned twice.
+// NextFromPool can be called concurrently, but like the other methods that change
+// the vault, not concurrently with them.
+// It returns ErrPoolDisabled if the pool is not enabled.
+func (v *Vault) NextFromPool() (*AddressInfo, error) {
+	pool := v.pool
+	if pool == nil {
+		return nil, ErrPoolDisabled
+	}
+
+	pool.lk.Lock()
+	defer pool.lk.Unlock()
+
+	pool.sync(*pool.nextIndex)
+
+	var info *AddressInfo
+	if len(pool.infos) > 0 {
+		pooled := pool.infos[0]
+		pooled.CreatedAt = timeNow()
+		info = &pooled
+		pool.infos = pool.infos[1:]
+	} else {
+		var err error
+		info, err = deriveBLSAddressInfo(pool.ext, pool.addressType, *pool.nextIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pool.first++
+
+	v.Addresses[info.Address] = *info
+	*pool.nextIndex++
+	v.notify(EventAddressAdded, info.Address)
+
+	pool.refill()
+
+	return info, nil
+}
+
+// sync drops the pooled addresses that don't start at the next index of the
+// vault, e.g. because they are derived by other methods or rolled back.
+// The pool lock should be held.
+func (p *addressPool) sync(nextIndex uint32) {
+	for len(p.infos) > 0 && p.first < nextIndex {
+		p.infos = p.infos[1:]
+		p.first++
+	}
+
+	if p.first != nextIndex {
+		p.infos = nil
+		p.first = nextIndex
+		p.refilling = false
+		p.generation++
+	}
+}
+
+// refill derives the missing addresses of the pool in the background.
+// The pool lock should be held.
+func (p *addressPool) refill() {
+	count := p.size - len(p.infos)
+	if p.refilling || count <= 0 {
+		return
+	}
+	p.refilling = true
+
+	generation := p.generation
+	start := p.first + uint32(len(p.infos))
+
+	go func() {
+		derived := make([]AddressInfo, 0, count)
+		for i := 0; i < count; i++ {
+			info, err := deriveBLSAddressInfo(p.ext, p.addressType, start+uint32(i))
+			if err != nil {
+				break
+			}
+			derived = append(derived, *info)
+		}
+
+		p.lk.Lock()
+		defer p.lk.Unlock()
+
+		if generation != p.generation {
+			// The pool is dropped in the meantime.
+			return
+		}
+		p.refilling = false
+
+		// Some addresses might be handed out in the meantime, derived directly
+		// since the pool was empty.
+		for len(derived) > 0 && start < p.first+uint32(len(p.infos)) {
+			derived = derived[1:]
+			start++
+		}
+		if start == p.first+uint32(len(p.infos)) {
+			p.infos = append(p.infos, derived...)
+		}
+	}()
+}
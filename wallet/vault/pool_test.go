@@ -0,0 +1,154 @@
+package vault
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pooledCount returns the number of the addresses in the pool.
+func pooledCount(vlt *Vault) int {
+	vlt.pool.lk.Lock()
+	defer vlt.pool.lk.Unlock()
+
+	return len(vlt.pool.infos)
+}
+
+func TestEnablePool(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Pool is not enabled", func(t *testing.T) {
+		_, err := td.vault.NextFromPool()
+		assert.ErrorIs(t, err, ErrPoolDisabled)
+	})
+
+	t.Run("Invalid size", func(t *testing.T) {
+		err := td.vault.EnablePool(PurposeBLS12381, crypto.AddressTypeBLSAccount, 0)
+		assert.ErrorIs(t, err, ErrInvalidCount)
+	})
+
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		err := td.vault.EnablePool(PurposeBIP44, crypto.AddressTypeEd25519Account, 10)
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+	})
+
+	t.Run("Pool is filled in the background", func(t *testing.T) {
+		require.NoError(t, td.vault.EnablePool(PurposeBLS12381, crypto.AddressTypeValidator, 5))
+
+		assert.Eventually(t, func() bool {
+			return pooledCount(td.vault) == 5
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, 6, td.vault.AddressCount(), "pooled addresses are not added to the vault")
+
+		td.vault.DisablePool()
+		_, err := td.vault.NextFromPool()
+		assert.ErrorIs(t, err, ErrPoolDisabled)
+	})
+}
+
+func TestNextFromPool(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Same addresses as deriving directly", func(t *testing.T) {
+		expected := td.vault.Clone()
+		require.NoError(t, td.vault.EnablePool(PurposeBLS12381, crypto.AddressTypeBLSAccount, 3))
+
+		for i := 0; i < 10; i++ {
+			want, err := expected.NewBLSAccountAddress("")
+			require.NoError(t, err)
+			got, err := td.vault.NextFromPool()
+			require.NoError(t, err)
+
+			assert.Equal(t, want.Address, got.Address)
+			assert.Equal(t, want.Path, got.Path)
+			assert.True(t, td.vault.Contains(got.Address))
+		}
+		assert.Equal(t, expected.Purposes, td.vault.Purposes)
+	})
+
+	t.Run("Addresses derived by other methods", func(t *testing.T) {
+		require.NoError(t, td.vault.EnablePool(PurposeBLS12381, crypto.AddressTypeBLSAccount, 3))
+		assert.Eventually(t, func() bool {
+			return pooledCount(td.vault) == 3
+		}, time.Second, time.Millisecond)
+
+		direct1, err := td.vault.NewBLSAccountAddress("")
+		require.NoError(t, err)
+		direct2, err := td.vault.NewBLSAccountAddress("")
+		require.NoError(t, err)
+
+		pooled, err := td.vault.NextFromPool()
+		require.NoError(t, err)
+		assert.NotEqual(t, direct1.Address, pooled.Address)
+		assert.NotEqual(t, direct2.Address, pooled.Address)
+
+		next, err := td.vault.Clone().NewBLSAccountAddress("")
+		require.NoError(t, err)
+		pooled, err = td.vault.NextFromPool()
+		require.NoError(t, err)
+		assert.Equal(t, next.Address, pooled.Address)
+	})
+
+	t.Run("Rolled back addresses", func(t *testing.T) {
+		require.NoError(t, td.vault.EnablePool(PurposeBLS12381, crypto.AddressTypeBLSAccount, 3))
+
+		tx := td.vault.Begin()
+		rolledBack, err := td.vault.NextFromPool()
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+
+		pooled, err := td.vault.NextFromPool()
+		require.NoError(t, err)
+		assert.Equal(t, rolledBack.Address, pooled.Address)
+	})
+}
+
+func TestNextFromPoolConcurrent(t *testing.T) {
+	td := setup(t)
+
+	require.NoError(t, td.vault.EnablePool(PurposeBLS12381, crypto.AddressTypeBLSAccount, 4))
+
+	const workers = 8
+	const rounds = 10
+
+	var wg sync.WaitGroup
+	infos := make(chan *AddressInfo, workers*rounds)
+	errs := make(chan error, workers*rounds)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for r := 0; r < rounds; r++ {
+				info, err := td.vault.NextFromPool()
+				if err != nil {
+					errs <- err
+
+					continue
+				}
+				infos <- info
+			}
+		}()
+	}
+	wg.Wait()
+	close(infos)
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	paths := make(map[string]bool)
+	for info := range infos {
+		assert.False(t, paths[info.Path], "path %s is handed out twice", info.Path)
+		paths[info.Path] = true
+	}
+	assert.Len(t, paths, workers*rounds)
+	assert.Equal(t, 6+workers*rounds, td.vault.AddressCount())
+	assert.Equal(t, uint32(1+workers*rounds), td.vault.Purposes.PurposeBLS.NextAccountIndex)
+}
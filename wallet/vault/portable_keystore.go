@@ -0,0 +1,225 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+//
+// Portable Keystore
+//
+// The portable keystore is a JSON document that keeps the secrets of a vault,
+// encrypted by a password, so other tools can open it. Example:
+//
+//   {
+//     "version": 1,
+//     "crypto": {
+//       "kdf": "ARGON2ID",
+//       "kdf_params": {"iterations": 3, "memory": 65536, "parallelism": 4},
+//       "key_len": 48,
+//       "cipher": "AES_256_CTR",
+//       "mac_method": "MACV1",
+//       "salt": "<hex>",
+//       "cipher_text": "<hex>",
+//       "mac": "<hex>"
+//     }
+//   }
+//
+// * The key is derived from the password and the salt by the KDF, either ARGON2ID
+//   or SCRYPT (with `n`, `r` and `p` parameters).
+// * The first 32 bytes of the key are the AES-256 key, and the last 16 bytes are the IV.
+// * The MAC is the first 4 bytes of SHA-256(key[16:32] || cipher_text).
+// * The plain text is the JSON encoded key store of the vault:
+//   `{"master_node":{"seed":"<mnemonic>","passphrase_seed":"<hex>"},"imported_keys":[...]}`.
+//   The `passphrase_seed` is set only if the vault has a BIP-39 passphrase.
+//
+// New KDFs or ciphers can be added under the same version, since they are named
+// in the document. The version changes only if the layout changes.
+//
+
+const portableKeystoreVersion = 1
+
+type portableKeystore struct {
+	Version int                  `json:"version"`
+	Crypto  portableKeystoreData `json:"crypto"`
+}
+
+type portableKeystoreData struct {
+	KDF        string            `json:"kdf"`
+	KDFParams  map[string]uint64 `json:"kdf_params"`
+	KeyLen     uint32            `json:"key_len"`
+	Cipher     string            `json:"cipher"`
+	MACMethod  string            `json:"mac_method"`
+	Salt       string            `json:"salt"`
+	CipherText string            `json:"cipher_text"`
+	MAC        string            `json:"mac"`
+}
+
+// ExportKeystore exports the secrets of the vault as a portable keystore,
+// encrypted by the password of the vault and using the same encryption parameters.
+// The vault should be encrypted.
+func (v *Vault) ExportKeystore(password string) ([]byte, error) {
+	return v.ExportKeystoreCtx(context.Background(), password)
+}
+
+// ExportKeystoreCtx is like ExportKeystore, but it returns the context error
+// as soon as the context is done, see decryptKeyStoreCtx.
+func (v *Vault) ExportKeystoreCtx(ctx context.Context, password string) ([]byte, error) {
+	if !v.IsNeutered() && !v.IsEncrypted() {
+		return nil, encrypter.ErrNotEncrypted
+	}
+
+	keyStore, err := v.decryptKeyStoreCtx(ctx, password)
+	v.audit(ctx, AuditExportKeystore, nil, err)
+	if err != nil {
+		return nil, err
+	}
+
+	plainData, err := json.Marshal(keyStore)
+	if err != nil {
+		return nil, err
+	}
+	plain := newSecureBytes(plainData)
+	defer plain.Close()
+
+	enc := v.Encrypter.Clone()
+	cipherText, err := enc.Encrypt(string(plain.Bytes()), password)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt may update the key length of the legacy vaults, so get the info afterwards.
+	info, err := enc.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(portableKeystore{
+		Version: portableKeystoreVersion,
+		Crypto: portableKeystoreData{
+			KDF:        info.KDF,
+			KDFParams:  info.KDFParams,
+			KeyLen:     info.KeyLen,
+			Cipher:     info.Cipher,
+			MACMethod:  info.MAC,
+			Salt:       hex.EncodeToString(data[:info.SaltLen]),
+			CipherText: hex.EncodeToString(data[info.SaltLen : len(data)-info.MACLen]),
+			MAC:        hex.EncodeToString(data[len(data)-info.MACLen:]),
+		},
+	}, "", "  ")
+}
+
+// ImportKeystore creates a vault from the portable keystore.
+// The vault is encrypted by the same password and encryption parameters.
+// The imported private keys are added again, but the HD addresses are not derived.
+// It returns encrypter.ErrInvalidPassword if the password doesn't match the MAC.
+func ImportKeystore(data []byte, password string, coinType uint32) (*Vault, error) {
+	portable := new(portableKeystore)
+	if err := json.Unmarshal(data, portable); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeystore, err)
+	}
+
+	if portable.Version != portableKeystoreVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidKeystore, portable.Version)
+	}
+
+	enc, cipherText, err := portable.Crypto.encrypter()
+	if err != nil {
+		return nil, err
+	}
+
+	plainText, err := enc.Decrypt(cipherText, password)
+	if err != nil {
+		return nil, err
+	}
+	plain := newSecureBytes([]byte(plainText))
+	defer plain.Close()
+
+	store := new(keyStore)
+	if err := json.Unmarshal(plain.Bytes(), store); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeystore, err)
+	}
+
+	importedKeys := store.ImportedKeys
+	store.ImportedKeys = make([]string, 0)
+	vlt, err := createVaultFromKeyStore(store, coinType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range importedKeys {
+		if _, err := vlt.ImportPrivateKeyString("", key); err != nil {
+			return nil, err
+		}
+	}
+
+	// The imported keys are added to the key store, so read it again.
+	store, err = vlt.decryptKeyStore("")
+	if err != nil {
+		return nil, err
+	}
+	vlt.Encrypter = enc
+	if err := vlt.encryptKeyStore(store, password); err != nil {
+		return nil, err
+	}
+
+	return vlt, nil
+}
+
+// encrypter returns the encrypter and the cipher text, in the format of the
+// encrypter package, for the keystore data.
+func (d *portableKeystoreData) encrypter() (encrypter.Encrypter, string, error) {
+	salt, err := hex.DecodeString(d.Salt)
+	if err != nil {
+		return encrypter.Encrypter{}, "", fmt.Errorf("%w: %w", ErrInvalidKeystore, err)
+	}
+
+	cipher, err := hex.DecodeString(d.CipherText)
+	if err != nil {
+		return encrypter.Encrypter{}, "", fmt.Errorf("%w: %w", ErrInvalidKeystore, err)
+	}
+
+	mac, err := hex.DecodeString(d.MAC)
+	if err != nil {
+		return encrypter.Encrypter{}, "", fmt.Errorf("%w: %w", ErrInvalidKeystore, err)
+	}
+
+	// The parameters are named the same as in the vault files.
+	params := make(map[string]string, len(d.KDFParams)+1)
+	for key, val := range d.KDFParams {
+		params[key] = strconv.FormatUint(val, 10)
+	}
+	params["keylen"] = strconv.FormatUint(uint64(d.KeyLen), 10)
+
+	enc := encrypter.Encrypter{
+		Method: strings.Join([]string{d.KDF, d.Cipher, d.MACMethod}, "-"),
+		Params: params,
+	}
+
+	info, err := enc.Info()
+	if err != nil {
+		return encrypter.Encrypter{}, "", err
+	}
+	if len(salt) != info.SaltLen || len(mac) != info.MACLen {
+		return encrypter.Encrypter{}, "", ErrInvalidKeystore
+	}
+
+	data := make([]byte, 0, len(salt)+len(cipher)+len(mac))
+	data = append(data, salt...)
+	data = append(data, cipher...)
+	data = append(data, mac...)
+
+	return enc, base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,169 @@
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
+
+func TestExportKeystore(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Not encrypted vault", func(t *testing.T) {
+		vlt, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		require.NoError(t, err)
+
+		_, err = vlt.ExportKeystore("")
+		assert.ErrorIs(t, err, encrypter.ErrNotEncrypted)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		_, err := td.vault.Neuter().ExportKeystore(tPassword)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.ExportKeystore("invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Open by the documented format", func(t *testing.T) {
+		data, err := td.vault.ExportKeystore(tPassword)
+		require.NoError(t, err)
+
+		portable := new(portableKeystore)
+		require.NoError(t, json.Unmarshal(data, portable))
+		assert.Equal(t, 1, portable.Version)
+		assert.Equal(t, "ARGON2ID", portable.Crypto.KDF)
+		assert.Equal(t, map[string]uint64{"iterations": 1, "memory": 8, "parallelism": 1},
+			portable.Crypto.KDFParams)
+		assert.Equal(t, uint32(48), portable.Crypto.KeyLen)
+		assert.Equal(t, "AES_256_CTR", portable.Crypto.Cipher)
+		assert.Equal(t, "MACV1", portable.Crypto.MACMethod)
+
+		salt, _ := hex.DecodeString(portable.Crypto.Salt)
+		cipherText, _ := hex.DecodeString(portable.Crypto.CipherText)
+		mac, _ := hex.DecodeString(portable.Crypto.MAC)
+
+		key := argon2.IDKey([]byte(tPassword), salt, 1, 8, 1, 48)
+		hasher := sha256.New()
+		hasher.Write(key[16:32])
+		hasher.Write(cipherText)
+		assert.Equal(t, hasher.Sum(nil)[:4], mac)
+
+		block, err := aes.NewCipher(key[:32])
+		require.NoError(t, err)
+		plain := make([]byte, len(cipherText))
+		cipher.NewCTR(block, key[32:]).XORKeyStream(plain, cipherText)
+
+		store := new(keyStore)
+		require.NoError(t, json.Unmarshal(plain, store))
+		assert.Equal(t, td.mnemonic, store.MasterNode.Mnemonic)
+		assert.Equal(t, []string{td.importedBLSPrv.String(), td.importedEd25519Prv.String()},
+			store.ImportedKeys)
+	})
+}
+
+func TestImportKeystore(t *testing.T) {
+	td := setup(t)
+
+	data, err := td.vault.ExportKeystore(tPassword)
+	require.NoError(t, err)
+
+	t.Run("Round trip", func(t *testing.T) {
+		imported, err := ImportKeystore(data, tPassword, 21888)
+		require.NoError(t, err)
+
+		assert.True(t, imported.IsEncrypted())
+		assert.True(t, td.vault.Encrypter.Equal(&imported.Encrypter))
+		assert.Equal(t, td.vault.Fingerprint, imported.Fingerprint)
+		assert.Equal(t, td.vault.Purposes.PurposeBLS.XPubAccount, imported.Purposes.PurposeBLS.XPubAccount)
+
+		mnemonic, err := imported.Mnemonic(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+
+		// The HD addresses are not derived, only the imported keys are added.
+		importedAddrs := []string{
+			td.importedBLSPrv.PublicKeyNative().AccountAddress().String(),
+			td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String(),
+			td.importedEd25519Prv.PublicKeyNative().AccountAddress().String(),
+		}
+		assert.Equal(t, len(importedAddrs), imported.AddressCount())
+		for _, addr := range importedAddrs {
+			assert.Equal(t, td.vault.AddressInfo(addr).Path, imported.AddressInfo(addr).Path)
+		}
+
+		prvs, err := imported.PrivateKeys(tPassword, importedAddrs)
+		require.NoError(t, err)
+		assert.Equal(t, td.importedEd25519Prv, prvs[2])
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := ImportKeystore(data, "invalid-password", 21888)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Tampered cipher", func(t *testing.T) {
+		portable := new(portableKeystore)
+		require.NoError(t, json.Unmarshal(data, portable))
+		cipherText, _ := hex.DecodeString(portable.Crypto.CipherText)
+		cipherText[0] ^= 0x01
+		portable.Crypto.CipherText = hex.EncodeToString(cipherText)
+		tampered, _ := json.Marshal(portable)
+
+		_, err := ImportKeystore(tampered, tPassword, 21888)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		portable := new(portableKeystore)
+		require.NoError(t, json.Unmarshal(data, portable))
+		portable.Version = 2
+		unsupported, _ := json.Marshal(portable)
+
+		_, err := ImportKeystore(unsupported, tPassword, 21888)
+		assert.ErrorIs(t, err, ErrInvalidKeystore)
+	})
+
+	t.Run("Invalid salt", func(t *testing.T) {
+		portable := new(portableKeystore)
+		require.NoError(t, json.Unmarshal(data, portable))
+		portable.Crypto.Salt = "00"
+		invalid, _ := json.Marshal(portable)
+
+		_, err := ImportKeystore(invalid, tPassword, 21888)
+		assert.ErrorIs(t, err, ErrInvalidKeystore)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := ImportKeystore([]byte("{"), tPassword, 21888)
+		assert.ErrorIs(t, err, ErrInvalidKeystore)
+	})
+
+	t.Run("Scrypt and passphrase", func(t *testing.T) {
+		vlt, err := CreateVaultFromMnemonicWithPassphrase(td.mnemonic, "passphrase", 21888)
+		require.NoError(t, err)
+		require.NoError(t, vlt.UpdatePassword("", tPassword,
+			encrypter.OptionKDF(encrypter.KDFScrypt), encrypter.OptionScryptN(16)))
+
+		data, err := vlt.ExportKeystore(tPassword)
+		require.NoError(t, err)
+
+		imported, err := ImportKeystore(data, tPassword, 21888)
+		require.NoError(t, err)
+		assert.Equal(t, vlt.Purposes, imported.Purposes)
+
+		hasPassphrase, err := imported.HasPassphrase(tPassword)
+		require.NoError(t, err)
+		assert.True(t, hasPassphrase)
+	})
+}
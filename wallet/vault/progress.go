@@ -0,0 +1,11 @@
+package vault
+
+// ProgressFunc is called by the long-running operations to report their progress.
+// The `done` is the number of completed steps out of `total` steps.
+type ProgressFunc func(done, total int)
+
+func (p ProgressFunc) report(done, total int) {
+	if p != nil {
+		p(done, total)
+	}
+}
@@ -0,0 +1,71 @@
+package vault
+
+import (
+	"sync"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"golang.org/x/exp/slices"
+)
+
+// pubKeyCache keeps the raw bytes of the parsed public keys. It is safe for
+// concurrent use, since the read-only methods of the vault fill it.
+// The raw bytes are cached instead of the public keys, since the public keys
+// initialize their internal state lazily and can't be shared between goroutines.
+type pubKeyCache struct {
+	lock sync.Mutex
+	keys map[pubKeyCacheKey][]byte
+}
+
+type pubKeyCacheKey struct {
+	pubStr      string
+	addressType crypto.AddressType
+}
+
+func newPubKeyCache() *pubKeyCache {
+	return &pubKeyCache{
+		keys: make(map[pubKeyCacheKey][]byte),
+	}
+}
+
+// parsePublicKey parses the stored public key for the address type and caches
+// the result, so the stored public keys are decoded only once, on first use.
+// The stored string remains the source of truth and the malformed public keys
+// are not cached, so they fail on every access.
+// A new public key is returned on each call.
+func (v *Vault) parsePublicKey(pubStr string, addressType crypto.AddressType) (crypto.PublicKey, error) {
+	if v.pubKeys == nil {
+		v.pubKeys = newPubKeyCache()
+	}
+
+	v.pubKeys.lock.Lock()
+	defer v.pubKeys.lock.Unlock()
+
+	key := pubKeyCacheKey{pubStr: pubStr, addressType: addressType}
+	if data, ok := v.pubKeys.keys[key]; ok {
+		return publicKeyFromBytes(slices.Clone(data), addressType)
+	}
+
+	pub, err := publicKeyFromString(pubStr, addressType)
+	if err != nil {
+		return nil, err
+	}
+	v.pubKeys.keys[key] = slices.Clone(pub.Bytes())
+
+	return pub, nil
+}
+
+// publicKeyFromBytes creates the public key for the address type from the raw bytes.
+func publicKeyFromBytes(data []byte, addressType crypto.AddressType) (crypto.PublicKey, error) {
+	switch addressType {
+	case crypto.AddressTypeValidator, crypto.AddressTypeBLSAccount:
+		return bls.PublicKeyFromBytes(data)
+
+	case crypto.AddressTypeEd25519Account:
+		return ed25519.PublicKeyFromBytes(data)
+
+	default:
+		return nil, ErrUnsupportedAddressType
+	}
+}
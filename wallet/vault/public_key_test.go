@@ -0,0 +1,93 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeyCache(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Cached public key", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		pub1, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+		pub2, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+
+		assert.Equal(t, info.PublicKey, pub2.String())
+		assert.NotSame(t, pub1, pub2)
+
+		pub2.Bytes()[0] ^= 0xff
+		pub3, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+		assert.Equal(t, info.PublicKey, pub3.String())
+	})
+
+	t.Run("Stored public key is the source of truth", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("bls-account-address")[0]
+		_, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+
+		pub, _ := td.RandBLSKeyPair()
+		info.PublicKey = pub.String()
+		td.vault.Addresses[info.Address] = info
+
+		got, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+		assert.Equal(t, pub.String(), got.String())
+	})
+
+	t.Run("Corrupt public key fails on access, not on load", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		info := vlt.AddressesByLabel("validator-address")[0]
+		info.PublicKey = "public1corrupted"
+		vlt.Addresses[info.Address] = info
+
+		data, err := json.Marshal(vlt)
+		require.NoError(t, err)
+
+		loaded := new(Vault)
+		require.NoError(t, json.Unmarshal(data, loaded))
+
+		for i := 0; i < 2; i++ {
+			_, err = loaded.PublicKey(info.Address)
+			assert.ErrorAs(t, err, &VaultError{})
+		}
+	})
+}
+
+func BenchmarkLoadVault(b *testing.B) {
+	mnemonic, _ := GenerateMnemonic(128)
+	vlt, _ := CreateVaultFromMnemonic(mnemonic, 21888)
+	_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeValidator, 5000, "")
+	_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 5000, "")
+	data, _ := json.Marshal(vlt)
+	addrs := vlt.AddressInfos()
+
+	b.Run(fmt.Sprintf("Load %d addresses", len(addrs)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			loaded := new(Vault)
+			_ = json.Unmarshal(data, loaded)
+		}
+	})
+
+	b.Run("First public key access", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			vlt.pubKeys = nil
+			_, _ = vlt.PublicKey(addrs[i%len(addrs)].Address)
+		}
+	})
+
+	b.Run("Cached public key access", func(b *testing.B) {
+		_, _ = vlt.PublicKey(addrs[0].Address)
+		for i := 0; i < b.N; i++ {
+			_, _ = vlt.PublicKey(addrs[0].Address)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/util/bech32m"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+// ReencryptImportedKeys re-encrypts the imported private keys with the given
+// password and the default encrypter with the given options, for example to
+// migrate the keys that are imported in an older format.
+// Each imported key is parsed and stored in its canonical encoding, and the
+// seed is kept as it is.
+//
+// The seed and the imported keys are stored in one encrypted key store, so the
+// cipher text of the seed changes too, but its content doesn't.
+// It is atomic: the new key store is decrypted and each imported key is
+// compared with the expected one, and if anything fails the vault remains
+// unchanged. After re-encryption, the vault is locked.
+func (v *Vault) ReencryptImportedKeys(password string, opts ...encrypter.Option) error {
+	if v.IsNeutered() {
+		return ErrNeutered
+	}
+
+	keyStore, err := v.decryptKeyStore(password)
+	if err != nil {
+		return err
+	}
+
+	for i, keyStr := range keyStore.ImportedKeys {
+		prv, err := parseImportedKey(keyStr)
+		if err != nil {
+			return VaultError{Op: fmt.Sprintf("imported key %d", i), Err: err}
+		}
+		keyStore.ImportedKeys[i] = prv.String()
+	}
+
+	oldEncrypter := v.Encrypter
+	oldKeyStore := v.KeyStore
+	rollback := func() {
+		v.Encrypter = oldEncrypter
+		v.KeyStore = oldKeyStore
+	}
+
+	v.Encrypter = encrypter.NopeEncrypter()
+	if password != "" {
+		v.Encrypter = encrypter.DefaultEncrypter(opts...)
+	}
+	if err := v.encryptKeyStore(keyStore, password); err != nil {
+		rollback()
+
+		return err
+	}
+
+	if err := v.verifyImportedKeys(password, keyStore); err != nil {
+		rollback()
+
+		return err
+	}
+	v.notify(EventPasswordChanged, "")
+
+	return nil
+}
+
+// verifyImportedKeys decrypts the key store and checks that the seed and the
+// imported keys are the same as the expected key store.
+func (v *Vault) verifyImportedKeys(password string, expected *keyStore) error {
+	keyStore, err := v.decryptKeyStore(password)
+	if err != nil {
+		return err
+	}
+
+	if keyStore.MasterNode != expected.MasterNode ||
+		len(keyStore.ImportedKeys) != len(expected.ImportedKeys) {
+		return ErrReencryptionMismatch
+	}
+
+	for i, keyStr := range keyStore.ImportedKeys {
+		if _, err := parseImportedKey(keyStr); err != nil {
+			return VaultError{Op: fmt.Sprintf("imported key %d", i), Err: err}
+		}
+
+		if keyStr != expected.ImportedKeys[i] {
+			return ErrReencryptionMismatch
+		}
+	}
+
+	return nil
+}
+
+// parseImportedKey parses the imported private key.
+// The signature scheme is detected from the encoded key type.
+func parseImportedKey(keyStr string) (crypto.PrivateKey, error) {
+	_, typ, _, err := bech32m.DecodeToBase256WithTypeNoLimit(keyStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyEncoding, err)
+	}
+
+	switch typ {
+	case crypto.SignatureTypeBLS:
+		return bls.PrivateKeyFromString(keyStr)
+	case crypto.SignatureTypeEd25519:
+		return ed25519.PrivateKeyFromString(keyStr)
+	default:
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyEncoding, crypto.InvalidSignatureTypeError(typ))
+	}
+}
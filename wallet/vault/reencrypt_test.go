@@ -0,0 +1,90 @@
+package vault
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencryptImportedKeys(t *testing.T) {
+	td := setup(t)
+
+	opts := []encrypter.Option{
+		encrypter.OptionIteration(2),
+		encrypter.OptionMemory(8),
+		encrypter.OptionParallelism(1),
+	}
+	importedAddrs := []string{
+		td.importedBLSPrv.PublicKeyNative().AccountAddress().String(),
+		td.importedEd25519Prv.PublicKeyNative().AccountAddress().String(),
+	}
+
+	// setImportedKey stores the key in the key store as it is, like the keys
+	// imported by older versions.
+	setImportedKey := func(t *testing.T, vlt *Vault, index int, keyStr string) {
+		t.Helper()
+
+		keyStore, err := vlt.decryptKeyStore(tPassword)
+		require.NoError(t, err)
+		keyStore.ImportedKeys[index] = keyStr
+		require.NoError(t, vlt.encryptKeyStore(keyStore, tPassword))
+	}
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		err := td.vault.Neuter().ReencryptImportedKeys(tPassword, opts...)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		err := vlt.ReencryptImportedKeys("wrong_password", opts...)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.Equal(t, td.vault.KeyStore, vlt.KeyStore)
+	})
+
+	t.Run("Invalid imported key", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		setImportedKey(t, vlt, 1, "invalid-key")
+		keyStore := vlt.KeyStore
+		encrypterBefore := vlt.Encrypter
+
+		err := vlt.ReencryptImportedKeys(tPassword, opts...)
+		assert.ErrorIs(t, err, ErrInvalidKeyEncoding)
+		var vaultErr VaultError
+		require.True(t, errors.As(err, &vaultErr))
+		assert.Equal(t, "imported key 1", vaultErr.Op)
+
+		assert.Equal(t, keyStore, vlt.KeyStore)
+		assert.Equal(t, encrypterBefore, vlt.Encrypter)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		setImportedKey(t, vlt, 0, strings.ToUpper(td.importedBLSPrv.String()))
+
+		require.NoError(t, vlt.ReencryptImportedKeys(tPassword, opts...))
+		assert.NotEqual(t, td.vault.KeyStore, vlt.KeyStore)
+		assert.False(t, vlt.IsUnlocked())
+
+		info, err := vlt.EncryptionParams()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), info.KDFParams["iterations"])
+
+		keyStore, err := vlt.decryptKeyStore(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.importedBLSPrv.String(), keyStore.ImportedKeys[0])
+
+		mnemonic, err := vlt.Mnemonic(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+
+		prvs, err := vlt.PrivateKeys(tPassword, importedAddrs)
+		require.NoError(t, err)
+		assert.Equal(t, td.importedBLSPrv.String(), prvs[0].String())
+		assert.Equal(t, td.importedEd25519Prv.String(), prvs[1].String())
+	})
+}
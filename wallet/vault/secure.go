@@ -0,0 +1,22 @@
+package vault
+
+// secureBytes holds a transient secret, like a seed or a serialized key store.
+// The backing array is zeroed on Close, instead of relying on the garbage collector.
+type secureBytes struct {
+	data []byte
+}
+
+func newSecureBytes(data []byte) *secureBytes {
+	return &secureBytes{data: data}
+}
+
+// Bytes returns the secret. It shouldn't be used after Close.
+func (s *secureBytes) Bytes() []byte {
+	return s.data
+}
+
+// Close zeroes the secret.
+func (s *secureBytes) Close() {
+	clear(s.data)
+	s.data = nil
+}
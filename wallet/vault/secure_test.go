@@ -0,0 +1,20 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureBytes(t *testing.T) {
+	secret := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	buf := newSecureBytes(secret)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, buf.Bytes())
+
+	buf.Close()
+	assert.Nil(t, buf.Bytes())
+	assert.Equal(t, make([]byte, 8), secret, "backing array should be zeroed")
+
+	// Closing twice is safe.
+	buf.Close()
+}
@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// seedChecksumKey is the key of the HMAC used for the seed checksum, so the
+// checksum can't be confused with other hashes of the seed.
+const seedChecksumKey = "pactus-seed-checksum"
+
+// seedChecksumLen is the number of bytes of the HMAC kept in the seed checksum.
+const seedChecksumLen = 4
+
+// SeedChecksum returns a short checksum of the seed, that can be written next to
+// a paper backup to later confirm the mnemonic is transcribed correctly, see
+// VerifySeedChecksum.
+// The checksum is the first 4 bytes of an HMAC-SHA256 of the seed, in hex.
+// It is stable for a given seed, and doesn't reveal the seed: it only rules out
+// a wrong mnemonic, with a chance of 1 in 2^32 to miss one.
+// The BIP-39 passphrase is part of the seed, so it changes the checksum.
+func (v *Vault) SeedChecksum(password string) (string, error) {
+	seedBytes, err := v.MnemonicSeed(password)
+	if err != nil {
+		return "", err
+	}
+	seed := newSecureBytes(seedBytes)
+	defer seed.Close()
+
+	return seedChecksum(seed.Bytes()), nil
+}
+
+// VerifySeedChecksum checks that the mnemonic and the BIP-39 passphrase produce
+// the given seed checksum, see SeedChecksum.
+// It returns false if the mnemonic is not valid.
+func VerifySeedChecksum(mnemonic, passphrase, checksum string) bool {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return false
+	}
+
+	seed := newSecureBytes(newSeed(mnemonic, passphrase))
+	defer seed.Close()
+
+	expected := seedChecksum(seed.Bytes())
+	actual := strings.ToLower(strings.TrimSpace(checksum))
+
+	return hmac.Equal([]byte(expected), []byte(actual))
+}
+
+func seedChecksum(seed []byte) string {
+	mac := hmac.New(sha256.New, []byte(seedChecksumKey))
+	mac.Write(seed)
+
+	return hex.EncodeToString(mac.Sum(nil)[:seedChecksumLen])
+}
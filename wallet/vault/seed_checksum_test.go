@@ -0,0 +1,61 @@
+package vault
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedChecksum(t *testing.T) {
+	td := setup(t)
+
+	checksum, err := td.vault.SeedChecksum(tPassword)
+	require.NoError(t, err)
+	assert.Len(t, checksum, 8)
+
+	t.Run("Stable checksum", func(t *testing.T) {
+		again, err := td.vault.SeedChecksum(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, checksum, again)
+
+		recovered, err := CreateVaultFromMnemonic(td.mnemonic, td.vault.CoinType)
+		require.NoError(t, err)
+		recoveredChecksum, err := recovered.SeedChecksum("")
+		require.NoError(t, err)
+		assert.Equal(t, checksum, recoveredChecksum)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.SeedChecksum("invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Matching mnemonic", func(t *testing.T) {
+		assert.True(t, VerifySeedChecksum(td.mnemonic, "", checksum))
+		assert.True(t, VerifySeedChecksum(td.mnemonic, "", " "+strings.ToUpper(checksum)+" "))
+	})
+
+	t.Run("Mismatching mnemonic", func(t *testing.T) {
+		mnemonic, err := GenerateMnemonic(128)
+		require.NoError(t, err)
+
+		assert.False(t, VerifySeedChecksum(mnemonic, "", checksum))
+		assert.False(t, VerifySeedChecksum("invalid mnemonic", "", checksum))
+		assert.False(t, VerifySeedChecksum(td.mnemonic, "", "00000000"))
+	})
+
+	t.Run("Passphrase changes the checksum", func(t *testing.T) {
+		assert.False(t, VerifySeedChecksum(td.mnemonic, "passphrase", checksum))
+
+		vlt, err := CreateVaultFromMnemonicWithPassphrase(td.mnemonic, "passphrase", td.vault.CoinType)
+		require.NoError(t, err)
+		withPassphrase, err := vlt.SeedChecksum("")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, checksum, withPassphrase)
+		assert.True(t, VerifySeedChecksum(td.mnemonic, "passphrase", withPassphrase))
+	})
+}
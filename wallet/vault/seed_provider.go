@@ -0,0 +1,79 @@
+package vault
+
+import (
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+// SeedProvider derives the private keys from a seed that is kept outside of
+// the vault, like in a hardware security module or a key management service.
+type SeedProvider interface {
+	// DeriveChild derives the private key at the given path,
+	// like "m/12381'/21888'/2'/0".
+	DeriveChild(path string) (crypto.PrivateKey, error)
+}
+
+// WithReadOnlySeed returns a copy of the vault without the secrets, that
+// delegates the derivation of the private keys to the seed provider.
+// So the seed is never loaded in memory, while the addresses can be managed
+// as before. The vault itself is not changed.
+//
+// The copy is neutered: it is stored without secrets and the provider is not
+// serialized, so it should be attached again after loading the vault.
+// The methods that need the seed, like Mnemonic, return ErrNoLocalSeed, and the
+// private keys of the imported addresses are not available.
+// The derived keys are checked against the public keys of the addresses.
+func (v *Vault) WithReadOnlySeed(provider SeedProvider) *Vault {
+	vlt := v.Clone()
+	vlt.NeuterInPlace()
+	vlt.provider = provider
+
+	return vlt
+}
+
+// providerPrivateKeys derives the private keys of the addresses by the seed provider.
+func (v *Vault) providerPrivateKeys(addrs []string) (map[string]crypto.PrivateKey, error) {
+	for _, addr := range addrs {
+		if !v.Contains(addr) {
+			return nil, NewErrAddressNotFound(addr)
+		}
+	}
+
+	keys := make(map[string]crypto.PrivateKey, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := keys[addr]; ok {
+			continue
+		}
+
+		info := v.Addresses[addr]
+		prv, err := v.providerPrivateKey(info)
+		if err != nil {
+			return nil, VaultError{Op: "private key", Address: addr, Path: info.Path, Err: err}
+		}
+		keys[addr] = prv
+	}
+
+	return keys, nil
+}
+
+func (v *Vault) providerPrivateKey(info AddressInfo) (crypto.PrivateKey, error) {
+	hdPath, err := addresspath.FromString(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if hdPath.Purpose() == _H(PurposeImportPrivateKey) {
+		return nil, ErrNoLocalSeed
+	}
+
+	prv, err := v.provider.DeriveChild(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if prv.PublicKey().String() != info.PublicKey {
+		return nil, ErrInvalidKey
+	}
+
+	return prv, nil
+}
@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSeedProvider keeps the private keys by their paths, like an external key manager.
+type mockSeedProvider struct {
+	keys  map[string]string
+	calls int
+}
+
+func (p *mockSeedProvider) DeriveChild(path string) (crypto.PrivateKey, error) {
+	p.calls++
+
+	keyStr, ok := p.keys[path]
+	if !ok {
+		return nil, errors.New("unknown path")
+	}
+
+	prv, err := bls.PrivateKeyFromString(keyStr)
+	if err == nil {
+		return prv, nil
+	}
+
+	return ed25519.PrivateKeyFromString(keyStr)
+}
+
+func TestWithReadOnlySeed(t *testing.T) {
+	td := setup(t)
+
+	derived := make([]string, 0)
+	for _, info := range td.vault.AddressInfos() {
+		if !td.vault.IsImported(info.Address) {
+			derived = append(derived, info.Address)
+		}
+	}
+	keys, err := td.vault.PrivateKeysMap(tPassword, derived)
+	require.NoError(t, err)
+
+	provider := &mockSeedProvider{keys: make(map[string]string)}
+	for addr, prv := range keys {
+		provider.keys[td.vault.AddressInfo(addr).Path] = prv.String()
+	}
+
+	vlt := td.vault.WithReadOnlySeed(provider)
+	assert.False(t, td.vault.IsNeutered())
+	assert.True(t, vlt.IsNeutered())
+	assert.Equal(t, td.vault.AddressCount(), vlt.AddressCount())
+
+	t.Run("No local seed", func(t *testing.T) {
+		_, err := vlt.Mnemonic(tPassword)
+		assert.ErrorIs(t, err, ErrNoLocalSeed)
+
+		_, err = vlt.MnemonicSeed(tPassword)
+		assert.ErrorIs(t, err, ErrNoLocalSeed)
+	})
+
+	t.Run("Private keys are derived by the provider", func(t *testing.T) {
+		provider.calls = 0
+		prvs, err := vlt.PrivateKeys("", derived)
+		require.NoError(t, err)
+		assert.Equal(t, len(derived), provider.calls)
+
+		for i, addr := range derived {
+			assert.Equal(t, keys[addr].String(), prvs[i].String())
+		}
+	})
+
+	t.Run("Signing", func(t *testing.T) {
+		msg := []byte("pactus")
+		addr := td.vault.AddressesByLabel("validator-address")[0].Address
+
+		sig, err := vlt.SignMessage("", addr, msg)
+		require.NoError(t, err)
+
+		pub, err := vlt.PublicKey(addr)
+		require.NoError(t, err)
+		assert.NoError(t, pub.Verify(msg, sig))
+	})
+
+	t.Run("Imported address", func(t *testing.T) {
+		addr := td.importedBLSPrv.PublicKeyNative().AccountAddress().String()
+		_, err := vlt.PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, ErrNoLocalSeed)
+
+		var vaultErr VaultError
+		require.True(t, errors.As(err, &vaultErr))
+		assert.Equal(t, addr, vaultErr.Address)
+	})
+
+	t.Run("Provider error", func(t *testing.T) {
+		info, err := vlt.NewBLSAccountAddress("")
+		require.NoError(t, err)
+
+		_, err = vlt.PrivateKeys("", []string{info.Address})
+		assert.ErrorContains(t, err, "unknown path")
+	})
+
+	t.Run("Key mismatch", func(t *testing.T) {
+		addr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+		path := td.vault.AddressInfo(addr).Path
+		_, otherPrv := td.RandBLSKeyPair()
+
+		mismatched := &mockSeedProvider{keys: map[string]string{path: otherPrv.String()}}
+		_, err := td.vault.WithReadOnlySeed(mismatched).PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("Clone keeps the provider", func(t *testing.T) {
+		addr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+		_, err := vlt.Clone().PrivateKeys("", []string{addr})
+		assert.NoError(t, err)
+	})
+}
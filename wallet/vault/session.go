@@ -0,0 +1,115 @@
+package vault
+
+import (
+	"sync"
+	"time"
+)
+
+// session keeps the key derived from the password while the vault is unlocked.
+// It never keeps the decrypted secrets, and it is not serialized.
+type session struct {
+	lock  sync.Mutex
+	key   []byte
+	timer *time.Timer
+}
+
+// wipe zeroes the cached key and stops the expiry timer.
+func (s *session) wipe() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	clear(s.key)
+	s.key = nil
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// expire wipes the session if the key is still the cached one.
+// It prevents an old timer from wiping a newer unlock.
+func (s *session) expire(key []byte) {
+	s.lock.Lock()
+	isCurrent := len(s.key) > 0 && &s.key[0] == &key[0]
+	s.lock.Unlock()
+
+	if isCurrent {
+		s.wipe()
+	}
+}
+
+// cachedKey returns a copy of the cached key, or nil if the session is locked.
+func (s *session) cachedKey() []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.key == nil {
+		return nil
+	}
+
+	return append([]byte(nil), s.key...)
+}
+
+// Unlock verifies the password and keeps the key derived from it in memory for the ttl duration.
+// While the vault is unlocked, the empty password can be used to access the secrets,
+// like PrivateKeys(""), without running the password hasher again.
+// Changing the key store, like importing a private key or updating the password, locks the vault.
+// If the vault has a delayed unlock policy, it returns ErrDelayedUnlock, see RequestUnlock.
+func (v *Vault) Unlock(password string, ttl time.Duration) error {
+	if v.IsNeutered() {
+		return ErrNeutered
+	}
+
+	if ttl <= 0 {
+		return ErrInvalidDuration
+	}
+
+	if v.UnlockPolicy != nil {
+		return ErrDelayedUnlock
+	}
+
+	if !v.IsEncrypted() {
+		// Nothing to cache, the secrets are accessible using the empty password.
+		return v.Encrypter.VerifyPassword(v.KeyStore, password)
+	}
+
+	key, err := v.Encrypter.DeriveKey(v.KeyStore, password)
+	if err != nil {
+		return err
+	}
+
+	v.startSession(key, ttl)
+
+	return nil
+}
+
+// startSession keeps the key in memory for the ttl duration.
+func (v *Vault) startSession(key []byte, ttl time.Duration) {
+	v.Lock()
+	if v.session == nil {
+		v.session = new(session)
+	}
+
+	sess := v.session
+	sess.lock.Lock()
+	defer sess.lock.Unlock()
+
+	sess.key = key
+	sess.timer = time.AfterFunc(ttl, func() { sess.expire(key) })
+}
+
+// Lock wipes the cached key from memory.
+func (v *Vault) Lock() {
+	if v.session != nil {
+		v.session.wipe()
+	}
+}
+
+// IsUnlocked returns true if the key derived from the password is cached in memory.
+func (v *Vault) IsUnlocked() bool {
+	if v.session == nil {
+		return false
+	}
+
+	return v.session.cachedKey() != nil
+}
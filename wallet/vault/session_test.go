@@ -0,0 +1,152 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnlock(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressInfos()[0].Address
+
+	t.Run("Invalid password", func(t *testing.T) {
+		err := td.vault.Unlock("invalid-password", time.Minute)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.False(t, td.vault.IsUnlocked())
+	})
+
+	t.Run("Invalid duration", func(t *testing.T) {
+		err := td.vault.Unlock(tPassword, 0)
+		assert.ErrorIs(t, err, ErrInvalidDuration)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		err := td.vault.Neuter().Unlock(tPassword, time.Minute)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Locked vault", func(t *testing.T) {
+		_, err := td.vault.PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Unlock and lock", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+		assert.True(t, td.vault.IsUnlocked())
+
+		prvs, err := td.vault.PrivateKeys("", []string{addr})
+		assert.NoError(t, err)
+		assert.Len(t, prvs, 1)
+
+		// The password still works.
+		_, err = td.vault.PrivateKeys(tPassword, []string{addr})
+		assert.NoError(t, err)
+
+		td.vault.Lock()
+		assert.False(t, td.vault.IsUnlocked())
+		_, err = td.vault.PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Lock wipes the key", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+		key := td.vault.session.key
+
+		td.vault.Lock()
+		assert.Equal(t, make([]byte, len(key)), key)
+	})
+
+	t.Run("TTL expiry", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, 10*time.Millisecond))
+		assert.True(t, td.vault.IsUnlocked())
+
+		assert.Eventually(t, func() bool {
+			return !td.vault.IsUnlocked()
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Changing the key store locks the vault", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+
+		_, prv := td.RandBLSKeyPair()
+		require.NoError(t, td.vault.ImportBLSPrivateKey(tPassword, prv))
+		assert.False(t, td.vault.IsUnlocked())
+
+		_, err := td.vault.PrivateKeys(tPassword, []string{addr})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not serialized", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+		defer td.vault.Lock()
+
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "session")
+	})
+}
+
+func TestUnlockNotEncrypted(t *testing.T) {
+	td := setup(t)
+	require.NoError(t, td.vault.UpdatePassword(tPassword, ""))
+
+	assert.ErrorIs(t, td.vault.Unlock("password", time.Minute), encrypter.ErrInvalidPassword)
+	assert.NoError(t, td.vault.Unlock("", time.Minute))
+	assert.False(t, td.vault.IsUnlocked())
+
+	_, err := td.vault.PrivateKeys("", []string{td.vault.AddressInfos()[0].Address})
+	assert.NoError(t, err)
+}
+
+func TestUnlockSingleKDFRun(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressInfos()[0].Address
+	require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+	defer td.vault.Lock()
+
+	count := encrypter.KDFRunCount()
+	for i := 0; i < 1000; i++ {
+		trx := td.GenerateTestTransferTx()
+		prvs, err := td.vault.PrivateKeys("", []string{addr})
+		require.NoError(t, err)
+
+		sig := prvs[0].Sign(trx.SignBytes())
+		trx.SetSignature(sig)
+		trx.SetPublicKey(prvs[0].PublicKey())
+	}
+	assert.Equal(t, count, encrypter.KDFRunCount())
+}
+
+func TestPrivateKeysKDFRuns(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressInfos()[0].Address
+
+	t.Run("Without unlock session", func(t *testing.T) {
+		encrypter.ResetKDFRunCount()
+		for i := 0; i < 2; i++ {
+			_, err := td.vault.PrivateKeys(tPassword, []string{addr})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, uint64(2), encrypter.KDFRunCount())
+	})
+
+	t.Run("With unlock session", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+		defer td.vault.Lock()
+
+		encrypter.ResetKDFRunCount()
+		for i := 0; i < 2; i++ {
+			_, err := td.vault.PrivateKeys("", []string{addr})
+			require.NoError(t, err)
+		}
+		assert.Zero(t, encrypter.KDFRunCount())
+	})
+}
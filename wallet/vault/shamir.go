@@ -0,0 +1,356 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+//
+// Seed Shares
+//
+// The entropy of the mnemonic is split into shares using Shamir's Secret Sharing
+// over GF(256), with the AES reduction polynomial x^8 + x^4 + x^3 + x + 1.
+// Each byte of the entropy is the constant term of a random polynomial of
+// degree `threshold - 1`, and the share with index `x` holds the value of
+// the polynomials at `x`.
+//
+// Each share is encoded as below, and then written with the English BIP-39
+// wordlist, 11 bits per word. The last word is padded with zero bits.
+//
+//   | Size | Field                                       |
+//   |------|---------------------------------------------|
+//   | 1    | Version, currently 1                        |
+//   | 2    | Identifier, random and the same for a split |
+//   | 1    | Threshold                                   |
+//   | 1    | Index of the share, from 1                  |
+//   | 1    | Language of the mnemonic                    |
+//   | 1    | Length of the entropy in bytes              |
+//   | n    | Value of the share                          |
+//   | 4    | Checksum, first 4 bytes of SHA-256          |
+//
+// The BIP-39 passphrase is not part of the shares.
+//
+
+const (
+	shareVersion    = 1
+	shareHeaderSize = 7
+	shareCheckSize  = 4
+	maxShares       = 255
+)
+
+type seedShare struct {
+	id        [2]byte
+	threshold int
+	index     byte
+	lang      Language
+	value     []byte
+}
+
+// ExportSeedShares splits the seed of the vault into shares.
+// Any `threshold` shares can recover the seed, while fewer shares reveal nothing about it.
+// The shares are returned as phrases of English words.
+// The BIP-39 passphrase, if any, is not part of the shares and should be kept separately.
+func (v *Vault) ExportSeedShares(password string, threshold, shares int) ([]string, error) {
+	return v.ExportSeedSharesCtx(context.Background(), password, threshold, shares)
+}
+
+// ExportSeedSharesCtx is like ExportSeedShares, but it returns the context error
+// as soon as the context is done, see decryptKeyStoreCtx.
+func (v *Vault) ExportSeedSharesCtx(ctx context.Context, password string, threshold, shares int,
+) ([]string, error) {
+	if threshold < 2 || threshold > shares || shares > maxShares {
+		return nil, ErrInvalidThreshold
+	}
+
+	keyStore, err := v.decryptKeyStoreCtx(ctx, password)
+	v.audit(ctx, AuditExportSeedShares, nil, err)
+	if err != nil {
+		return nil, err
+	}
+
+	lang, err := DetectMnemonicLanguage(keyStore.MasterNode.Mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	entropyBytes, err := entropyFromMnemonic(keyStore.MasterNode.Mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	entropy := newSecureBytes(entropyBytes)
+	defer entropy.Close()
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+
+	values, err := splitSecret(entropy.Bytes(), threshold, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	phrases := make([]string, 0, shares)
+	for i, value := range values {
+		share := seedShare{
+			id:        id,
+			threshold: threshold,
+			index:     byte(i + 1),
+			lang:      lang,
+			value:     value,
+		}
+		phrases = append(phrases, share.encode())
+	}
+
+	return phrases, nil
+}
+
+// RecoverFromShares recovers a vault from the seed shares.
+// At least `threshold` shares of the same split are required.
+func RecoverFromShares(shares []string, coinType uint32) (*Vault, error) {
+	return RecoverFromSharesWithPassphrase(shares, "", coinType)
+}
+
+// RecoverFromSharesWithPassphrase recovers a vault from the seed shares and
+// the BIP-39 passphrase.
+func RecoverFromSharesWithPassphrase(shares []string, passphrase string, coinType uint32) (*Vault, error) {
+	decoded := make([]*seedShare, 0, len(shares))
+	seen := make(map[byte]bool, len(shares))
+	for _, phrase := range shares {
+		share, err := decodeSeedShare(phrase)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(decoded) > 0 {
+			first := decoded[0]
+			if share.id != first.id ||
+				share.threshold != first.threshold ||
+				share.lang != first.lang ||
+				len(share.value) != len(first.value) {
+				return nil, ErrShareMismatch
+			}
+		}
+
+		if seen[share.index] {
+			continue
+		}
+		seen[share.index] = true
+		decoded = append(decoded, share)
+	}
+
+	if len(decoded) == 0 || len(decoded) < decoded[0].threshold {
+		return nil, ErrNotEnoughShares
+	}
+
+	entropy := newSecureBytes(combineShares(decoded[:decoded[0].threshold]))
+	defer entropy.Close()
+
+	mnemonic, err := mnemonicFromEntropy(entropy.Bytes(), decoded[0].lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateVaultFromMnemonicWithPassphrase(mnemonic, passphrase, coinType)
+}
+
+func (s *seedShare) encode() string {
+	data := make([]byte, 0, shareHeaderSize+len(s.value)+shareCheckSize)
+	data = append(data, shareVersion, s.id[0], s.id[1],
+		byte(s.threshold), s.index, byte(s.lang), byte(len(s.value)))
+	data = append(data, s.value...)
+	checksum := sha256.Sum256(data)
+	data = append(data, checksum[:shareCheckSize]...)
+
+	words := make([]string, 0, (len(data)*8+10)/11)
+	acc, bits := 0, 0
+	for _, b := range data {
+		acc = acc<<8 | int(b)
+		bits += 8
+		for bits >= 11 {
+			bits -= 11
+			words = append(words, wordlists.English[(acc>>bits)&0x7ff])
+		}
+		acc &= 1<<bits - 1
+	}
+	if bits > 0 {
+		words = append(words, wordlists.English[(acc<<(11-bits))&0x7ff])
+	}
+
+	return strings.Join(words, " ")
+}
+
+func decodeSeedShare(phrase string) (*seedShare, error) {
+	words := strings.Fields(phrase)
+	data := make([]byte, 0, len(words)*11/8)
+	acc, bits := 0, 0
+	for _, word := range words {
+		index, ok := englishWordIndex[word]
+		if !ok {
+			return nil, ErrInvalidShare
+		}
+		acc = acc<<11 | index
+		bits += 11
+		for bits >= 8 {
+			bits -= 8
+			data = append(data, byte(acc>>bits))
+		}
+		acc &= 1<<bits - 1
+	}
+
+	if len(data) < shareHeaderSize+shareCheckSize || data[0] != shareVersion {
+		return nil, ErrInvalidShare
+	}
+
+	// The words should encode exactly the share, with less than one word of padding.
+	size := shareHeaderSize + int(data[6]) + shareCheckSize
+	if len(data) < size || len(words)*11-size*8 >= 11 {
+		return nil, ErrInvalidShare
+	}
+
+	// The padding bits should be zero.
+	if acc != 0 || !isZero(data[size:]) {
+		return nil, ErrInvalidShare
+	}
+	data = data[:size]
+
+	checksum := sha256.Sum256(data[:size-shareCheckSize])
+	if !bytes.Equal(checksum[:shareCheckSize], data[size-shareCheckSize:]) {
+		return nil, ErrInvalidShareChecksum
+	}
+
+	share := &seedShare{
+		id:        [2]byte{data[1], data[2]},
+		threshold: int(data[3]),
+		index:     data[4],
+		lang:      Language(data[5]),
+		value:     data[shareHeaderSize : size-shareCheckSize],
+	}
+	if share.threshold < 2 || share.index == 0 {
+		return nil, ErrInvalidShare
+	}
+
+	return share, nil
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// englishWordIndex maps the words of the English wordlist to their index.
+var englishWordIndex = func() map[string]int {
+	index := make(map[string]int, len(wordlists.English))
+	for i, word := range wordlists.English {
+		index[word] = i
+	}
+
+	return index
+}()
+
+// splitSecret splits each byte of the secret into shares, using a random
+// polynomial of degree `threshold - 1`. The share i is the value at x = i + 1.
+func splitSecret(secret []byte, threshold, shares int) ([][]byte, error) {
+	values := make([][]byte, shares)
+	for i := range values {
+		values[i] = make([]byte, len(secret))
+	}
+
+	coeffs := make([]byte, threshold)
+	defer clear(coeffs)
+
+	for pos, b := range secret {
+		coeffs[0] = b
+		if _, err := rand.Read(coeffs[1:]); err != nil {
+			return nil, err
+		}
+
+		for i := range values {
+			x := byte(i + 1)
+
+			// Horner's method
+			y := byte(0)
+			for c := threshold - 1; c >= 0; c-- {
+				y = gfMul(y, x) ^ coeffs[c]
+			}
+			values[i][pos] = y
+		}
+	}
+
+	return values, nil
+}
+
+// combineShares recovers the secret by the Lagrange interpolation at x = 0.
+func combineShares(shares []*seedShare) []byte {
+	secret := make([]byte, len(shares[0].value))
+	for i, share := range shares {
+		// Lagrange basis polynomial at x = 0
+		basis := byte(1)
+		for j, other := range shares {
+			if i == j {
+				continue
+			}
+			basis = gfMul(basis, gfDiv(other.index, other.index^share.index))
+		}
+
+		for pos, y := range share.value {
+			secret[pos] ^= gfMul(y, basis)
+		}
+	}
+
+	return secret
+}
+
+// gfExp and gfLog are the exponent and logarithm tables of GF(256),
+// with 3 as the generator.
+var gfExp, gfLog = gfTables()
+
+func gfTables() ([510]byte, [256]byte) {
+	var exp [510]byte
+	var log [256]byte
+
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+
+		// Multiply by the generator, x * 3 = x * 2 + x
+		x2 := x << 1
+		if x2&0x100 != 0 {
+			x2 ^= 0x11b
+		}
+		x ^= x2
+	}
+
+	for i := 255; i < len(exp); i++ {
+		exp[i] = exp[i-255]
+	}
+
+	return exp, log
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
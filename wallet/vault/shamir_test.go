@@ -0,0 +1,147 @@
+package vault
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaloisField(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			product := gfMul(byte(a), byte(b))
+			require.Equal(t, byte(a), gfDiv(product, byte(b)))
+		}
+	}
+
+	// Known values of GF(256) with the AES polynomial.
+	assert.Equal(t, byte(0xc1), gfMul(0x57, 0x83))
+	assert.Equal(t, byte(0xfe), gfMul(0x57, 0x13))
+	assert.Zero(t, gfMul(0, 0x13))
+}
+
+func TestExportSeedShares(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid threshold", func(t *testing.T) {
+		_, err := td.vault.ExportSeedShares(tPassword, 1, 3)
+		assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+		_, err = td.vault.ExportSeedShares(tPassword, 4, 3)
+		assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+		_, err = td.vault.ExportSeedShares(tPassword, 2, 256)
+		assert.ErrorIs(t, err, ErrInvalidThreshold)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		_, err := td.vault.Neuter().ExportSeedShares("", 2, 3)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.ExportSeedShares("invalid-password", 2, 3)
+		assert.Error(t, err)
+	})
+
+	t.Run("Any threshold shares recover the seed", func(t *testing.T) {
+		shares, err := td.vault.ExportSeedShares(tPassword, 3, 5)
+		require.NoError(t, err)
+		require.Len(t, shares, 5)
+
+		combinations := [][]int{
+			{0, 1, 2}, {0, 1, 3}, {0, 2, 4}, {1, 3, 4}, {2, 3, 4}, {4, 2, 0},
+			{0, 1, 2, 3, 4},
+		}
+		for _, indexes := range combinations {
+			selected := make([]string, 0, len(indexes))
+			for _, i := range indexes {
+				selected = append(selected, shares[i])
+			}
+
+			recovered, err := RecoverFromShares(selected, 21888)
+			require.NoError(t, err)
+
+			mnemonic, err := recovered.Mnemonic("")
+			require.NoError(t, err)
+			assert.Equal(t, td.mnemonic, mnemonic)
+			assert.Equal(t, td.vault.Purposes.PurposeBLS.XPubAccount, recovered.Purposes.PurposeBLS.XPubAccount)
+		}
+	})
+
+	t.Run("Fewer shares than threshold", func(t *testing.T) {
+		shares, err := td.vault.ExportSeedShares(tPassword, 3, 5)
+		require.NoError(t, err)
+
+		_, err = RecoverFromShares(shares[:2], 21888)
+		assert.ErrorIs(t, err, ErrNotEnoughShares)
+
+		// Duplicated shares are counted once.
+		_, err = RecoverFromShares([]string{shares[0], shares[1], shares[0]}, 21888)
+		assert.ErrorIs(t, err, ErrNotEnoughShares)
+
+		_, err = RecoverFromShares(nil, 21888)
+		assert.ErrorIs(t, err, ErrNotEnoughShares)
+	})
+
+	t.Run("Shares of different splits", func(t *testing.T) {
+		shares1, err := td.vault.ExportSeedShares(tPassword, 2, 3)
+		require.NoError(t, err)
+		shares2, err := td.vault.ExportSeedShares(tPassword, 2, 3)
+		require.NoError(t, err)
+
+		_, err = RecoverFromShares([]string{shares1[0], shares2[1]}, 21888)
+		assert.ErrorIs(t, err, ErrShareMismatch)
+	})
+
+	t.Run("Invalid share", func(t *testing.T) {
+		shares, err := td.vault.ExportSeedShares(tPassword, 2, 3)
+		require.NoError(t, err)
+
+		words := strings.Fields(shares[0])
+
+		// Typo in a word of the value
+		typo := append([]string{}, words...)
+		if typo[8] == "abandon" {
+			typo[8] = "ability"
+		} else {
+			typo[8] = "abandon"
+		}
+		_, err = RecoverFromShares([]string{strings.Join(typo, " "), shares[1]}, 21888)
+		assert.ErrorIs(t, err, ErrInvalidShareChecksum)
+
+		// Unknown word
+		unknown := append([]string{}, words...)
+		unknown[0] = "pactus"
+		_, err = RecoverFromShares([]string{strings.Join(unknown, " "), shares[1]}, 21888)
+		assert.ErrorIs(t, err, ErrInvalidShare)
+
+		// Missing word
+		_, err = RecoverFromShares([]string{strings.Join(words[:len(words)-1], " "), shares[1]}, 21888)
+		assert.ErrorIs(t, err, ErrInvalidShare)
+
+		// Extra word
+		_, err = RecoverFromShares([]string{shares[0] + " abandon", shares[1]}, 21888)
+		assert.ErrorIs(t, err, ErrInvalidShare)
+	})
+
+	t.Run("Passphrase and language are kept", func(t *testing.T) {
+		mnemonic, err := GenerateMnemonicWithLanguage(256, LanguageSpanish)
+		require.NoError(t, err)
+		vlt, err := CreateVaultFromMnemonicWithPassphrase(mnemonic, "passphrase", 21888)
+		require.NoError(t, err)
+
+		shares, err := vlt.ExportSeedShares("", 2, 2)
+		require.NoError(t, err)
+
+		recovered, err := RecoverFromSharesWithPassphrase(shares, "passphrase", 21888)
+		require.NoError(t, err)
+
+		recoveredMnemonic, err := recovered.Mnemonic("")
+		require.NoError(t, err)
+		assert.Equal(t, mnemonic, recoveredMnemonic)
+		assert.Equal(t, vlt.Purposes, recovered.Purposes)
+	})
+}
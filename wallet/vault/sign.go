@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/crypto/hash"
+	"github.com/pactus-project/pactus/types/tx"
+)
+
+// SignMessage signs the message using the private key of the given address.
+// The private key is zeroed after signing, so it never leaves the vault.
+// Based on the address type, the message is signed using BLS or Ed25519 signature scheme.
+func (v *Vault) SignMessage(password, addr string, msg []byte) (crypto.Signature, error) {
+	prvs, err := v.PrivateKeys(password, []string{addr})
+	if err != nil {
+		return nil, err
+	}
+
+	prv := prvs[0]
+	defer clearPrivateKey(prv)
+
+	return prv.Sign(msg), nil
+}
+
+// SignHash signs the hash using the private key of the given address.
+// It works like SignMessage, with the hash bytes as the message.
+func (v *Vault) SignHash(password, addr string, h hash.Hash) (crypto.Signature, error) {
+	return v.SignMessage(password, addr, h.Bytes())
+}
+
+// SignAtPath derives the private key at the given path, signs the message and
+// zeroes the private key. Unlike SignMessage, the address doesn't need to be in
+// the vault, so stateless signers can sign for any derivable path.
+// The purpose and the coin type of the path should match the vault, and the
+// address type should match the purpose.
+// The imported keys are not derived from the seed, so the paths with the import
+// purpose return ErrUnsupportedPurpose.
+func (v *Vault) SignAtPath(password, path string, msg []byte) (crypto.Signature, error) {
+	prv, err := v.privateKeyAtPath(password, path)
+	v.audit(context.Background(), AuditPrivateKeys, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	defer clearPrivateKey(prv)
+
+	return prv.Sign(msg), nil
+}
+
+// privateKeyAtPath derives the private key at the path from the seed.
+func (v *Vault) privateKeyAtPath(password, pathStr string) (crypto.PrivateKey, error) {
+	hdPath, err := v.parseDerivablePath(pathStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.provider != nil {
+		return v.provider.DeriveChild(hdPath.String())
+	}
+
+	if v.IsNeutered() {
+		return nil, ErrNeutered
+	}
+
+	keyStore, err := v.decryptKeyStore(password)
+	if err != nil {
+		return nil, err
+	}
+	seedBytes, err := keyStore.MasterNode.seed()
+	if err != nil {
+		return nil, err
+	}
+	seed := newSecureBytes(seedBytes)
+	defer seed.Close()
+
+	if _N(hdPath.Purpose()) == PurposeBLS12381 {
+		return v.deriveBLSPrivateKey(seed.Bytes(), hdPath)
+	}
+
+	return v.deriveEd25519PrivateKey(seed.Bytes(), hdPath)
+}
+
+// SignTransaction decodes the raw transaction, signs it using the private key of
+// the signer of its payload, and returns the serialized signed transaction.
+// It returns AddressNotFoundError if the signer is not in the vault.
+// The private key is zeroed after signing.
+// It needs no network access, so it can be used for offline signing.
+func (v *Vault) SignTransaction(password string, rawTx []byte) ([]byte, error) {
+	trx, err := tx.FromBytes(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := trx.Payload().Signer().String()
+	if !v.Contains(signer) {
+		return nil, NewErrAddressNotFound(signer)
+	}
+
+	prvs, err := v.PrivateKeys(password, []string{signer})
+	if err != nil {
+		return nil, err
+	}
+
+	prv := prvs[0]
+	defer clearPrivateKey(prv)
+
+	trx.SetSignature(prv.Sign(trx.SignBytes()))
+	trx.SetPublicKey(prv.PublicKey())
+
+	return trx.Bytes()
+}
+
+// clearPrivateKey zeroes the private key in memory.
+func clearPrivateKey(prv crypto.PrivateKey) {
+	switch key := prv.(type) {
+	case *bls.PrivateKey:
+		key.Clear()
+	case *ed25519.PrivateKey:
+		key.Clear()
+	}
+}
@@ -0,0 +1,214 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	ed25519hdkeychain "github.com/pactus-project/pactus/crypto/ed25519/hdkeychain"
+	"github.com/pactus-project/pactus/crypto/hash"
+	"github.com/pactus-project/pactus/types/tx"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	td := setup(t)
+
+	msg := []byte("pactus")
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandAccAddress().String()
+		_, err := td.vault.SignMessage(tPassword, addr, msg)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		addr := td.vault.AddressInfos()[0].Address
+		_, err := td.vault.SignMessage("invalid-password", addr, msg)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Sign with all addresses", func(t *testing.T) {
+		for _, info := range td.vault.AddressInfos() {
+			sig, err := td.vault.SignMessage(tPassword, info.Address, msg)
+			require.NoError(t, err)
+
+			path, _ := addresspath.FromString(info.Path)
+			switch _N(path.AddressType()) {
+			case uint32(crypto.AddressTypeEd25519Account):
+				pub, _ := ed25519.PublicKeyFromString(info.PublicKey)
+				assert.NoError(t, pub.Verify(msg, sig), "address %s", info.Address)
+			default:
+				pub, _ := bls.PublicKeyFromString(info.PublicKey)
+				assert.NoError(t, pub.Verify(msg, sig), "address %s", info.Address)
+			}
+		}
+	})
+}
+
+func TestSignAtPath(t *testing.T) {
+	td := setup(t)
+
+	msg := []byte("pactus")
+
+	t.Run("BLS path not in the vault", func(t *testing.T) {
+		ext, _, err := td.vault.blsExtendedKey(PurposeBLS12381, crypto.AddressTypeBLSAccount)
+		require.NoError(t, err)
+		info, err := deriveBLSAddressInfo(ext, crypto.AddressTypeBLSAccount, 50)
+		require.NoError(t, err)
+		require.False(t, td.vault.Contains(info.Address))
+
+		sig, err := td.vault.SignAtPath(tPassword, "m/12381'/21888'/2'/50", msg)
+		require.NoError(t, err)
+
+		pub, _ := bls.PublicKeyFromString(info.PublicKey)
+		assert.NoError(t, pub.Verify(msg, sig))
+		assert.Equal(t, 6, td.vault.AddressCount())
+	})
+
+	t.Run("Ed25519 path not in the vault", func(t *testing.T) {
+		seed, err := td.vault.MnemonicSeed(tPassword)
+		require.NoError(t, err)
+		masterKey, err := ed25519hdkeychain.NewMaster(seed)
+		require.NoError(t, err)
+		ext, err := masterKey.DerivePath([]uint32{_H(PurposeBIP44), _H(21888), _H(3), _H(7)})
+		require.NoError(t, err)
+		pub, err := ed25519.PublicKeyFromBytes(ext.RawPublicKey())
+		require.NoError(t, err)
+
+		sig, err := td.vault.SignAtPath(tPassword, "m/44'/21888'/3'/7'", msg)
+		require.NoError(t, err)
+		assert.NoError(t, pub.Verify(msg, sig))
+	})
+
+	t.Run("Same signature as the stored address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		want, err := td.vault.SignMessage(tPassword, info.Address, msg)
+		require.NoError(t, err)
+
+		got, err := td.vault.SignAtPath(tPassword, info.Path, msg)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("Invalid paths", func(t *testing.T) {
+		tests := []struct {
+			path string
+			err  error
+		}{
+			{"invalid", ErrInvalidPath},
+			{"m/12381'/21888'/2'", ErrInvalidPath},
+			{"m/12381'/21888'/2'/5'", ErrInvalidPath},
+			{"m/12381'/21888'/3'/5", ErrUnsupportedAddressType},
+			{"m/44'/21888'/2'/5'", ErrUnsupportedAddressType},
+			{"m/65535'/21888'/2'/0'", ErrUnsupportedPurpose},
+			{"m/12381'/21777'/2'/5", ErrInvalidCoinType},
+		}
+		for _, tt := range tests {
+			_, err := td.vault.SignAtPath(tPassword, tt.path, msg)
+			assert.ErrorIs(t, err, tt.err, "path: %s", tt.path)
+		}
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.SignAtPath("invalid-password", "m/12381'/21888'/2'/50", msg)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		_, err := td.vault.Neuter().SignAtPath("", "m/12381'/21888'/2'/50", msg)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+}
+
+func TestSignHash(t *testing.T) {
+	td := setup(t)
+
+	info := td.vault.AddressFromPath("m/12381'/21888'/2'/0")
+	h := hash.CalcHash([]byte("pactus"))
+
+	sig, err := td.vault.SignHash(tPassword, info.Address, h)
+	require.NoError(t, err)
+
+	pub, _ := bls.PublicKeyFromString(info.PublicKey)
+	assert.NoError(t, pub.Verify(h.Bytes(), sig))
+}
+
+func TestSignTransaction(t *testing.T) {
+	td := setup(t)
+
+	blsAddr, _ := crypto.AddressFromString(td.vault.AddressesByLabel("bls-account-address")[0].Address)
+	ed25519Addr, _ := crypto.AddressFromString(td.vault.AddressesByLabel("ed25519-account-address")[0].Address)
+	valAddr, _ := crypto.AddressFromString(td.vault.AddressesByLabel("validator-address")[0].Address)
+
+	rawTx := func(trx *tx.Tx) []byte {
+		data, err := trx.Bytes()
+		require.NoError(t, err)
+
+		return data
+	}
+
+	t.Run("Sign transactions", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			trx  *tx.Tx
+		}{
+			{"BLS account", tx.NewTransferTx(td.RandHeight(), blsAddr,
+				td.RandAccAddress(), td.RandAmount(), td.RandFee())},
+			{"Ed25519 account", tx.NewTransferTx(td.RandHeight(), ed25519Addr,
+				td.RandAccAddress(), td.RandAmount(), td.RandFee())},
+			{"Validator", tx.NewUnbondTx(td.RandHeight(), valAddr)},
+		}
+
+		for _, tc := range testCases {
+			signed, err := td.vault.SignTransaction(tPassword, rawTx(tc.trx))
+			require.NoError(t, err, tc.name)
+
+			signedTx, err := tx.FromBytes(signed)
+			require.NoError(t, err, tc.name)
+			assert.True(t, signedTx.IsSigned(), tc.name)
+			assert.NoError(t, signedTx.BasicCheck(), tc.name)
+			assert.Equal(t, tc.trx.ID(), signedTx.ID(), tc.name)
+
+			pub, err := td.vault.PublicKey(tc.trx.Payload().Signer().String())
+			require.NoError(t, err)
+			assert.True(t, pub.EqualsTo(signedTx.PublicKey()), tc.name)
+		}
+	})
+
+	t.Run("Unknown signer", func(t *testing.T) {
+		sender := td.RandAccAddress()
+		trx := tx.NewTransferTx(td.RandHeight(), sender, td.RandAccAddress(), td.RandAmount(), td.RandFee())
+
+		_, err := td.vault.SignTransaction(tPassword, rawTx(trx))
+		assert.ErrorIs(t, err, NewErrAddressNotFound(sender.String()))
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		trx := tx.NewTransferTx(td.RandHeight(), blsAddr, td.RandAccAddress(), td.RandAmount(), td.RandFee())
+
+		_, err := td.vault.SignTransaction("invalid-password", rawTx(trx))
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Invalid transaction", func(t *testing.T) {
+		_, err := td.vault.SignTransaction(tPassword, []byte{0x01, 0x02})
+		assert.Error(t, err)
+	})
+}
+
+func TestClearPrivateKey(t *testing.T) {
+	td := setup(t)
+
+	_, blsPrv := td.RandBLSKeyPair()
+	clearPrivateKey(blsPrv)
+	assert.Equal(t, make([]byte, bls.PrivateKeySize), blsPrv.Bytes())
+
+	_, ed25519Prv := td.RandEd25519KeyPair()
+	clearPrivateKey(ed25519Prv)
+	assert.Equal(t, make([]byte, ed25519.PrivateKeySize), ed25519Prv.Bytes())
+}
@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/pactus-project/pactus/crypto/hash"
+)
+
+// MaxSigningRecords is the maximum number of signing records kept for each address.
+// The oldest records are dropped first.
+const MaxSigningRecords = 1000
+
+// signingScopeOffset is the offset of the scope in the signing context,
+// after the subject hash.
+const signingScopeOffset = hash.HashSize
+
+// SigningRecord keeps the usage of an address key for signing.
+// It holds no key material.
+type SigningRecord struct {
+	Height   uint32    `json:"height"`    // Height that the data is signed for
+	Subject  string    `json:"subject"`   // Subject of the signing in hex, like the block hash
+	Scope    string    `json:"scope"`     // Scope of the signing in hex, like the height, round and vote type
+	SignedAt time.Time `json:"signed_at"` // Time that the record is added
+}
+
+// RecordSigning records that the address key has signed the context.
+// The context has the same layout as the sign bytes of the consensus votes:
+// the subject, a 32-byte hash like the block hash, followed by the scope,
+// which starts with the height as 4 bytes in little endian.
+// Signing two different subjects in the same scope is a double sign.
+// Recording the same context again is a no-op.
+// It works on neutered vaults, since no private key is needed.
+func (v *Vault) RecordSigning(addr string, context []byte) error {
+	if _, ok := v.Addresses[addr]; !ok {
+		return NewErrAddressNotFound(addr)
+	}
+
+	if len(context) < signingScopeOffset+4 {
+		return ErrInvalidSigningContext
+	}
+
+	record := SigningRecord{
+		Height:   binary.LittleEndian.Uint32(context[signingScopeOffset:]),
+		Subject:  hex.EncodeToString(context[:signingScopeOffset]),
+		Scope:    hex.EncodeToString(context[signingScopeOffset:]),
+		SignedAt: timeNow(),
+	}
+
+	records := v.Signings[addr]
+	for _, recorded := range records {
+		if recorded.Subject == record.Subject && recorded.Scope == record.Scope {
+			return nil
+		}
+	}
+
+	if len(records) >= MaxSigningRecords {
+		records = records[len(records)-MaxSigningRecords+1:]
+	}
+
+	if v.Signings == nil {
+		v.Signings = make(map[string][]SigningRecord)
+	}
+	v.Signings[addr] = append(records, record)
+
+	return nil
+}
+
+// SigningHistory returns the signing records of the address, from the oldest.
+func (v *Vault) SigningHistory(addr string) []SigningRecord {
+	records := make([]SigningRecord, len(v.Signings[addr]))
+	copy(records, v.Signings[addr])
+
+	return records
+}
+
+// HasDoubleSign checks if the address key has signed two different subjects
+// in the same scope, like two block hashes for the same height and round.
+func (v *Vault) HasDoubleSign(addr string) bool {
+	subjects := make(map[string]string, len(v.Signings[addr]))
+	for _, record := range v.Signings[addr] {
+		subject, ok := subjects[record.Scope]
+		if ok && subject != record.Subject {
+			return true
+		}
+		subjects[record.Scope] = record.Subject
+	}
+
+	return false
+}
+
+func cloneSignings(signings map[string][]SigningRecord) map[string][]SigningRecord {
+	if signings == nil {
+		return nil
+	}
+
+	cloned := make(map[string][]SigningRecord, len(signings))
+	for addr, records := range signings {
+		cloned[addr] = append([]SigningRecord(nil), records...)
+	}
+
+	return cloned
+}
@@ -0,0 +1,98 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pactus-project/pactus/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signingContext(subject []byte, height uint32, round int16) []byte {
+	context := append([]byte{}, subject...)
+	context = append(context, util.Uint32ToSlice(height)...)
+	context = append(context, util.Int16ToSlice(round)...)
+
+	return context
+}
+
+func TestRecordSigning(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressesByLabel("validator-address")[0].Address
+	hash1 := td.RandHash().Bytes()
+	hash2 := td.RandHash().Bytes()
+
+	t.Run("Unknown address", func(t *testing.T) {
+		unknownAddr := td.RandValAddress().String()
+		err := td.vault.RecordSigning(unknownAddr, signingContext(hash1, 1, 0))
+		assert.ErrorIs(t, err, NewErrAddressNotFound(unknownAddr))
+	})
+
+	t.Run("Invalid context", func(t *testing.T) {
+		err := td.vault.RecordSigning(addr, hash1)
+		assert.ErrorIs(t, err, ErrInvalidSigningContext)
+	})
+
+	t.Run("No double sign", func(t *testing.T) {
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(hash1, 10, 0)))
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(hash2, 10, 1)))
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(hash2, 11, 0)))
+
+		// Recording the same context again is a no-op.
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(hash1, 10, 0)))
+
+		history := td.vault.SigningHistory(addr)
+		require.Len(t, history, 3)
+		assert.Equal(t, uint32(10), history[0].Height)
+		assert.Equal(t, uint32(11), history[2].Height)
+		assert.False(t, td.vault.HasDoubleSign(addr))
+	})
+
+	t.Run("Double sign", func(t *testing.T) {
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(hash2, 10, 0)))
+
+		assert.True(t, td.vault.HasDoubleSign(addr))
+	})
+
+	t.Run("Persisted and kept by neutered vault", func(t *testing.T) {
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+
+		decoded := new(Vault)
+		require.NoError(t, json.Unmarshal(data, decoded))
+		assert.Equal(t, len(td.vault.SigningHistory(addr)), len(decoded.SigningHistory(addr)))
+		assert.True(t, decoded.HasDoubleSign(addr))
+
+		neutered := td.vault.Neuter()
+		assert.True(t, neutered.HasDoubleSign(addr))
+
+		otherAddr := neutered.AddressesByLabel("bls-account-address")[0].Address
+		require.NoError(t, neutered.RecordSigning(otherAddr, signingContext(hash1, 1, 0)))
+		assert.Len(t, neutered.SigningHistory(otherAddr), 1)
+		assert.Empty(t, td.vault.SigningHistory(otherAddr))
+	})
+
+	t.Run("Removed with the address", func(t *testing.T) {
+		addr := td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String()
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(hash1, 1, 0)))
+		require.NoError(t, td.vault.RemoveAddress(addr, tPassword, false))
+
+		assert.Empty(t, td.vault.SigningHistory(addr))
+		assert.NotContains(t, td.vault.Signings, addr)
+	})
+}
+
+func TestSigningHistoryLimit(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressesByLabel("validator-address")[0].Address
+	for height := uint32(1); height <= MaxSigningRecords+10; height++ {
+		require.NoError(t, td.vault.RecordSigning(addr, signingContext(td.RandHash().Bytes(), height, 0)))
+	}
+
+	history := td.vault.SigningHistory(addr)
+	assert.Len(t, history, MaxSigningRecords)
+	assert.Equal(t, uint32(11), history[0].Height)
+}
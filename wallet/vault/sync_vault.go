@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"sync"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+// SyncVault wraps a vault to make it safe for concurrent use.
+// The read-only methods can run concurrently, while the methods that
+// change the vault run exclusively.
+// The wrapped vault should not be accessed directly anymore;
+// use View and Update for the methods that are not wrapped.
+type SyncVault struct {
+	lk sync.RWMutex
+
+	vault *Vault
+}
+
+// NewSyncVault wraps the vault for concurrent use.
+func NewSyncVault(vlt *Vault) *SyncVault {
+	// The read-only methods fill the caches, so they should exist beforehand.
+	if vlt.paths == nil {
+		vlt.paths = newPathCache()
+	}
+	if vlt.order == nil {
+		vlt.order = &orderCache{}
+	}
+	if vlt.pubKeys == nil {
+		vlt.pubKeys = newPubKeyCache()
+	}
+
+	return &SyncVault{
+		vault: vlt,
+	}
+}
+
+// Subscribe returns a channel that receives the events of the vault, and a
+// function to unsubscribe. See Vault.Subscribe.
+func (sv *SyncVault) Subscribe() (<-chan VaultEvent, func()) {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.Subscribe()
+}
+
+// View calls fn with the vault while holding the read lock.
+// The fn should not change the vault.
+func (sv *SyncVault) View(fn func(vlt *Vault)) {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	fn(sv.vault)
+}
+
+// Update calls fn with the vault while holding the write lock.
+func (sv *SyncVault) Update(fn func(vlt *Vault) error) error {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return fn(sv.vault)
+}
+
+func (sv *SyncVault) AddressCount() int {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	return sv.vault.AddressCount()
+}
+
+func (sv *SyncVault) Contains(addr string) bool {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	return sv.vault.Contains(addr)
+}
+
+func (sv *SyncVault) AddressInfo(addr string) *AddressInfo {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	return sv.vault.AddressInfo(addr)
+}
+
+func (sv *SyncVault) AddressInfos() []AddressInfo {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	return sv.vault.AddressInfos()
+}
+
+func (sv *SyncVault) Label(addr string) string {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	return sv.vault.Label(addr)
+}
+
+func (sv *SyncVault) PrivateKeys(password string, addrs []string) ([]crypto.PrivateKey, error) {
+	sv.lk.RLock()
+	defer sv.lk.RUnlock()
+
+	return sv.vault.PrivateKeys(password, addrs)
+}
+
+func (sv *SyncVault) NewValidatorAddress(label string) (*AddressInfo, error) {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.NewValidatorAddress(label)
+}
+
+func (sv *SyncVault) NewBLSAccountAddress(label string) (*AddressInfo, error) {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.NewBLSAccountAddress(label)
+}
+
+func (sv *SyncVault) NewEd25519AccountAddress(label, password string) (*AddressInfo, error) {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.NewEd25519AccountAddress(label, password)
+}
+
+func (sv *SyncVault) SetLabel(addr, label string) error {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.SetLabel(addr, label)
+}
+
+func (sv *SyncVault) ImportBLSPrivateKey(password string, prv *bls.PrivateKey) error {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.ImportBLSPrivateKey(password, prv)
+}
+
+func (sv *SyncVault) ImportEd25519PrivateKey(password string, prv *ed25519.PrivateKey) error {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.ImportEd25519PrivateKey(password, prv)
+}
+
+func (sv *SyncVault) UpdatePassword(oldPassword, newPassword string, opts ...encrypter.Option) error {
+	sv.lk.Lock()
+	defer sv.lk.Unlock()
+
+	return sv.vault.UpdatePassword(oldPassword, newPassword, opts...)
+}
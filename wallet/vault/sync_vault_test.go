@@ -0,0 +1,83 @@
+package vault
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncVaultConcurrentAccess(t *testing.T) {
+	td := setup(t)
+
+	syncVault := NewSyncVault(td.vault)
+	knownAddr := td.vault.AddressInfos()[0].Address
+
+	const workers = 8
+	const rounds = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*rounds)
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for r := 0; r < rounds; r++ {
+				info, err := syncVault.NewBLSAccountAddress(fmt.Sprintf("worker-%d-%d", w, r))
+				if err != nil {
+					errs <- err
+
+					continue
+				}
+				if err := syncVault.SetLabel(info.Address, "updated"); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+
+		go func() {
+			defer wg.Done()
+
+			for r := 0; r < rounds; r++ {
+				_ = syncVault.AddressInfos()
+				_ = syncVault.AddressInfo(knownAddr)
+				_ = syncVault.Contains(knownAddr)
+				_ = syncVault.AddressCount()
+				syncVault.View(func(vlt *Vault) {
+					_, _ = vlt.PublicKey(knownAddr)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 6+workers*rounds, syncVault.AddressCount())
+	syncVault.View(func(vlt *Vault) {
+		assert.Len(t, vlt.AddressesByLabel("updated"), workers*rounds)
+		assert.Equal(t, uint32(1+workers*rounds), vlt.Purposes.PurposeBLS.NextAccountIndex)
+	})
+}
+
+func TestSyncVaultUpdate(t *testing.T) {
+	td := setup(t)
+
+	syncVault := NewSyncVault(td.vault)
+	err := syncVault.Update(func(vlt *Vault) error {
+		_, err := vlt.NewValidatorAddress("new-validator")
+
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, syncVault.AddressCount())
+	assert.Len(t, td.vault.AddressesByLabel("new-validator"), 1)
+}
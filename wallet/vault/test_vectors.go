@@ -0,0 +1,65 @@
+package vault
+
+import (
+	"github.com/pactus-project/pactus/crypto"
+)
+
+// TestVector is a derived address for the cross-implementation compatibility tests.
+type TestVector struct {
+	Fingerprint string             `json:"fingerprint"`  // Fingerprint of the master public key in hex
+	AddressType crypto.AddressType `json:"address_type"` // Type of the address
+	Path        string             `json:"path"`         // Derivation path of the address
+	PublicKey   string             `json:"public_key"`   // Public key of the address
+	Address     string             `json:"address"`      // Address, encoded for the current network
+}
+
+// GenerateTestVectors derives the first `count` addresses of each address type,
+// validator, BLS account and Ed25519 account, from the mnemonic.
+// Other implementations of the Pactus wallet can compare their derivation against them.
+// The output is deterministic: the vectors are ordered by the address type,
+// then by the address index.
+func GenerateTestVectors(mnemonic string, coinType uint32, count int) ([]TestVector, error) {
+	if count <= 0 {
+		return nil, ErrInvalidCount
+	}
+
+	vlt, err := CreateVaultFromMnemonic(mnemonic, coinType)
+	if err != nil {
+		return nil, err
+	}
+
+	derivers := []struct {
+		addressType crypto.AddressType
+		newAddress  func() (*AddressInfo, error)
+	}{
+		{crypto.AddressTypeValidator, func() (*AddressInfo, error) {
+			return vlt.NewValidatorAddress("")
+		}},
+		{crypto.AddressTypeBLSAccount, func() (*AddressInfo, error) {
+			return vlt.NewBLSAccountAddress("")
+		}},
+		{crypto.AddressTypeEd25519Account, func() (*AddressInfo, error) {
+			return vlt.NewEd25519AccountAddress("", "")
+		}},
+	}
+
+	vectors := make([]TestVector, 0, len(derivers)*count)
+	for _, deriver := range derivers {
+		for i := 0; i < count; i++ {
+			info, err := deriver.newAddress()
+			if err != nil {
+				return nil, err
+			}
+
+			vectors = append(vectors, TestVector{
+				Fingerprint: vlt.Fingerprint,
+				AddressType: deriver.addressType,
+				Path:        info.Path,
+				PublicKey:   info.PublicKey,
+				Address:     info.Address,
+			})
+		}
+	}
+
+	return vectors, nil
+}
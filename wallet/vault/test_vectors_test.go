@@ -0,0 +1,51 @@
+package vault
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/pactus-project/pactus/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+const testVectorsMnemonic = "abandon abandon abandon abandon abandon abandon " +
+	"abandon abandon abandon abandon abandon about"
+
+func TestGenerateTestVectors(t *testing.T) {
+	vectors, err := GenerateTestVectors(testVectorsMnemonic, CoinTypeMainnet, 3)
+	require.NoError(t, err)
+	require.Len(t, vectors, 9)
+
+	data, err := json.MarshalIndent(vectors, "", "  ")
+	require.NoError(t, err)
+
+	goldenFile := "./testdata/test_vectors.json"
+	if *updateGolden {
+		require.NoError(t, util.WriteFile(goldenFile, data))
+	}
+
+	golden, err := util.ReadFile(goldenFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(golden), string(data),
+		"derivation is changed, run the test with -update if it is intended")
+
+	t.Run("Stable across runs", func(t *testing.T) {
+		again, err := GenerateTestVectors(testVectorsMnemonic, CoinTypeMainnet, 3)
+		require.NoError(t, err)
+		assert.Equal(t, vectors, again)
+	})
+
+	t.Run("Invalid count", func(t *testing.T) {
+		_, err := GenerateTestVectors(testVectorsMnemonic, CoinTypeMainnet, 0)
+		assert.ErrorIs(t, err, ErrInvalidCount)
+	})
+
+	t.Run("Invalid mnemonic", func(t *testing.T) {
+		_, err := GenerateTestVectors("invalid mnemonic", CoinTypeMainnet, 1)
+		assert.Error(t, err)
+	})
+}
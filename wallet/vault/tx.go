@@ -0,0 +1,97 @@
+package vault
+
+import "golang.org/x/exp/slices"
+
+// Tx is a transaction on the vault.
+// It keeps a snapshot of the addresses and the next derivation indexes, so the
+// derived addresses can be discarded by Rollback without leaving a gap.
+// The transaction is not safe for concurrent use.
+type Tx struct {
+	vault     *Vault
+	addresses map[string]AddressInfo
+	purposes  purposes
+	done      bool
+}
+
+// Begin starts a new transaction on the vault.
+func (v *Vault) Begin() *Tx {
+	addresses := make(map[string]AddressInfo, len(v.Addresses))
+	for addr, info := range v.Addresses {
+		addresses[addr] = info
+	}
+
+	return &Tx{
+		vault:     v,
+		addresses: addresses,
+		purposes:  v.Purposes,
+	}
+}
+
+// NewValidatorAddress derives a new validator address in the transaction.
+func (tx *Tx) NewValidatorAddress(label string) (*AddressInfo, error) {
+	if tx.done {
+		return nil, ErrTxDone
+	}
+
+	return tx.vault.NewValidatorAddress(label)
+}
+
+// NewBLSAccountAddress derives a new BLS account address in the transaction.
+func (tx *Tx) NewBLSAccountAddress(label string) (*AddressInfo, error) {
+	if tx.done {
+		return nil, ErrTxDone
+	}
+
+	return tx.vault.NewBLSAccountAddress(label)
+}
+
+// NewEd25519AccountAddress derives a new Ed25519 account address in the transaction.
+func (tx *Tx) NewEd25519AccountAddress(label, password string) (*AddressInfo, error) {
+	if tx.done {
+		return nil, ErrTxDone
+	}
+
+	return tx.vault.NewEd25519AccountAddress(label, password)
+}
+
+// Commit keeps the changes made in the transaction.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+
+	tx.done = true
+	tx.addresses = nil
+
+	return nil
+}
+
+// Rollback discards the changes made in the transaction and restores the
+// addresses and the next derivation indexes.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return ErrTxDone
+	}
+
+	tx.done = true
+	added := make([]string, 0)
+	for addr := range tx.vault.Addresses {
+		if _, ok := tx.addresses[addr]; !ok {
+			added = append(added, addr)
+		}
+	}
+	slices.Sort(added)
+
+	tx.vault.Addresses = tx.addresses
+	tx.vault.Purposes = tx.purposes
+	tx.addresses = nil
+	for _, addr := range added {
+		tx.vault.notify(EventAddressRemoved, addr)
+	}
+	if slices.Contains(added, tx.vault.DefaultAddr) {
+		tx.vault.DefaultAddr = ""
+		tx.vault.notify(EventDefaultAddressChanged, "")
+	}
+
+	return nil
+}
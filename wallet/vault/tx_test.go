@@ -0,0 +1,74 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxRollback(t *testing.T) {
+	td := setup(t)
+
+	addrs := td.vault.AddressInfos()
+	purposes := td.vault.Purposes
+
+	tx := td.vault.Begin()
+	valInfo, err := tx.NewValidatorAddress("validator")
+	require.NoError(t, err)
+	_, err = tx.NewBLSAccountAddress("bls-account")
+	require.NoError(t, err)
+	_, err = tx.NewEd25519AccountAddress("ed25519-account", tPassword)
+	require.NoError(t, err)
+	assert.Equal(t, 9, td.vault.AddressCount())
+
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, addrs, td.vault.AddressInfos())
+	assert.Equal(t, purposes, td.vault.Purposes)
+	assert.False(t, td.vault.Contains(valInfo.Address))
+
+	t.Run("Next derivation reuses the index", func(t *testing.T) {
+		info, err := td.vault.NewValidatorAddress("validator")
+		require.NoError(t, err)
+		assert.Equal(t, valInfo.Address, info.Address)
+	})
+
+	t.Run("Closed transaction", func(t *testing.T) {
+		_, err := tx.NewValidatorAddress("validator")
+		assert.ErrorIs(t, err, ErrTxDone)
+		_, err = tx.NewBLSAccountAddress("bls-account")
+		assert.ErrorIs(t, err, ErrTxDone)
+		_, err = tx.NewEd25519AccountAddress("ed25519-account", tPassword)
+		assert.ErrorIs(t, err, ErrTxDone)
+		assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+		assert.ErrorIs(t, tx.Rollback(), ErrTxDone)
+	})
+}
+
+func TestTxCommit(t *testing.T) {
+	td := setup(t)
+
+	tx := td.vault.Begin()
+	info, err := tx.NewBLSAccountAddress("bls-account")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	assert.True(t, td.vault.Contains(info.Address))
+	assert.Equal(t, uint32(2), td.vault.Purposes.PurposeBLS.NextAccountIndex)
+	assert.ErrorIs(t, tx.Rollback(), ErrTxDone)
+	assert.True(t, td.vault.Contains(info.Address))
+}
+
+func TestTxFailedDerivation(t *testing.T) {
+	td := setup(t)
+
+	tx := td.vault.Begin()
+	_, err := tx.NewValidatorAddress("validator")
+	require.NoError(t, err)
+	_, err = tx.NewEd25519AccountAddress("ed25519-account", "wrong-password")
+	require.Error(t, err)
+
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, 6, td.vault.AddressCount())
+	assert.Equal(t, uint32(1), td.vault.Purposes.PurposeBLS.NextValidatorIndex)
+}
@@ -1,27 +1,23 @@
 package vault
 
 import (
+	"time"
+
 	"github.com/pactus-project/pactus/wallet/addresspath"
-	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/exp/constraints"
 )
 
 // GenerateMnemonic generates a new mnemonic (seed phrase) based on BIP-39
 // https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+// It is kept for backward compatibility, use GenerateMnemonicStrength instead.
 func GenerateMnemonic(bitSize int) (string, error) {
-	entropy, err := bip39.NewEntropy(bitSize)
-	if err != nil {
-		return "", err
-	}
-
-	mnemonic, _ := bip39.NewMnemonic(entropy)
-
-	return mnemonic, nil
+	return GenerateMnemonicStrength(MnemonicStrength(bitSize))
 }
 
 // CheckMnemonic validates a mnemonic (seed phrase) based on BIP-39.
+// The language of the mnemonic is detected automatically.
 func CheckMnemonic(mnemonic string) error {
-	_, err := bip39.EntropyFromMnemonic(mnemonic)
+	_, err := entropyFromMnemonic(mnemonic)
 
 	return err
 }
@@ -29,11 +25,19 @@ func CheckMnemonic(mnemonic string) error {
 // _H hardens the integer value 'i' by adding 0x80000000 (2^31) to it.
 // This function does not check if 'i' is already hardened.
 func _H[T constraints.Integer](i T) uint32 {
-	return uint32(i) + addresspath.HardenedKeyStart
+	return addresspath.Hardened(uint32(i))
 }
 
 // _N de-hardens the integer value 'i' by subtracting 0x80000000 (2^31) from it.
-// This function does not check if 'i' is already non-hardened.
+// Non-hardened values are returned unchanged.
 func _N[T constraints.Integer](i T) uint32 {
-	return uint32(i) - addresspath.HardenedKeyStart
+	index, _ := addresspath.Unharden(uint32(i))
+
+	return index
+}
+
+// timeNow returns the current time in UTC, truncated to seconds.
+// It is a variable, so the tests can move the clock.
+var timeNow = func() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
 }
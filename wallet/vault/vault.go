@@ -2,16 +2,22 @@ package vault
 
 import (
 	"cmp"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/pactus-project/pactus/crypto"
 	"github.com/pactus-project/pactus/crypto/bls"
 	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
 	"github.com/pactus-project/pactus/crypto/ed25519"
 	ed25519hdkeychain "github.com/pactus-project/pactus/crypto/ed25519/hdkeychain"
+	"github.com/pactus-project/pactus/crypto/hash"
+	"github.com/pactus-project/pactus/genesis"
 	"github.com/pactus-project/pactus/wallet/addresspath"
 	"github.com/pactus-project/pactus/wallet/encrypter"
-	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/exp/slices"
 )
 
@@ -36,6 +42,7 @@ import (
 // * `purpose`: Indicates the specific use case for the derived addresses:
 //    - 12381: Used for the BLS12-381 curve, based on PIP-8.
 //    - 65535: Used for imported private keys, based on PIP-13.
+//    - 65534: Used for watch-only public keys, specific to this wallet.
 //    - 44: A comprehensive purpose for standard curves, based on BIP-44.
 //
 // * `coin_type`: Identifies the coin type:
@@ -43,44 +50,118 @@ import (
 //    - 21777: Pactus Testnet
 //
 // * `address_type`: Specifies the type of address.
+//    Unlike BIP-44, this level is not an account index. The values 1', 2' and 3'
+//    are the validator, BLS account and Ed25519 account address types, so the
+//    path has no account level and a vault holds a single HD account.
+//    Isolated accounts under the same mnemonic can be created with different
+//    passphrases, see CreateVaultFromMnemonicWithPassphrase.
 //
 // * `address_index`: A sequential number and increase when a new address is derived.
 //
+// Unlike BIP-44, there is no `chain` level to separate the external (receive)
+// and internal (change) addresses. Pactus is account-based, so transactions have
+// no change outputs and the addresses can be reused. All the addresses are on
+// the external chain, ChainExternal. Paths with a chain level are accepted only
+// for the external chain and are stored without it; other chains are rejected
+// with ErrUnsupportedChain.
+//
 // References:
 //  - https://pips.pactus.org/PIPs/pip-8
 //  - https://pips.pactus.org/PIPs/pip-13
 //  - https://github.com/bitcoin/bips/blob/master/bip-0044.mediawiki
 //
 
+const (
+	CoinTypeMainnet = uint32(21888)
+	CoinTypeTestnet = uint32(21777)
+)
+
+// Chains of BIP-44. Only the external chain is supported, see the path specification.
+const (
+	ChainExternal = uint32(0) // Receive addresses
+	ChainInternal = uint32(1) // Change addresses, not supported
+)
+
 const (
 	TypeFull     = int(1)
 	TypeNeutered = int(2)
 )
 
+// Origin defines how the key of an address is obtained.
+type Origin int
+
+const (
+	OriginHDDerived = Origin(0) // Derived from the seed, recoverable from the mnemonic
+	OriginImported  = Origin(1) // Imported private key, not recoverable from the mnemonic
+	OriginWatchOnly = Origin(2) // Public key only, the vault doesn't hold the private key
+)
+
+func (o Origin) String() string {
+	switch o {
+	case OriginHDDerived:
+		return "hd-derived"
+	case OriginImported:
+		return "imported"
+	case OriginWatchOnly:
+		return "watch-only"
+	default:
+		return fmt.Sprintf("unknown origin: %d", int(o))
+	}
+}
+
 type AddressInfo struct {
-	Address   string `json:"address"`    // Address in the wallet
-	PublicKey string `json:"public_key"` // Public key associated with the address
-	Label     string `json:"label"`      // Label for the address
-	Path      string `json:"path"`       // Path for the address
+	Address     string `json:"address"`              // Address in the wallet
+	PublicKey   string `json:"public_key"`           // Public key associated with the address
+	Label       string `json:"label"`                // Label for the address
+	Path        string `json:"path"`                 // Path for the address
+	IsWatchOnly bool   `json:"watch_only,omitempty"` // True if the vault doesn't hold the private key
+	Origin      Origin `json:"origin,omitempty"`     // Origin of the key, omitted for HD derived keys
+	Group       string `json:"group,omitempty"`      // Slash-delimited group of the address, like "Treasury/Cold"
+
+	CreatedAt  time.Time `json:"created_at"`   // Time that the address is derived or imported, zero for old vaults
+	LastUsedAt time.Time `json:"last_used_at"` // Time that the address is last used, set by TouchAddress
 }
 
 const (
 	PurposeBLS12381         = uint32(12381)
 	PurposeBIP44            = uint32(44)
 	PurposeImportPrivateKey = uint32(65535)
+	PurposeWatchOnly        = uint32(65534)
 
 	PurposeBLS12381Hardened         = PurposeBLS12381 + addresspath.HardenedKeyStart
 	PurposeBIP44Hardened            = PurposeBIP44 + addresspath.HardenedKeyStart
 	PurposeImportPrivateKeyHardened = PurposeImportPrivateKey + addresspath.HardenedKeyStart
+	PurposeWatchOnlyHardened        = PurposeWatchOnly + addresspath.HardenedKeyStart
 )
 
+// Vault holds the addresses and the encrypted secrets of a wallet.
+// A vault is not safe for concurrent use. Use SyncVault to share it between goroutines.
 type Vault struct {
-	Type      int                    `json:"type"`      // Wallet type. 1: Full keys, 2: Neutered
-	CoinType  uint32                 `json:"coin_type"` // Coin type: 21888 for Mainnet, 21777 for Testnet
-	Addresses map[string]AddressInfo `json:"addresses"` // All addresses that are stored in the wallet
-	Encrypter encrypter.Encrypter    `json:"encrypter"` // Encryption algorithm
-	KeyStore  string                 `json:"key_store"` // KeyStore that stores the secrets and encrypts using Encrypter
-	Purposes  purposes               `json:"purposes"`  // Contains Purpose 12381 for BLS signature
+	Version     int                    `json:"version,omitempty"`     // Vault format version, see CurrentVaultVersion
+	Type        int                    `json:"type"`                  // Wallet type. 1: Full keys, 2: Neutered
+	CoinType    uint32                 `json:"coin_type"`             // Coin type: 21888 for Mainnet, 21777 for Testnet
+	Addresses   map[string]AddressInfo `json:"addresses"`             // All addresses that are stored in the wallet
+	Encrypter   encrypter.Encrypter    `json:"encrypter"`             // Encryption algorithm
+	KeyStore    string                 `json:"key_store"`             // KeyStore that stores the secrets and encrypts using Encrypter
+	Purposes    purposes               `json:"purposes"`              // Contains Purpose 12381 for BLS signature
+	Fingerprint string                 `json:"fingerprint,omitempty"` // Fingerprint of the master public key in hex
+
+	Signings    map[string][]SigningRecord `json:"signings,omitempty"`        // Signing history of the addresses, see RecordSigning
+	DefaultAddr string                     `json:"default_address,omitempty"` // Preferred address, see SetDefaultAddress
+	Hint        string                     `json:"password_hint,omitempty"`   // Password hint, not secret, see SetPasswordHint
+
+	UnlockPolicy *UnlockPolicy `json:"unlock_policy,omitempty"` // Delayed unlock policy, see SetUnlockPolicy
+
+	session      *session     // Unlock session, not serialized
+	migratedFrom int          // Format version before migration, not serialized
+	decodedCRC   uint32       // CRC of the vault as decoded, before migration, not serialized
+	paths        *pathCache   // Cache of the parsed paths, not serialized
+	order        *orderCache  // Cache of the sorted addresses, not serialized
+	pubKeys      *pubKeyCache // Cache of the parsed public keys, not serialized
+	pool         *addressPool // Addresses derived ahead of time, see EnablePool, not serialized
+	auditLogger  AuditLogger  // Records the accesses to the secrets, see SetAuditLogger, not serialized
+	events       *eventHub    // Subscribers of the events, not serialized
+	provider     SeedProvider // External seed, see WithReadOnlySeed, not serialized
 }
 
 type keyStore struct {
@@ -89,7 +170,8 @@ type keyStore struct {
 }
 
 type masterNode struct {
-	Mnemonic string `json:"seed,omitempty"` // Seed phrase or mnemonic (encrypted)
+	Mnemonic string `json:"seed,omitempty"`            // Seed phrase or mnemonic (encrypted)
+	Seed     string `json:"passphrase_seed,omitempty"` // BIP39 seed, set only if a passphrase is used (encrypted)
 }
 
 type purposes struct {
@@ -108,11 +190,45 @@ type purposeBIP44 struct {
 	NextEd25519Index uint32 `json:"next_ed25519_index"` // Index of next Ed25519 derived account: m/44'/21888/3'/0'
 }
 
+// CreateVaultFromMnemonic creates a new vault from the mnemonic.
+// Mnemonics of 12, 15, 18, 21 and 24 words are supported. To make sure the
+// mnemonic has the number of words of the backup, use ValidateMnemonicStrength.
 func CreateVaultFromMnemonic(mnemonic string, coinType uint32) (*Vault, error) {
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	return CreateVaultFromMnemonicWithPassphrase(mnemonic, "", coinType)
+}
+
+// CreateVaultFromMnemonicWithPassphrase creates a new vault from the mnemonic
+// and the optional BIP39 passphrase (also known as the "25th word").
+// The passphrase itself is never stored. Instead, if it is not empty,
+// the derived seed is kept inside the key store alongside the mnemonic.
+// The language of the mnemonic is detected automatically.
+func CreateVaultFromMnemonicWithPassphrase(mnemonic, passphrase string, coinType uint32) (*Vault, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	store := &keyStore{
+		MasterNode: masterNode{
+			Mnemonic: mnemonic,
+		},
+		ImportedKeys: make([]string, 0),
+	}
+	if passphrase != "" {
+		store.MasterNode.Seed = hex.EncodeToString(newSeed(mnemonic, passphrase))
+	}
+
+	return createVaultFromKeyStore(store, coinType)
+}
+
+// createVaultFromKeyStore creates a new non-encrypted vault from the master node
+// of the key store. The imported keys of the key store are stored as they are,
+// without adding their addresses.
+func createVaultFromKeyStore(store *keyStore, coinType uint32) (*Vault, error) {
+	seed, err := store.MasterNode.seed()
 	if err != nil {
 		return nil, err
 	}
+
 	masterKey, err := blshdkeychain.NewMaster(seed, false)
 	if err != nil {
 		return nil, err
@@ -137,19 +253,13 @@ func CreateVaultFromMnemonic(mnemonic string, coinType uint32) (*Vault, error) {
 		return nil, err
 	}
 
-	store := keyStore{
-		MasterNode: masterNode{
-			Mnemonic: mnemonic,
-		},
-		ImportedKeys: make([]string, 0),
-	}
-
 	storeDate, err := json.Marshal(store)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Vault{
+		Version:   CurrentVaultVersion,
 		Type:      TypeFull,
 		CoinType:  coinType,
 		Encrypter: enc,
@@ -161,17 +271,142 @@ func CreateVaultFromMnemonic(mnemonic string, coinType uint32) (*Vault, error) {
 				XPubAccount:   xPubAccount.Neuter().String(),
 			},
 		},
+		Fingerprint: masterFingerprint(masterKey),
 	}, nil
 }
 
+// PreviewRecovery checks the mnemonic before restoring a vault from it.
+// It returns the master fingerprint and the first address of each address type,
+// keyed by the address type name, like "validator", so the user can compare
+// them with their records.
+// The vault used for derivation is discarded and nothing is persisted.
+func PreviewRecovery(mnemonic, passphrase string, coinType uint32,
+) (fingerprint uint32, firstAddrs map[string]string, err error) {
+	vlt, err := CreateVaultFromMnemonicWithPassphrase(mnemonic, passphrase, coinType)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	validatorInfo, err := vlt.NewValidatorAddress("")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	blsAccountInfo, err := vlt.NewBLSAccountAddress("")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The throwaway vault is not encrypted.
+	ed25519AccountInfo, err := vlt.NewEd25519AccountAddress("", "")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	firstAddrs = map[string]string{
+		crypto.AddressTypeValidator.String():      validatorInfo.Address,
+		crypto.AddressTypeBLSAccount.String():     blsAccountInfo.Address,
+		crypto.AddressTypeEd25519Account.String(): ed25519AccountInfo.Address,
+	}
+
+	return vlt.MasterFingerprint(), firstAddrs, nil
+}
+
+// masterFingerprint returns the first 4 bytes of the hash of the master public key in hex.
+func masterFingerprint(masterKey *blshdkeychain.ExtendedKey) string {
+	return hex.EncodeToString(hash.Hash160(masterKey.RawPublicKey())[:4])
+}
+
+// MasterFingerprint returns the fingerprint of the master public key.
+// It identifies the seed of the vault and it's available on neutered vaults.
+// Old vaults that don't store the fingerprint return zero.
+func (v *Vault) MasterFingerprint() uint32 {
+	data, err := hex.DecodeString(v.Fingerprint)
+	if err != nil || len(data) != 4 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(data)
+}
+
+// Clone returns a deep copy of the vault.
+// Changing the clone doesn't affect the original vault.
+// The clone is locked, even if the original vault is unlocked.
+func (v *Vault) Clone() *Vault {
+	cloned := &Vault{
+		Version:      v.Version,
+		Type:         v.Type,
+		CoinType:     v.CoinType,
+		Addresses:    make(map[string]AddressInfo, len(v.Addresses)),
+		Encrypter:    v.Encrypter.Clone(),
+		KeyStore:     v.KeyStore,
+		Purposes:     v.Purposes,
+		Fingerprint:  v.Fingerprint,
+		Signings:     cloneSignings(v.Signings),
+		DefaultAddr:  v.DefaultAddr,
+		Hint:         v.Hint,
+		UnlockPolicy: v.UnlockPolicy.clone(),
+		migratedFrom: v.migratedFrom,
+		decodedCRC:   v.decodedCRC,
+		provider:     v.provider,
+	}
+
+	for addr, info := range v.Addresses {
+		cloned.Addresses[addr] = info
+	}
+
+	return cloned
+}
+
+// Equal checks if both vaults are structurally the same.
+// It compares the version, type, coin type, fingerprint, purposes,
+// the encrypter method and KDF parameters, the default address, and the
+// addresses with their public keys, paths, labels, groups and origins.
+// The key store is not compared, since the same secrets encrypt to different
+// cipher texts. The address timestamps, the signing history, the password hint,
+// the unlock policy and session, and the migration state are not compared either.
+func (v *Vault) Equal(other *Vault) bool {
+	if v.Version != other.Version ||
+		v.Type != other.Type ||
+		v.CoinType != other.CoinType ||
+		v.Fingerprint != other.Fingerprint ||
+		v.Purposes != other.Purposes ||
+		v.DefaultAddr != other.DefaultAddr ||
+		!v.Encrypter.Equal(&other.Encrypter) ||
+		len(v.Addresses) != len(other.Addresses) {
+		return false
+	}
+
+	for addr, info := range v.Addresses {
+		otherInfo, ok := other.Addresses[addr]
+		if !ok {
+			return false
+		}
+
+		info.CreatedAt, info.LastUsedAt = time.Time{}, time.Time{}
+		otherInfo.CreatedAt, otherInfo.LastUsedAt = time.Time{}, time.Time{}
+		if info != otherInfo {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Neuter returns a copy of the vault without the secrets.
+// The vault itself is not changed, see NeuterInPlace.
 func (v *Vault) Neuter() *Vault {
 	neutered := &Vault{
-		Type:      TypeNeutered,
-		CoinType:  v.CoinType,
-		Encrypter: encrypter.NopeEncrypter(),
-		Addresses: make(map[string]AddressInfo),
-		KeyStore:  "",
-		Purposes:  v.Purposes,
+		Version:     CurrentVaultVersion,
+		Type:        TypeNeutered,
+		CoinType:    v.CoinType,
+		Encrypter:   encrypter.NopeEncrypter(),
+		Addresses:   make(map[string]AddressInfo),
+		KeyStore:    "",
+		Purposes:    v.Purposes,
+		Fingerprint: v.Fingerprint,
+		Signings:    cloneSignings(v.Signings),
+		DefaultAddr: v.DefaultAddr,
 	}
 
 	for addr, info := range v.Addresses {
@@ -181,20 +416,122 @@ func (v *Vault) Neuter() *Vault {
 	return neutered
 }
 
+// NeuterInPlace removes the secrets from the vault, the encrypted key store and
+// the unlock session, so it keeps only the addresses and the public keys.
+// The secrets can't be restored, except by recovering from the mnemonic, so
+// make sure another copy of the vault or the mnemonic exists before calling it.
+func (v *Vault) NeuterInPlace() {
+	v.Lock()
+
+	v.Version = CurrentVaultVersion
+	v.Type = TypeNeutered
+	v.Encrypter = encrypter.NopeEncrypter()
+	v.KeyStore = ""
+	v.Hint = ""
+	v.UnlockPolicy = nil
+	v.notify(EventNeutered, "")
+}
+
+// CanUnneuter checks if the vault still holds its secrets, so they can be used
+// after neutering a copy of it. It is always false for a neutered vault, since
+// the secrets can't be restored from the vault itself.
+func (v *Vault) CanUnneuter() bool {
+	return !v.IsNeutered()
+}
+
+// IsTestnet returns true if the vault is created for the testnet coin type.
+func (v *Vault) IsTestnet() bool {
+	return v.CoinType == CoinTypeTestnet
+}
+
+// Network returns the network of the vault based on its coin type.
+// Custom coin types, used by local networks, return Localnet.
+func (v *Vault) Network() genesis.ChainType {
+	switch v.CoinType {
+	case CoinTypeMainnet:
+		return genesis.Mainnet
+	case CoinTypeTestnet:
+		return genesis.Testnet
+	default:
+		return genesis.Localnet
+	}
+}
+
 func (v *Vault) IsNeutered() bool {
 	return v.Type == TypeNeutered
 }
 
+// UpdatePassword re-encrypts the vault with the new password.
+// If the new password is empty, the vault is not encrypted anymore and the
+// password hint and the delayed unlock policy are removed.
+// The new password is checked against the password policy in the options,
+// like encrypter.OptionMinPasswordEntropy, and against the password hint.
 func (v *Vault) UpdatePassword(oldPassword, newPassword string, opts ...encrypter.Option) error {
+	return v.UpdatePasswordCtx(context.Background(), oldPassword, newPassword, opts...)
+}
+
+// UpdatePasswordCtx is like UpdatePassword, but it can be cancelled through the context.
+// If the context is done, the vault remains unchanged.
+func (v *Vault) UpdatePasswordCtx(ctx context.Context, oldPassword, newPassword string,
+	opts ...encrypter.Option,
+) error {
+	return v.RekeyWithProgress(ctx, oldPassword, newPassword, nil, opts...)
+}
+
+// Rekey re-encrypts all the secrets of the vault, the seed and the imported
+// private keys, with the new password and the new encryption parameters.
+// A new random salt is generated even if the old and new passwords are the same,
+// so all the ciphertexts change.
+// It is atomic: if re-encryption fails, the vault remains unchanged.
+// All the secrets are kept in a single key store, so the password is hashed
+// only twice, once to decrypt with the old salt and once to encrypt with the new
+// salt, regardless of the number of imported keys. The memory of the password
+// hasher is not kept after hashing, so the peak memory is about one run of it.
+func (v *Vault) Rekey(oldPassword, newPassword string, opts ...encrypter.Option) error {
+	return v.RekeyWithProgress(context.Background(), oldPassword, newPassword, nil, opts...)
+}
+
+// RekeyWithProgress is like Rekey, but it reports the progress of the
+// decryption and the encryption steps and can be cancelled through the context.
+// If the context is cancelled, the vault remains unchanged.
+func (v *Vault) RekeyWithProgress(ctx context.Context, oldPassword, newPassword string,
+	progress ProgressFunc, opts ...encrypter.Option,
+) error {
+	const totalSteps = 2
+
 	if v.IsNeutered() {
 		return ErrNeutered
 	}
 
-	keyStore, err := v.decryptKeyStore(oldPassword)
+	if newPassword != "" {
+		err := encrypter.CheckPasswordStrength(newPassword, opts...)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPasswordHint(v.Hint, newPassword); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	progress.report(0, totalSteps)
+
+	keyStore, err := v.decryptKeyStoreCtx(ctx, oldPassword)
 	if err != nil {
+		v.audit(ctx, AuditUpdatePassword, nil, err)
+
+		return err
+	}
+	progress.report(1, totalSteps)
+
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
+	oldEncrypter := v.Encrypter
 	newEncrypter := encrypter.NopeEncrypter()
 	if newPassword != "" {
 		newEncrypter = encrypter.DefaultEncrypter(opts...)
@@ -202,12 +539,58 @@ func (v *Vault) UpdatePassword(oldPassword, newPassword string, opts ...encrypte
 	v.Encrypter = newEncrypter
 	err = v.encryptKeyStore(keyStore, newPassword)
 	if err != nil {
+		// Roll back, the key store is only updated on success.
+		v.Encrypter = oldEncrypter
+
 		return err
 	}
+	if newPassword == "" {
+		v.Hint = ""
+		v.UnlockPolicy = nil
+	}
+	progress.report(totalSteps, totalSteps)
+	v.notify(EventPasswordChanged, "")
+	v.audit(ctx, AuditUpdatePassword, nil, nil)
+
+	return nil
+}
+
+// UpgradeEncryption re-encrypts the vault with the same password, if the stored
+// encryption parameters are weaker than the default encrypter with the given options.
+// The stored parameters that are already stronger are kept.
+// It returns false if the vault is strong enough or not encrypted. In this case
+// the password is not checked, so no password hashing is done.
+// After upgrading, the vault is locked.
+func (v *Vault) UpgradeEncryption(password string, opts ...encrypter.Option) (bool, error) {
+	if v.IsNeutered() {
+		return false, ErrNeutered
+	}
+
+	if !v.IsEncrypted() {
+		return false, nil
+	}
+
+	newEncrypter, weaker := v.Encrypter.Strengthen(opts...)
+	if !weaker {
+		return false, nil
+	}
+
+	keyStore, err := v.decryptKeyStore(password)
+	if err != nil {
+		return false, err
+	}
 
+	oldEncrypter := v.Encrypter
 	v.Encrypter = newEncrypter
+	err = v.encryptKeyStore(keyStore, password)
+	if err != nil {
+		// Roll back, the key store is only updated on success.
+		v.Encrypter = oldEncrypter
 
-	return nil
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (v *Vault) Label(addr string) string {
@@ -219,83 +602,252 @@ func (v *Vault) Label(addr string) string {
 	return info.Label
 }
 
+// SetLabel sets the label of the address.
+// The label is normalized to NFC and control characters are removed.
+// It returns ErrLabelTooLong if the normalized label is longer than MaxLabelLength bytes.
 func (v *Vault) SetLabel(addr, label string) error {
 	info, ok := v.Addresses[addr]
 	if !ok {
 		return NewErrAddressNotFound(addr)
 	}
 
+	label, err := validateLabel(label)
+	if err != nil {
+		return err
+	}
+
+	if info.Label == label {
+		return nil
+	}
+
 	info.Label = label
 	v.Addresses[addr] = info
+	v.notify(EventLabelChanged, addr)
 
 	return nil
 }
 
-func (v *Vault) AddressInfos() []AddressInfo {
-	addrs := make([]AddressInfo, 0, 1)
-	for _, addrInfo := range v.Addresses {
-		addrs = append(addrs, addrInfo)
+// SetDefaultAddress sets the preferred address of the vault, for example for
+// receiving or paying the fees. An empty address clears it.
+// It is only metadata and doesn't affect signing.
+// Removing the default address from the vault clears it too.
+func (v *Vault) SetDefaultAddress(addr string) error {
+	if addr != "" && !v.Contains(addr) {
+		return NewErrAddressNotFound(addr)
+	}
+
+	if v.DefaultAddr == addr {
+		return nil
 	}
 
-	v.sortAddressesByAddressIndex(addrs...)
-	v.sortAddressesByAddressType(addrs...)
-	v.sortAddressesByPurpose(addrs...)
+	v.DefaultAddr = addr
+	v.notify(EventDefaultAddressChanged, addr)
 
-	return addrs
+	return nil
 }
 
-func (v *Vault) AllValidatorAddresses() []AddressInfo {
-	addrs := make([]AddressInfo, 0, v.AddressCount()/2)
-	for _, addrInfo := range v.Addresses {
-		addrPath, _ := addresspath.FromString(addrInfo.Path)
-		if addrPath.AddressType() == _H(crypto.AddressTypeValidator) {
-			addrs = append(addrs, addrInfo)
+// DefaultAddress returns the default address of the vault, if it is set.
+func (v *Vault) DefaultAddress() (string, bool) {
+	return v.DefaultAddr, v.DefaultAddr != ""
+}
+
+// TouchAddress sets the last used time of the address to the current time.
+func (v *Vault) TouchAddress(addr string) error {
+	info, ok := v.Addresses[addr]
+	if !ok {
+		return NewErrAddressNotFound(addr)
+	}
+
+	info.LastUsedAt = timeNow()
+	v.Addresses[addr] = info
+
+	return nil
+}
+
+// RemoveAddress removes the address from the vault.
+//
+// For HD-derived addresses, removal doesn't roll back the next index, so
+// re-deriving addresses stays deterministic. It refuses to remove an address
+// if an address with a higher index of the same type exists, unless force is set.
+//
+// For imported private keys, the key is also purged from the key store,
+// once no other address refers to it. The password is only used in this case.
+func (v *Vault) RemoveAddress(addr, password string, force bool) error {
+	info, ok := v.Addresses[addr]
+	if !ok {
+		return NewErrAddressNotFound(addr)
+	}
+
+	addrPath, err := addresspath.FromString(info.Path)
+	if err != nil {
+		return VaultError{Op: "remove address", Address: addr, Path: info.Path, Err: err}
+	}
+
+	switch addrPath.Purpose() {
+	case _H(PurposeBLS12381), _H(PurposeBIP44):
+		if !force && v.hasHigherIndex(addrPath) {
+			return ErrIndexGap
+		}
+
+	case _H(PurposeImportPrivateKey):
+		if !v.IsNeutered() && !v.isImportedKeyShared(addr, addrPath) {
+			err := v.purgeImportedKey(password, _N(addrPath.AddressIndex()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	delete(v.Addresses, addr)
+	delete(v.Signings, addr)
+	v.notify(EventAddressRemoved, addr)
+	if v.DefaultAddr == addr {
+		v.DefaultAddr = ""
+		v.notify(EventDefaultAddressChanged, "")
+	}
+
+	return nil
+}
+
+// hasHigherIndex checks if an address with the same purpose and address type,
+// but a higher address index, exists in the vault.
+func (v *Vault) hasHigherIndex(addrPath addresspath.Path) bool {
+	for _, info := range v.Addresses {
+		otherPath, err := addresspath.FromString(info.Path)
+		if err != nil || len(otherPath) != len(addrPath) {
+			continue
+		}
+
+		if otherPath.Purpose() == addrPath.Purpose() &&
+			otherPath.CoinType() == addrPath.CoinType() &&
+			otherPath.AddressType() == addrPath.AddressType() &&
+			otherPath.AddressIndex() > addrPath.AddressIndex() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isImportedKeyShared checks if another address is derived from the same imported key.
+// An imported BLS key is used for both validator and account addresses.
+func (v *Vault) isImportedKeyShared(addr string, addrPath addresspath.Path) bool {
+	for _, info := range v.Addresses {
+		if info.Address == addr {
+			continue
+		}
+
+		otherPath, err := addresspath.FromString(info.Path)
+		if err != nil {
+			continue
+		}
+
+		if otherPath.Purpose() == addrPath.Purpose() &&
+			otherPath.AddressIndex() == addrPath.AddressIndex() {
+			return true
 		}
 	}
 
-	v.sortAddressesByAddressIndex(addrs...)
-	v.sortAddressesByPurpose(addrs...)
+	return false
+}
+
+// purgeImportedKey clears the imported key at the given index.
+// The entry is kept empty, so the index of the other imported keys doesn't change.
+func (v *Vault) purgeImportedKey(password string, index uint32) error {
+	keyStore, err := v.decryptKeyStore(password)
+	if err != nil {
+		return err
+	}
+
+	if int(index) >= len(keyStore.ImportedKeys) {
+		return ErrInvalidPath
+	}
+	keyStore.ImportedKeys[index] = ""
+
+	return v.encryptKeyStore(keyStore, password)
+}
+
+func (v *Vault) AddressInfos() []AddressInfo {
+	addrs := v.sortedAddresses()
+	infos := make([]AddressInfo, len(addrs))
+	for i, addr := range addrs {
+		infos[i] = v.Addresses[addr]
+	}
+
+	return infos
+}
+
+// SortByCreatedAt returns all the addresses sorted by their creation time.
+// Addresses with the same creation time, like the addresses of old vaults,
+// keep the same order as AddressInfos.
+func (v *Vault) SortByCreatedAt() []AddressInfo {
+	addrs := v.AddressInfos()
+	slices.SortStableFunc(addrs, func(a, b AddressInfo) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
 
 	return addrs
 }
 
-func (v *Vault) AllAccountAddresses() []AddressInfo {
-	addrs := make([]AddressInfo, 0, v.AddressCount()/2)
-	for _, addrInfo := range v.Addresses {
-		addrPath, _ := addresspath.FromString(addrInfo.Path)
-		if addrPath.AddressType() != _H(crypto.AddressTypeValidator) {
-			addrs = append(addrs, addrInfo)
+// ForEachAddress calls fn for each address in the same order as AddressInfos,
+// until fn returns false.
+// Unlike AddressInfos, no slice of AddressInfo is built.
+// The vault should not be changed by fn.
+func (v *Vault) ForEachAddress(fn func(AddressInfo) bool) {
+	for _, addr := range v.sortedAddresses() {
+		if !fn(v.Addresses[addr]) {
+			return
 		}
 	}
+}
 
-	v.sortAddressesByAddressIndex(addrs...)
-	v.sortAddressesByPurpose(addrs...)
+func (v *Vault) AllValidatorAddresses() []AddressInfo {
+	return v.addressesOfTypes(crypto.AddressTypeValidator)
+}
+
+func (v *Vault) AllAccountAddresses() []AddressInfo {
+	return v.addressesOfTypes(crypto.AddressTypeBLSAccount, crypto.AddressTypeEd25519Account)
+}
+
+// addressesOfTypes returns the addresses of the given types, in the same order
+// as AddressInfos.
+func (v *Vault) addressesOfTypes(addressTypes ...crypto.AddressType) []AddressInfo {
+	addrs := make([]AddressInfo, 0)
+	v.ForEachAddress(func(info AddressInfo) bool {
+		path, err := v.parsePath(info.Path)
+		if err == nil && len(path) >= 3 &&
+			slices.Contains(addressTypes, crypto.AddressType(_N(path.AddressType()))) {
+			addrs = append(addrs, info)
+		}
+
+		return true
+	})
 
 	return addrs
 }
 
-func (*Vault) sortAddressesByPurpose(addrs ...AddressInfo) {
+func (v *Vault) sortAddressesByPurpose(addrs ...AddressInfo) {
 	slices.SortStableFunc(addrs, func(a, b AddressInfo) int {
-		pathA, _ := addresspath.FromString(a.Path)
-		pathB, _ := addresspath.FromString(b.Path)
+		pathA, _ := v.parsePath(a.Path)
+		pathB, _ := v.parsePath(b.Path)
 
 		return cmp.Compare(pathA.Purpose(), pathB.Purpose())
 	})
 }
 
-func (*Vault) sortAddressesByAddressType(addrs ...AddressInfo) {
+func (v *Vault) sortAddressesByAddressType(addrs ...AddressInfo) {
 	slices.SortStableFunc(addrs, func(a, b AddressInfo) int {
-		pathA, _ := addresspath.FromString(a.Path)
-		pathB, _ := addresspath.FromString(b.Path)
+		pathA, _ := v.parsePath(a.Path)
+		pathB, _ := v.parsePath(b.Path)
 
 		return cmp.Compare(pathA.AddressType(), pathB.AddressType())
 	})
 }
 
-func (*Vault) sortAddressesByAddressIndex(addrs ...AddressInfo) {
+func (v *Vault) sortAddressesByAddressIndex(addrs ...AddressInfo) {
 	slices.SortStableFunc(addrs, func(a, b AddressInfo) int {
-		pathA, _ := addresspath.FromString(a.Path)
-		pathB, _ := addresspath.FromString(b.Path)
+		pathA, _ := v.parsePath(a.Path)
+		pathB, _ := v.parsePath(b.Path)
 
 		return cmp.Compare(pathA.AddressIndex(), pathB.AddressIndex())
 	})
@@ -305,21 +857,88 @@ func (v *Vault) IsEncrypted() bool {
 	return v.Encrypter.IsEncrypted()
 }
 
+// EncryptionParams returns the password hasher and cipher parameters used to
+// encrypt the vault. No password is required, since they are stored in plain.
+// It returns encrypter.ErrNotEncrypted if the vault is not encrypted.
+func (v *Vault) EncryptionParams() (encrypter.EncryptionInfo, error) {
+	return v.Encrypter.Info()
+}
+
 func (v *Vault) AddressCount() int {
 	return len(v.Addresses)
 }
 
+// AddressCountByType returns the number of addresses for each address type.
+// Addresses with a corrupted path are not counted.
+func (v *Vault) AddressCountByType() map[crypto.AddressType]int {
+	counts := make(map[crypto.AddressType]int)
+	for _, info := range v.Addresses {
+		addrPath, err := v.parsePath(info.Path)
+		if err != nil || len(addrPath) < 3 {
+			continue
+		}
+		counts[crypto.AddressType(_N(addrPath.AddressType()))]++
+	}
+
+	return counts
+}
+
+// AddressCountByOrigin returns the number of addresses for each key origin.
+func (v *Vault) AddressCountByOrigin() map[Origin]int {
+	counts := make(map[Origin]int)
+	for _, info := range v.Addresses {
+		counts[info.Origin]++
+	}
+
+	return counts
+}
+
+// AddressFromPath returns the address info for the derivation path, or nil if
+// the path is not valid or not found.
 func (v *Vault) AddressFromPath(p string) *AddressInfo {
+	info, _ := v.AddressFromPathE(p)
+
+	return info
+}
+
+// AddressFromPathE returns the address info for the derivation path.
+// It returns the parsing error if the path is malformed, ErrInvalidPath if the
+// path doesn't have four levels, ErrInvalidCoinType if the coin type doesn't
+// match the vault, and PathNotFoundError if no address is derived at the path.
+func (v *Vault) AddressFromPathE(p string) (*AddressInfo, error) {
+	path, err := addresspath.FromString(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) != 4 {
+		return nil, ErrInvalidPath
+	}
+
+	if path.CoinType() != _H(v.CoinType) {
+		return nil, ErrInvalidCoinType
+	}
+
+	pathStr := path.String()
 	for _, addressInfo := range v.Addresses {
-		if addressInfo.Path == p {
-			return &addressInfo
+		if addressInfo.Path == pathStr {
+			return &addressInfo, nil
 		}
 	}
 
-	return nil
+	return nil, PathNotFoundError{Path: pathStr}
 }
 
 func (v *Vault) ImportBLSPrivateKey(password string, prv *bls.PrivateKey) error {
+	return v.ImportBLSPrivateKeys(password, []*bls.PrivateKey{prv})
+}
+
+// ImportBLSPrivateKeys imports a batch of BLS private keys.
+// The key store is decrypted and encrypted once for the whole batch, so the
+// password hasher runs twice regardless of the number of keys.
+// The import is atomic: if a key is duplicated in the batch or it already exists
+// in the vault, an AddressExistsError is returned and no key is imported.
+func (v *Vault) ImportBLSPrivateKeys(password string, prvs []*bls.PrivateKey) error {
 	if v.IsNeutered() {
 		return ErrNeutered
 	}
@@ -329,52 +948,73 @@ func (v *Vault) ImportBLSPrivateKey(password string, prv *bls.PrivateKey) error
 		return err
 	}
 
-	addressIndex := len(keyStore.ImportedKeys)
-	pub := prv.PublicKeyNative()
+	keys := v.keyFingerprints()
+	infos := make(map[string]AddressInfo, 2*len(prvs))
+	for i, prv := range prvs {
+		addressIndex := len(keyStore.ImportedKeys) + i
+		pub := prv.PublicKeyNative()
+
+		accAddr := pub.AccountAddress().String()
+		valAddr := pub.ValidatorAddress().String()
+		for _, addr := range []string{accAddr, valAddr} {
+			if v.Contains(addr) {
+				return AddressExistsError{Address: addr}
+			}
+		}
+		if err := keys.add(pub, accAddr); err != nil {
+			return err
+		}
 
-	accAddr := pub.AccountAddress()
-	if v.Contains(accAddr.String()) {
-		return ErrAddressExists
+		blsAccPathStr := addresspath.NewPath(
+			_H(PurposeImportPrivateKey),
+			_H(v.CoinType),
+			_H(crypto.AddressTypeBLSAccount),
+			_H(addressIndex)).String()
+
+		blsValidatorPathStr := addresspath.NewPath(
+			_H(PurposeImportPrivateKey),
+			_H(v.CoinType),
+			_H(crypto.AddressTypeValidator),
+			_H(addressIndex)).String()
+
+		infos[accAddr] = AddressInfo{
+			Address:   accAddr,
+			PublicKey: pub.String(),
+			Label:     "Imported BLS Account Address",
+			Origin:    OriginImported,
+			Path:      blsAccPathStr,
+			CreatedAt: timeNow(),
+		}
+
+		infos[valAddr] = AddressInfo{
+			Address:   valAddr,
+			PublicKey: pub.String(),
+			Label:     "Imported Validator Address",
+			Origin:    OriginImported,
+			Path:      blsValidatorPathStr,
+			CreatedAt: timeNow(),
+		}
 	}
 
-	valAddr := pub.ValidatorAddress()
-	if v.Contains(valAddr.String()) {
-		return ErrAddressExists
+	for _, prv := range prvs {
+		keyStore.ImportedKeys = append(keyStore.ImportedKeys, prv.String())
 	}
 
-	blsAccPathStr := addresspath.NewPath(
-		_H(PurposeImportPrivateKey),
-		_H(v.CoinType),
-		_H(crypto.AddressTypeBLSAccount),
-		_H(addressIndex)).String()
-
-	blsValidatorPathStr := addresspath.NewPath(
-		_H(PurposeImportPrivateKey),
-		_H(v.CoinType),
-		_H(crypto.AddressTypeValidator),
-		_H(addressIndex)).String()
-
-	v.Addresses[accAddr.String()] = AddressInfo{
-		Address:   accAddr.String(),
-		PublicKey: pub.String(),
-		Label:     "Imported BLS Account Address",
-		Path:      blsAccPathStr,
-	}
-
-	v.Addresses[valAddr.String()] = AddressInfo{
-		Address:   valAddr.String(),
-		PublicKey: pub.String(),
-		Label:     "Imported Validator Address",
-		Path:      blsValidatorPathStr,
-	}
-
-	keyStore.ImportedKeys = append(keyStore.ImportedKeys, prv.String())
-
 	err = v.encryptKeyStore(keyStore, password)
 	if err != nil {
 		return err
 	}
 
+	addrs := make([]string, 0, len(infos))
+	for addr, info := range infos {
+		v.Addresses[addr] = info
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+	for _, addr := range addrs {
+		v.notify(EventAddressAdded, addr)
+	}
+
 	return nil
 }
 
@@ -393,7 +1033,10 @@ func (v *Vault) ImportEd25519PrivateKey(password string, prv *ed25519.PrivateKey
 
 	accAddr := pub.AccountAddress()
 	if v.Contains(accAddr.String()) {
-		return ErrAddressExists
+		return AddressExistsError{Address: accAddr.String()}
+	}
+	if err := v.keyFingerprints().add(pub, accAddr.String()); err != nil {
+		return err
 	}
 
 	accPathStr := addresspath.NewPath(
@@ -406,7 +1049,9 @@ func (v *Vault) ImportEd25519PrivateKey(password string, prv *ed25519.PrivateKey
 		Address:   accAddr.String(),
 		PublicKey: pub.String(),
 		Label:     "Imported Ed25519 Account Address",
+		Origin:    OriginImported,
 		Path:      accPathStr,
+		CreatedAt: timeNow(),
 	}
 
 	keyStore.ImportedKeys = append(keyStore.ImportedKeys, prv.String())
@@ -415,87 +1060,162 @@ func (v *Vault) ImportEd25519PrivateKey(password string, prv *ed25519.PrivateKey
 	if err != nil {
 		return err
 	}
+	v.notify(EventAddressAdded, accAddr.String())
 
 	return nil
 }
 
 // PrivateKeys retrieves the private keys for the given addresses using the provided password.
+// Duplicated addresses are ignored, and the keys are returned in the order
+// of the first occurrence of each address.
 func (v *Vault) PrivateKeys(password string, addrs []string) ([]crypto.PrivateKey, error) {
+	return v.PrivateKeysCtx(context.Background(), password, addrs)
+}
+
+// PrivateKeysCtx is like PrivateKeys, but it returns the context error
+// as soon as the context is done, see decryptKeyStoreCtx.
+func (v *Vault) PrivateKeysCtx(ctx context.Context, password string, addrs []string,
+) ([]crypto.PrivateKey, error) {
+	uniqueAddrs := make([]string, 0, len(addrs))
+	seen := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		uniqueAddrs = append(uniqueAddrs, addr)
+	}
+
+	keyMap, err := v.privateKeysMap(ctx, password, uniqueAddrs)
+	v.audit(ctx, AuditPrivateKeys, uniqueAddrs, err)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]crypto.PrivateKey, len(uniqueAddrs))
+	for i, addr := range uniqueAddrs {
+		keys[i] = keyMap[addr]
+	}
+
+	return keys, nil
+}
+
+// PrivateKeysMap retrieves the private keys for the given addresses using the provided password.
+// It returns a map from address to its private key.
+// If any address doesn't exist in the vault, no key is returned.
+func (v *Vault) PrivateKeysMap(password string, addrs []string) (map[string]crypto.PrivateKey, error) {
+	ctx := context.Background()
+	keys, err := v.privateKeysMap(ctx, password, addrs)
+	v.audit(ctx, AuditPrivateKeys, addrs, err)
+
+	return keys, err
+}
+
+func (v *Vault) privateKeysMap(ctx context.Context, password string, addrs []string,
+) (map[string]crypto.PrivateKey, error) {
+	for _, addr := range addrs {
+		info := v.AddressInfo(addr)
+		if info != nil && info.IsWatchOnly {
+			return nil, ErrWatchOnly
+		}
+	}
+
+	if v.provider != nil {
+		return v.providerPrivateKeys(addrs)
+	}
+
 	if v.IsNeutered() {
 		return nil, ErrNeutered
 	}
 
+	for _, addr := range addrs {
+		if !v.Contains(addr) {
+			return nil, NewErrAddressNotFound(addr)
+		}
+	}
+
 	// Decrypt the key store once to avoid decrypting for each key.
-	keyStore, err := v.decryptKeyStore(password)
+	keyStore, err := v.decryptKeyStoreCtx(ctx, password)
 	if err != nil {
 		return nil, err
 	}
-	seed := bip39.NewSeed(keyStore.MasterNode.Mnemonic, "")
+	seedBytes, err := keyStore.MasterNode.seed()
+	if err != nil {
+		return nil, err
+	}
+	seed := newSecureBytes(seedBytes)
+	defer seed.Close()
 
-	keys := make([]crypto.PrivateKey, len(addrs))
-	for i, addr := range addrs {
-		info := v.AddressInfo(addr)
-		if info == nil {
-			return nil, NewErrAddressNotFound(addr)
+	keys := make(map[string]crypto.PrivateKey, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := keys[addr]; ok {
+			continue
 		}
 
-		hdPath, err := addresspath.FromString(info.Path)
+		info := v.Addresses[addr]
+		prv, err := v.privateKey(keyStore, seed.Bytes(), info)
 		if err != nil {
-			return nil, err
+			return nil, VaultError{Op: "private key", Address: addr, Path: info.Path, Err: err}
 		}
+		keys[addr] = prv
+	}
 
-		if hdPath.CoinType() != _H(v.CoinType) {
-			return nil, ErrInvalidCoinType
-		}
+	return keys, nil
+}
 
-		switch hdPath.Purpose() {
-		case _H(PurposeBLS12381):
-			prvKey, err := v.deriveBLSPrivateKey(seed, hdPath)
-			if err != nil {
-				return nil, err
-			}
-			keys[i] = prvKey
-		case _H(PurposeBIP44):
-			prvKey, err := v.deriveEd25519PrivateKey(seed, hdPath)
-			if err != nil {
-				return nil, err
-			}
-			keys[i] = prvKey
-		case _H(PurposeImportPrivateKey):
-			index := _N(hdPath.AddressIndex())
-			str := keyStore.ImportedKeys[index]
-
-			var prv crypto.PrivateKey
-			switch _N(hdPath.AddressType()) {
-			case uint32(crypto.AddressTypeValidator),
-				uint32(crypto.AddressTypeBLSAccount):
-				prv, err = bls.PrivateKeyFromString(str)
-				if err != nil {
-					return nil, err
-				}
-
-			case uint32(crypto.AddressTypeEd25519Account):
-				prv, err = ed25519.PrivateKeyFromString(str)
-				if err != nil {
-					return nil, err
-				}
-			}
+func (v *Vault) privateKey(keyStore *keyStore, seed []byte, info AddressInfo) (crypto.PrivateKey, error) {
+	hdPath, err := addresspath.FromString(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if hdPath.CoinType() != _H(v.CoinType) {
+		return nil, ErrInvalidCoinType
+	}
+
+	switch hdPath.Purpose() {
+	case _H(PurposeBLS12381):
+		return v.deriveBLSPrivateKey(seed, hdPath)
+	case _H(PurposeBIP44):
+		return v.deriveEd25519PrivateKey(seed, hdPath)
+	case _H(PurposeImportPrivateKey):
+		index := _N(hdPath.AddressIndex())
+		str := keyStore.ImportedKeys[index]
+
+		switch _N(hdPath.AddressType()) {
+		case uint32(crypto.AddressTypeValidator),
+			uint32(crypto.AddressTypeBLSAccount):
+			return bls.PrivateKeyFromString(str)
+
+		case uint32(crypto.AddressTypeEd25519Account):
+			return ed25519.PrivateKeyFromString(str)
 
-			keys[i] = prv
 		default:
-			return nil, ErrUnsupportedPurpose
+			return nil, ErrUnsupportedAddressType
 		}
+	default:
+		return nil, ErrUnsupportedPurpose
 	}
-
-	return keys, nil
 }
 
 func (v *Vault) NewValidatorAddress(label string) (*AddressInfo, error) {
+	label, err := validateLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
 	ext, err := blshdkeychain.NewKeyFromString(v.Purposes.PurposeBLS.XPubValidator)
 	if err != nil {
 		return nil, err
 	}
+	if err := v.checkKeyPath(ext.Path(), PurposeBLS12381); err != nil {
+		return nil, err
+	}
 	index := v.Purposes.PurposeBLS.NextValidatorIndex
+	path, err := v.addressPath(PurposeBLS12381, crypto.AddressTypeValidator, index)
+	if err != nil {
+		return nil, err
+	}
 	ext, err = ext.DerivePath([]uint32{index})
 	if err != nil {
 		return nil, err
@@ -511,20 +1231,34 @@ func (v *Vault) NewValidatorAddress(label string) (*AddressInfo, error) {
 		Address:   addr,
 		Label:     label,
 		PublicKey: blsPubKey.String(),
-		Path:      addresspath.NewPath(ext.Path()...).String(),
+		Path:      path.String(),
+		CreatedAt: timeNow(),
 	}
 	v.Addresses[addr] = info
 	v.Purposes.PurposeBLS.NextValidatorIndex++
+	v.notify(EventAddressAdded, addr)
 
 	return &info, nil
 }
 
 func (v *Vault) NewBLSAccountAddress(label string) (*AddressInfo, error) {
+	label, err := validateLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
 	ext, err := blshdkeychain.NewKeyFromString(v.Purposes.PurposeBLS.XPubAccount)
 	if err != nil {
 		return nil, err
 	}
+	if err := v.checkKeyPath(ext.Path(), PurposeBLS12381); err != nil {
+		return nil, err
+	}
 	index := v.Purposes.PurposeBLS.NextAccountIndex
+	path, err := v.addressPath(PurposeBLS12381, crypto.AddressTypeBLSAccount, index)
+	if err != nil {
+		return nil, err
+	}
 	ext, err = ext.DerivePath([]uint32{index})
 	if err != nil {
 		return nil, err
@@ -540,16 +1274,398 @@ func (v *Vault) NewBLSAccountAddress(label string) (*AddressInfo, error) {
 		Address:   addr,
 		Label:     label,
 		PublicKey: blsPubKey.String(),
-		Path:      addresspath.NewPath(ext.Path()...).String(),
+		Path:      path.String(),
+		CreatedAt: timeNow(),
 	}
 	v.Addresses[addr] = info
 	v.Purposes.PurposeBLS.NextAccountIndex++
+	v.notify(EventAddressAdded, addr)
 
 	return &info, nil
 }
 
+// DeriveAddressesRange derives `count` consecutive addresses for the given
+// purpose and address type, starting from the next unused index.
+// The extended public key is parsed once and reused for all derivations.
+// The addresses are added to the vault only if all derivations succeed.
+// The label of each address is `labelPrefix` followed by its index, normalized
+// like the labels of the other derivation methods, and ErrLabelTooLong is
+// returned if one of them is longer than MaxLabelLength bytes.
+//
+// Only the BLS purpose is supported, since Ed25519 addresses need the
+// master private key for derivation.
+func (v *Vault) DeriveAddressesRange(purpose uint32, addressType crypto.AddressType,
+	count int, labelPrefix string,
+) ([]AddressInfo, error) {
+	if count < 0 {
+		return nil, ErrInvalidCount
+	}
+
+	ext, nextIndex, err := v.blsExtendedKey(purpose, addressType)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]AddressInfo, 0, count)
+	for i := 0; i < count; i++ {
+		index := *nextIndex + uint32(i)
+		info, err := deriveBLSAddressInfo(ext, addressType, index)
+		if err != nil {
+			return nil, err
+		}
+		info.Label, err = validateLabel(fmt.Sprintf("%s%d", labelPrefix, index))
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, *info)
+	}
+
+	for _, info := range infos {
+		v.Addresses[info.Address] = info
+	}
+	*nextIndex += uint32(count)
+	for _, info := range infos {
+		v.notify(EventAddressAdded, info.Address)
+	}
+
+	return infos, nil
+}
+
+// NeuteredDeriveNext derives the next address for the given purpose and
+// address type from the stored extended public key, adds it to the vault and
+// advances the next index. It doesn't need the seed, so a neutered vault can
+// use it to keep up with the addresses derived by the full vault.
+//
+// Only the BLS purpose is supported. Ed25519 addresses are derived with
+// hardened indexes and imported keys have no extended public key, so both
+// return ErrUnsupportedPurpose.
+func (v *Vault) NeuteredDeriveNext(purpose uint32, addressType crypto.AddressType,
+	label string,
+) (*AddressInfo, error) {
+	label, err := validateLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	ext, nextIndex, err := v.blsExtendedKey(purpose, addressType)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := deriveBLSAddressInfo(ext, addressType, *nextIndex)
+	if err != nil {
+		return nil, err
+	}
+	info.Label = label
+
+	v.Addresses[info.Address] = *info
+	*nextIndex++
+	v.notify(EventAddressAdded, info.Address)
+
+	return info, nil
+}
+
+// PublicKeysAtPaths derives the public keys at the given paths from the stored
+// extended public keys, in the same order as the paths. It needs no secret, so
+// a neutered vault can use it, e.g. to set up a watch-only server.
+// It is the public counterpart of SignAtPath, and the paths are checked the
+// same way. Ed25519 keys are derived with hardened indexes, which need the
+// private key, so like the import purpose, the BIP-44 purpose returns
+// ErrUnsupportedPurpose.
+func (v *Vault) PublicKeysAtPaths(paths []string) ([]crypto.PublicKey, error) {
+	exts := make(map[crypto.AddressType]*blshdkeychain.ExtendedKey)
+	pubs := make([]crypto.PublicKey, 0, len(paths))
+	for _, pathStr := range paths {
+		pub, err := v.publicKeyAtPath(pathStr, exts)
+		if err != nil {
+			return nil, VaultError{Op: "public key at path", Path: pathStr, Err: err}
+		}
+		pubs = append(pubs, pub)
+	}
+
+	return pubs, nil
+}
+
+// publicKeyAtPath derives the BLS public key at the path. The parsed extended
+// public keys are kept in exts, so each is parsed once.
+func (v *Vault) publicKeyAtPath(pathStr string, exts map[crypto.AddressType]*blshdkeychain.ExtendedKey,
+) (*bls.PublicKey, error) {
+	path, err := v.parseDerivablePath(pathStr)
+	if err != nil {
+		return nil, err
+	}
+	if _N(path.Purpose()) != PurposeBLS12381 {
+		return nil, ErrUnsupportedPurpose
+	}
+
+	addressType := crypto.AddressType(_N(path.AddressType()))
+	ext, ok := exts[addressType]
+	if !ok {
+		ext, _, err = v.blsExtendedKey(PurposeBLS12381, addressType)
+		if err != nil {
+			return nil, err
+		}
+		exts[addressType] = ext
+	}
+
+	childExt, err := ext.Derive(path.AddressIndex())
+	if err != nil {
+		return nil, err
+	}
+
+	return bls.PublicKeyFromBytes(childExt.RawPublicKey())
+}
+
+// ScanAddresses discovers the used addresses for the given purpose and address
+// type, based on the BIP-44 account discovery algorithm.
+// It derives addresses sequentially from the next unused index and checks them
+// using the `used` callback. The scan stops after `gapLimit` consecutive unused
+// addresses. All addresses up to the last used one are added to the vault and returned.
+//
+// Only the BLS purpose is supported, since Ed25519 addresses need the
+// master private key for derivation.
+func (v *Vault) ScanAddresses(purpose uint32, addressType crypto.AddressType,
+	gapLimit int, used func(addr string) bool,
+) ([]AddressInfo, error) {
+	return v.ScanAddressesWithProgress(context.Background(), purpose, addressType, gapLimit, used, nil)
+}
+
+// ScanAddressesWithProgress is like ScanAddresses, but it reports the number of
+// checked addresses and can be cancelled through the context.
+// The total is the minimum number of addresses to check, and it grows
+// whenever a used address is found.
+// If the context is cancelled, no address is added to the vault.
+func (v *Vault) ScanAddressesWithProgress(ctx context.Context,
+	purpose uint32, addressType crypto.AddressType, gapLimit int, used func(addr string) bool, progress ProgressFunc,
+) ([]AddressInfo, error) {
+	if gapLimit <= 0 {
+		return nil, ErrInvalidCount
+	}
+
+	ext, nextIndex, err := v.blsExtendedKey(purpose, addressType)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]AddressInfo, 0)
+	pending := make([]AddressInfo, 0, gapLimit)
+	for index := *nextIndex; len(pending) < gapLimit; index++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		info, err := deriveBLSAddressInfo(ext, addressType, index)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, *info)
+		if used(info.Address) {
+			found = append(found, pending...)
+			pending = pending[:0]
+		}
+
+		progress.report(len(found)+len(pending), len(found)+gapLimit)
+	}
+
+	for _, info := range found {
+		v.Addresses[info.Address] = info
+	}
+	*nextIndex += uint32(len(found))
+	for _, info := range found {
+		v.notify(EventAddressAdded, info.Address)
+	}
+
+	return found, nil
+}
+
+// DeriveAtPath derives the address at the given path and adds it to the vault,
+// without deriving the addresses before it.
+// The next index of the address type is advanced past the path index, if it is higher.
+//
+// Only the BLS purpose is supported, since Ed25519 addresses need the
+// master private key for derivation.
+// A BIP-44 style path with a chain level, like m/12381'/21888'/2'/0/5, is
+// accepted for the external chain, and it returns ErrUnsupportedChain for other chains.
+func (v *Vault) DeriveAtPath(path, label string) (*AddressInfo, error) {
+	label, err := validateLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	addrPath, err := addresspath.FromString(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+	}
+
+	addrPath, err = withoutChain(addrPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrPath) != 4 {
+		return nil, ErrInvalidPath
+	}
+
+	if addrPath.Purpose() != _H(PurposeBLS12381) {
+		return nil, ErrUnsupportedPurpose
+	}
+
+	if err := addrPath.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+	}
+
+	if err := v.checkKeyPath(addrPath, PurposeBLS12381); err != nil {
+		return nil, err
+	}
+
+	addressType := crypto.AddressType(_N(addrPath.AddressType()))
+	ext, nextIndex, err := v.blsExtendedKey(PurposeBLS12381, addressType)
+	if err != nil {
+		return nil, err
+	}
+
+	index := addrPath.AddressIndex()
+	info, err := deriveBLSAddressInfo(ext, addressType, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Contains(info.Address) {
+		return nil, AddressExistsError{Address: info.Address}
+	}
+
+	info.Label = label
+	v.Addresses[info.Address] = *info
+	if index+1 > *nextIndex {
+		*nextIndex = index + 1
+	}
+	v.notify(EventAddressAdded, info.Address)
+
+	return info, nil
+}
+
+// NewChangeAddress always returns ErrUnsupportedChain, since Pactus has no
+// change addresses. Use the receive addresses instead, like NewBLSAccountAddress.
+// It exists to reject the change chain explicitly for code ported from BIP-44 wallets.
+func (*Vault) NewChangeAddress(_ crypto.AddressType, _ string) (*AddressInfo, error) {
+	return nil, ErrUnsupportedChain
+}
+
+// withoutChain removes the chain level from a five-level path.
+// It returns ErrUnsupportedChain if the chain is not the external chain.
+// Other paths are returned unchanged.
+func withoutChain(addrPath addresspath.Path) (addresspath.Path, error) {
+	if len(addrPath) != 5 {
+		return addrPath, nil
+	}
+
+	if addrPath[3] != ChainExternal {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChain, addrPath[3])
+	}
+
+	return addresspath.Path{addrPath[0], addrPath[1], addrPath[2], addrPath[4]}, nil
+}
+
+// blsExtendedKey returns the extended public key and the next index counter
+// for the given purpose and address type.
+func (v *Vault) blsExtendedKey(purpose uint32, addressType crypto.AddressType,
+) (*blshdkeychain.ExtendedKey, *uint32, error) {
+	if purpose != PurposeBLS12381 {
+		return nil, nil, ErrUnsupportedPurpose
+	}
+
+	var xPub string
+	var nextIndex *uint32
+	switch addressType {
+	case crypto.AddressTypeValidator:
+		xPub = v.Purposes.PurposeBLS.XPubValidator
+		nextIndex = &v.Purposes.PurposeBLS.NextValidatorIndex
+	case crypto.AddressTypeBLSAccount:
+		xPub = v.Purposes.PurposeBLS.XPubAccount
+		nextIndex = &v.Purposes.PurposeBLS.NextAccountIndex
+	default:
+		return nil, nil, ErrUnsupportedAddressType
+	}
+
+	ext, err := blshdkeychain.NewKeyFromString(xPub)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := v.checkKeyPath(ext.Path(), purpose); err != nil {
+		return nil, nil, err
+	}
+
+	return ext, nextIndex, nil
+}
+
+// addressPath builds the address path for the purpose and the address type in
+// the coin type of the vault.
+func (v *Vault) addressPath(purpose uint32, addressType crypto.AddressType, index uint32,
+) (addresspath.Path, error) {
+	return addresspath.NewBuilder().
+		Purpose(purpose).
+		CoinType(v.CoinType).
+		AddressType(uint32(addressType)).
+		AddressIndex(index).
+		Build()
+}
+
+// checkKeyPath checks that the purpose and the coin type of the key path
+// match the vault.
+func (v *Vault) checkKeyPath(path []uint32, purpose uint32) error {
+	if len(path) < 2 || path[0] != _H(purpose) {
+		return ErrInvalidPath
+	}
+
+	if path[1] != _H(v.CoinType) {
+		return CoinTypeMismatchError{
+			Expected: v.CoinType,
+			Got:      _N(path[1]),
+		}
+	}
+
+	return nil
+}
+
+// deriveBLSAddressInfo derives the child of the extended public key at the
+// given index and returns its address info without a label.
+func deriveBLSAddressInfo(ext *blshdkeychain.ExtendedKey, addressType crypto.AddressType,
+	index uint32,
+) (*AddressInfo, error) {
+	childExt, err := ext.Derive(index)
+	if err != nil {
+		return nil, err
+	}
+
+	blsPubKey, err := bls.PublicKeyFromBytes(childExt.RawPublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	var addr string
+	if addressType == crypto.AddressTypeValidator {
+		addr = blsPubKey.ValidatorAddress().String()
+	} else {
+		addr = blsPubKey.AccountAddress().String()
+	}
+
+	return &AddressInfo{
+		Address:   addr,
+		PublicKey: blsPubKey.String(),
+		Path:      addresspath.NewPath(childExt.Path()...).String(),
+		CreatedAt: timeNow(),
+	}, nil
+}
+
 func (v *Vault) NewEd25519AccountAddress(label, password string) (*AddressInfo, error) {
-	seed, err := v.MnemonicSeed(password)
+	label, err := validateLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := v.mnemonicSeed(password)
 	if err != nil {
 		return nil, err
 	}
@@ -560,12 +1676,11 @@ func (v *Vault) NewEd25519AccountAddress(label, password string) (*AddressInfo,
 	}
 
 	index := v.Purposes.PurposeBIP44.NextEd25519Index
-	ext, err := masterKey.DerivePath([]uint32{
-		_H(PurposeBIP44),
-		_H(v.CoinType),
-		_H(crypto.AddressTypeEd25519Account),
-		_H(index),
-	})
+	path, err := v.addressPath(PurposeBIP44, crypto.AddressTypeEd25519Account, index)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := masterKey.DerivePath(path)
 	if err != nil {
 		return nil, err
 	}
@@ -580,14 +1695,73 @@ func (v *Vault) NewEd25519AccountAddress(label, password string) (*AddressInfo,
 		Address:   addr,
 		Label:     label,
 		PublicKey: ed25519PubKey.String(),
-		Path:      addresspath.NewPath(ext.Path()...).String(),
+		Path:      path.String(),
+		CreatedAt: timeNow(),
 	}
 	v.Addresses[addr] = info
 	v.Purposes.PurposeBIP44.NextEd25519Index++
+	v.notify(EventAddressAdded, addr)
 
 	return &info, nil
 }
 
+// NewEd25519ValidatorAddress always returns ErrUnsupportedValidatorScheme.
+// Validators in Pactus must use BLS keys, since the consensus relies on
+// BLS signature aggregation. Use NewValidatorAddress instead.
+func (*Vault) NewEd25519ValidatorAddress(_, _ string) (*AddressInfo, error) {
+	return nil, ErrUnsupportedValidatorScheme
+}
+
+// NextIndex returns the index of the next address that will be derived for
+// the given purpose and address type.
+// For imported keys and watch-only addresses, the index is shared between
+// all address types. It returns zero for unsupported purposes or address types.
+func (v *Vault) NextIndex(purpose uint32, addressType crypto.AddressType) uint32 {
+	switch purpose {
+	case PurposeBLS12381:
+		switch addressType {
+		case crypto.AddressTypeValidator:
+			return v.Purposes.PurposeBLS.NextValidatorIndex
+		case crypto.AddressTypeBLSAccount:
+			return v.Purposes.PurposeBLS.NextAccountIndex
+		default:
+			return 0
+		}
+
+	case PurposeBIP44:
+		if addressType == crypto.AddressTypeEd25519Account {
+			return v.Purposes.PurposeBIP44.NextEd25519Index
+		}
+
+		return 0
+
+	case PurposeImportPrivateKey, PurposeWatchOnly:
+		return v.nextPurposeIndex(purpose)
+
+	default:
+		return 0
+	}
+}
+
+// nextPurposeIndex returns the next address index for a purpose, like imported
+// keys, whose index is not stored in the vault.
+func (v *Vault) nextPurposeIndex(purpose uint32) uint32 {
+	nextIndex := uint32(0)
+	for _, info := range v.Addresses {
+		addrPath, err := addresspath.FromString(info.Path)
+		if err != nil || addrPath.Purpose() != _H(purpose) {
+			continue
+		}
+
+		index := _N(addrPath.AddressIndex()) + 1
+		if index > nextIndex {
+			nextIndex = index
+		}
+	}
+
+	return nextIndex
+}
+
 // AddressInfo like it can return bls.PublicKey instead of string.
 func (v *Vault) AddressInfo(addr string) *AddressInfo {
 	info, ok := v.Addresses[addr]
@@ -602,8 +1776,93 @@ func (v *Vault) Contains(addr string) bool {
 	return v.AddressInfo(addr) != nil
 }
 
+// IsImported checks if the address belongs to an imported private key.
+// Imported keys can't be recovered from the mnemonic.
+func (v *Vault) IsImported(addr string) bool {
+	info := v.AddressInfo(addr)
+
+	return info != nil && info.Origin == OriginImported
+}
+
+// ContainsPublicKey checks if the vault has an address for the public key.
+// For BLS public keys, both the account and the validator addresses are checked.
+func (v *Vault) ContainsPublicKey(pub crypto.PublicKey) bool {
+	switch pub := pub.(type) {
+	case *bls.PublicKey:
+		return v.Contains(pub.AccountAddress().String()) ||
+			v.Contains(pub.ValidatorAddress().String())
+
+	case *ed25519.PublicKey:
+		return v.Contains(pub.AccountAddress().String())
+
+	default:
+		return false
+	}
+}
+
+// PublicKey returns the public key of the address, parsed based on the address type:
+// a BLS public key for the validator and BLS account addresses, and an Ed25519
+// public key for the Ed25519 account addresses.
+// The stored public key is parsed on first access and cached.
+// It returns an error if the stored public key can't be parsed.
+func (v *Vault) PublicKey(addr string) (crypto.PublicKey, error) {
+	info, ok := v.Addresses[addr]
+	if !ok {
+		return nil, NewErrAddressNotFound(addr)
+	}
+
+	addressType, err := ValidateAddressString(info.Address)
+	if err != nil {
+		return nil, VaultError{Op: "public key", Address: addr, Path: info.Path, Err: err}
+	}
+
+	pub, err := v.parsePublicKey(info.PublicKey, addressType)
+	if err != nil {
+		return nil, VaultError{Op: "public key", Address: addr, Path: info.Path, Err: err}
+	}
+
+	return pub, nil
+}
+
+// publicKeyFromString parses the public key for the address type.
+func publicKeyFromString(pubStr string, addressType crypto.AddressType) (crypto.PublicKey, error) {
+	switch addressType {
+	case crypto.AddressTypeValidator, crypto.AddressTypeBLSAccount:
+		return bls.PublicKeyFromString(pubStr)
+
+	case crypto.AddressTypeEd25519Account:
+		return ed25519.PublicKeyFromString(pubStr)
+
+	default:
+		return nil, ErrUnsupportedAddressType
+	}
+}
+
+// ContainsPath checks if the vault has an address with the derivation path.
+func (v *Vault) ContainsPath(path string) bool {
+	return v.AddressFromPath(path) != nil
+}
+
+// VerifyPassword checks the password without decrypting any secret.
+// It returns encrypter.ErrInvalidPassword if the password is not correct.
+// For a non-encrypted vault, only the empty password is valid.
+func (v *Vault) VerifyPassword(password string) error {
+	if v.IsNeutered() {
+		return ErrNeutered
+	}
+
+	return v.Encrypter.VerifyPassword(v.KeyStore, password)
+}
+
 func (v *Vault) Mnemonic(password string) (string, error) {
-	keyStore, err := v.decryptKeyStore(password)
+	return v.MnemonicCtx(context.Background(), password)
+}
+
+// MnemonicCtx is like Mnemonic, but it returns the context error
+// as soon as the context is done, see decryptKeyStoreCtx.
+func (v *Vault) MnemonicCtx(ctx context.Context, password string) (string, error) {
+	keyStore, err := v.decryptKeyStoreCtx(ctx, password)
+	v.audit(ctx, AuditMnemonic, nil, err)
 	if err != nil {
 		return "", err
 	}
@@ -611,28 +1870,141 @@ func (v *Vault) Mnemonic(password string) (string, error) {
 	return keyStore.MasterNode.Mnemonic, nil
 }
 
+// HasPassphrase returns true if the vault was created with a BIP39 passphrase.
+func (v *Vault) HasPassphrase(password string) (bool, error) {
+	keyStore, err := v.decryptKeyStore(password)
+	if err != nil {
+		return false, err
+	}
+
+	return keyStore.MasterNode.Seed != "", nil
+}
+
+// MnemonicSeed returns the BIP39 seed of the vault.
+// The access is recorded in the audit events as AuditMnemonic.
 func (v *Vault) MnemonicSeed(password string) ([]byte, error) {
-	mnemonic, err := v.Mnemonic(password)
+	seed, err := v.mnemonicSeed(password)
+	v.audit(context.Background(), AuditMnemonic, nil, err)
+
+	return seed, err
+}
+
+// mnemonicSeed returns the BIP39 seed of the vault, without recording an audit event.
+func (v *Vault) mnemonicSeed(password string) ([]byte, error) {
+	keyStore, err := v.decryptKeyStore(password)
 	if err != nil {
 		return nil, err
 	}
-	seed := bip39.NewSeed(mnemonic, "")
 
-	return seed, nil
+	return keyStore.MasterNode.seed()
+}
+
+// XPrvAccount returns the account-level extended private key of the given purpose.
+// For the BLS purpose, it is the extended private key of the BLS account addresses,
+// and for the BIP44 purpose, it is the extended private key of the Ed25519 account addresses.
+// The seed and the extended private keys are zeroed after serialization.
+func (v *Vault) XPrvAccount(password string, purpose uint32) (string, error) {
+	if purpose != PurposeBLS12381 && purpose != PurposeBIP44 {
+		return "", ErrUnsupportedPurpose
+	}
+
+	keyStore, err := v.decryptKeyStore(password)
+	v.audit(context.Background(), AuditExportXPrv, nil, err)
+	if err != nil {
+		return "", err
+	}
+
+	seedBytes, err := keyStore.MasterNode.seed()
+	if err != nil {
+		return "", err
+	}
+	seed := newSecureBytes(seedBytes)
+	defer seed.Close()
+
+	switch purpose {
+	case PurposeBLS12381:
+		masterKey, err := blshdkeychain.NewMaster(seed.Bytes(), false)
+		if err != nil {
+			return "", err
+		}
+		defer masterKey.Clear()
+
+		ext, err := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381),
+			_H(v.CoinType),
+			_H(crypto.AddressTypeBLSAccount),
+		})
+		if err != nil {
+			return "", err
+		}
+		defer ext.Clear()
+
+		return ext.String(), nil
+
+	case PurposeBIP44:
+		masterKey, err := ed25519hdkeychain.NewMaster(seed.Bytes())
+		if err != nil {
+			return "", err
+		}
+		defer masterKey.Clear()
+
+		ext, err := masterKey.DerivePath([]uint32{
+			_H(PurposeBIP44),
+			_H(v.CoinType),
+			_H(crypto.AddressTypeEd25519Account),
+		})
+		if err != nil {
+			return "", err
+		}
+		defer ext.Clear()
+
+		return ext.String(), nil
+
+	default:
+		return "", ErrUnsupportedPurpose
+	}
+}
+
+// seed returns the BIP39 seed of the master node.
+// If no passphrase was used, the seed is derived from the mnemonic.
+func (n *masterNode) seed() ([]byte, error) {
+	if n.Seed != "" {
+		return hex.DecodeString(n.Seed)
+	}
+
+	return newSeed(n.Mnemonic, ""), nil
 }
 
 func (v *Vault) decryptKeyStore(password string) (*keyStore, error) {
+	if v.provider != nil {
+		return nil, ErrNoLocalSeed
+	}
+
 	if v.IsNeutered() {
 		return nil, ErrNeutered
 	}
 
-	keyStoreData, err := v.Encrypter.Decrypt(v.KeyStore, password)
+	var keyStoreData string
+	var err error
+	if key := v.unlockedKey(password); key != nil {
+		cachedKey := newSecureBytes(key)
+		keyStoreData, err = v.Encrypter.DecryptWithKey(v.KeyStore, cachedKey.Bytes())
+		cachedKey.Close()
+	} else {
+		if v.UnlockPolicy != nil && v.IsEncrypted() {
+			return nil, ErrDelayedUnlock
+		}
+		keyStoreData, err = v.Encrypter.Decrypt(v.KeyStore, password)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	data := newSecureBytes([]byte(keyStoreData))
+	defer data.Close()
+
 	keyStore := new(keyStore)
-	err = json.Unmarshal([]byte(keyStoreData), keyStore)
+	err = json.Unmarshal(data.Bytes(), keyStore)
 	if err != nil {
 		return nil, err
 	}
@@ -640,21 +2012,76 @@ func (v *Vault) decryptKeyStore(password string) (*keyStore, error) {
 	return keyStore, nil
 }
 
+// decryptKeyStoreCtx is like decryptKeyStore, but it returns the context error
+// as soon as the context is done.
+// The password hasher can't be interrupted, so it keeps running in the background
+// on a snapshot of the key store and its result is discarded.
+func (v *Vault) decryptKeyStoreCtx(ctx context.Context, password string) (*keyStore, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if ctx.Done() == nil {
+		// The context can't be cancelled.
+		return v.decryptKeyStore(password)
+	}
+
+	type result struct {
+		keyStore *keyStore
+		err      error
+	}
+
+	snapshot := &Vault{
+		Type:         v.Type,
+		Encrypter:    v.Encrypter.Clone(),
+		KeyStore:     v.KeyStore,
+		UnlockPolicy: v.UnlockPolicy,
+		session:      v.session,
+		provider:     v.provider,
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		keyStore, err := snapshot.decryptKeyStore(password)
+		resultCh <- result{keyStore: keyStore, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-resultCh:
+		return res.keyStore, res.err
+	}
+}
+
 func (v *Vault) encryptKeyStore(keyStore *keyStore, password string) error {
 	keyStoreData, err := json.Marshal(keyStore)
 	if err != nil {
 		return err
 	}
+	data := newSecureBytes(keyStoreData)
+	defer data.Close()
 
-	keyStoreEnc, err := v.Encrypter.Encrypt(string(keyStoreData), password)
+	keyStoreEnc, err := v.Encrypter.Encrypt(string(data.Bytes()), password)
 	if err != nil {
 		return err
 	}
 	v.KeyStore = keyStoreEnc
 
+	// The cached key can't decrypt the new key store, since the salt is changed.
+	v.Lock()
+
 	return nil
 }
 
+// unlockedKey returns the cached key if the password is empty and the vault is unlocked.
+func (v *Vault) unlockedKey(password string) []byte {
+	if password != "" || v.session == nil || !v.IsEncrypted() {
+		return nil
+	}
+
+	return v.session.cachedKey()
+}
+
 func (*Vault) deriveBLSPrivateKey(mnemonicSeed []byte, path []uint32) (*bls.PrivateKey, error) {
 	masterKey, err := blshdkeychain.NewMaster(mnemonicSeed, false)
 	if err != nil {
@@ -1,14 +1,21 @@
 package vault
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pactus-project/pactus/crypto"
 	"github.com/pactus-project/pactus/crypto/bls"
 	"github.com/pactus-project/pactus/crypto/bls/hdkeychain"
 	"github.com/pactus-project/pactus/crypto/ed25519"
+	ed25519hdkeychain "github.com/pactus-project/pactus/crypto/ed25519/hdkeychain"
+	"github.com/pactus-project/pactus/genesis"
 	"github.com/pactus-project/pactus/util/testsuite"
 	"github.com/pactus-project/pactus/wallet/addresspath"
 	"github.com/pactus-project/pactus/wallet/encrypter"
@@ -86,6 +93,44 @@ func TestAddressCount(t *testing.T) {
 	assert.Equal(t, 6, neutered.AddressCount())
 }
 
+func TestAddressCountByType(t *testing.T) {
+	td := setup(t)
+
+	assert.Equal(t, map[crypto.AddressType]int{
+		crypto.AddressTypeValidator:      2,
+		crypto.AddressTypeBLSAccount:     2,
+		crypto.AddressTypeEd25519Account: 2,
+	}, td.vault.AddressCountByType())
+
+	t.Run("Count after adding and removing", func(t *testing.T) {
+		info, err := td.vault.NewValidatorAddress("new-validator")
+		require.NoError(t, err)
+		assert.Equal(t, 3, td.vault.AddressCountByType()[crypto.AddressTypeValidator])
+
+		require.NoError(t, td.vault.RemoveAddress(info.Address, "", false))
+		assert.Equal(t, 2, td.vault.AddressCountByType()[crypto.AddressTypeValidator])
+	})
+}
+
+func TestAddressCountByOrigin(t *testing.T) {
+	td := setup(t)
+
+	assert.Equal(t, map[Origin]int{
+		OriginHDDerived: 3,
+		OriginImported:  3,
+	}, td.vault.AddressCountByOrigin())
+
+	t.Run("Count after adding and removing", func(t *testing.T) {
+		pub, _ := td.RandBLSKeyPair()
+		info, err := td.vault.ImportWatchOnlyPublicKey(pub, "watch-only")
+		require.NoError(t, err)
+		assert.Equal(t, 2, td.vault.AddressCountByOrigin()[OriginWatchOnly])
+
+		require.NoError(t, td.vault.RemoveAddress(info.Address, "", false))
+		assert.Equal(t, 1, td.vault.AddressCountByOrigin()[OriginWatchOnly])
+	})
+}
+
 func TestContains(t *testing.T) {
 	td := setup(t)
 
@@ -102,6 +147,146 @@ func TestContains(t *testing.T) {
 	})
 }
 
+func TestIsImported(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Imported addresses", func(t *testing.T) {
+		addrs := []string{
+			td.importedBLSPrv.PublicKeyNative().AccountAddress().String(),
+			td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String(),
+			td.importedEd25519Prv.PublicKeyNative().AccountAddress().String(),
+		}
+		for _, addr := range addrs {
+			assert.True(t, td.vault.IsImported(addr))
+			assert.Equal(t, OriginImported, td.vault.AddressInfo(addr).Origin)
+		}
+	})
+
+	t.Run("HD derived addresses", func(t *testing.T) {
+		for _, info := range td.vault.AddressInfos() {
+			path, _ := addresspath.FromString(info.Path)
+			if path.Purpose() == _H(PurposeImportPrivateKey) {
+				continue
+			}
+			assert.False(t, td.vault.IsImported(info.Address))
+			assert.Equal(t, OriginHDDerived, info.Origin)
+		}
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		assert.False(t, td.vault.IsImported(td.RandAccAddress().String()))
+	})
+}
+
+func TestOriginString(t *testing.T) {
+	assert.Equal(t, "hd-derived", OriginHDDerived.String())
+	assert.Equal(t, "imported", OriginImported.String())
+	assert.Equal(t, "watch-only", OriginWatchOnly.String())
+	assert.Equal(t, "unknown origin: 3", Origin(3).String())
+}
+
+func TestContainsPublicKey(t *testing.T) {
+	td := setup(t)
+
+	t.Run("BLS validator address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		pub, err := bls.PublicKeyFromString(info.PublicKey)
+		require.NoError(t, err)
+
+		assert.True(t, td.vault.ContainsPublicKey(pub))
+	})
+
+	t.Run("BLS account address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("bls-account-address")[0]
+		pub, err := bls.PublicKeyFromString(info.PublicKey)
+		require.NoError(t, err)
+
+		assert.True(t, td.vault.ContainsPublicKey(pub))
+	})
+
+	t.Run("Ed25519 account address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("ed25519-account-address")[0]
+		pub, err := ed25519.PublicKeyFromString(info.PublicKey)
+		require.NoError(t, err)
+
+		assert.True(t, td.vault.ContainsPublicKey(pub))
+	})
+
+	t.Run("Unknown public keys", func(t *testing.T) {
+		blsPub, _ := td.RandBLSKeyPair()
+		ed25519Pub, _ := td.RandEd25519KeyPair()
+
+		assert.False(t, td.vault.ContainsPublicKey(blsPub))
+		assert.False(t, td.vault.ContainsPublicKey(ed25519Pub))
+		assert.False(t, td.vault.ContainsPublicKey(nil))
+	})
+}
+
+func TestPublicKey(t *testing.T) {
+	td := setup(t)
+
+	t.Run("BLS validator address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		pub, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+
+		blsPub, ok := pub.(*bls.PublicKey)
+		require.True(t, ok)
+		assert.Equal(t, info.Address, blsPub.ValidatorAddress().String())
+	})
+
+	t.Run("BLS account address", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("bls-account-address")[0]
+		pub, err := td.vault.PublicKey(info.Address)
+		require.NoError(t, err)
+
+		blsPub, ok := pub.(*bls.PublicKey)
+		require.True(t, ok)
+		assert.Equal(t, info.Address, blsPub.AccountAddress().String())
+	})
+
+	t.Run("Ed25519 account address", func(t *testing.T) {
+		addr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+		pub, err := td.vault.PublicKey(addr)
+		require.NoError(t, err)
+
+		edPub, ok := pub.(*ed25519.PublicKey)
+		require.True(t, ok)
+		assert.True(t, td.importedEd25519Prv.PublicKeyNative().EqualsTo(edPub))
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandAccAddress().String()
+		_, err := td.vault.PublicKey(addr)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Corrupt public key", func(t *testing.T) {
+		info := td.vault.AddressesByLabel("ed25519-account-address")[0]
+		info.PublicKey = "invalid-public-key"
+		td.vault.Addresses[info.Address] = info
+
+		_, err := td.vault.PublicKey(info.Address)
+		assert.Error(t, err)
+	})
+}
+
+func TestContainsPath(t *testing.T) {
+	td := setup(t)
+
+	for _, info := range td.vault.AddressInfos() {
+		assert.True(t, td.vault.ContainsPath(info.Path))
+		assert.Equal(t, info.Address, td.vault.AddressFromPath(info.Path).Address)
+	}
+
+	assert.True(t, td.vault.ContainsPath("m/12381'/21888'/1'/0"))
+	assert.True(t, td.vault.ContainsPath("m/12381'/21888'/2'/0"))
+	assert.True(t, td.vault.ContainsPath("m/44'/21888'/3'/0'"))
+	assert.False(t, td.vault.ContainsPath("m/12381'/21888'/1'/1"))
+	assert.False(t, td.vault.ContainsPath("m/12381'/21777'/1'/0"))
+	assert.False(t, td.vault.ContainsPath("invalid-path"))
+}
+
 func TestSortAddressInfo(t *testing.T) {
 	td := setup(t)
 
@@ -118,6 +303,82 @@ func TestSortAddressInfo(t *testing.T) {
 	assert.Equal(t, "m/65535'/21888'/3'/1'", infos[5].Path)
 }
 
+func TestForEachAddress(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Same order as AddressInfos", func(t *testing.T) {
+		infos := make([]AddressInfo, 0)
+		td.vault.ForEachAddress(func(info AddressInfo) bool {
+			infos = append(infos, info)
+
+			return true
+		})
+
+		assert.Equal(t, td.vault.AddressInfos(), infos)
+	})
+
+	t.Run("Early termination", func(t *testing.T) {
+		count := 0
+		td.vault.ForEachAddress(func(info AddressInfo) bool {
+			count++
+
+			return info.Path != "m/12381'/21888'/1'/0"
+		})
+
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Corrupted path comes last", func(t *testing.T) {
+		vlt := td.vault.Clone()
+		info := vlt.AddressInfos()[0]
+		info.Path = "m"
+		vlt.Addresses[info.Address] = info
+
+		var last AddressInfo
+		vlt.ForEachAddress(func(info AddressInfo) bool {
+			last = info
+
+			return true
+		})
+
+		assert.Equal(t, info, last)
+		assert.Len(t, vlt.AllAccountAddresses(), len(td.vault.AllAccountAddresses())-1)
+	})
+}
+
+func BenchmarkFirstAccountAddress(b *testing.B) {
+	mnemonic, _ := GenerateMnemonic(128)
+	vlt, _ := CreateVaultFromMnemonic(mnemonic, 21888)
+	_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeValidator, 500, "")
+	_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 500, "")
+
+	isAccount := func(info AddressInfo) bool {
+		path, _ := vlt.parsePath(info.Path)
+
+		return path.AddressType() == _H(crypto.AddressTypeBLSAccount)
+	}
+
+	b.Run("Filter AddressInfos", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, info := range vlt.AddressInfos() {
+				if isAccount(info) {
+					break
+				}
+			}
+		}
+	})
+
+	b.Run("ForEachAddress", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			vlt.ForEachAddress(func(info AddressInfo) bool {
+				return !isAccount(info)
+			})
+		}
+	})
+}
+
 func TestAllAccountAddresses(t *testing.T) {
 	td := setup(t)
 
@@ -185,6 +446,39 @@ func TestAddressFromPath(t *testing.T) {
 	})
 }
 
+func TestAddressFromPathE(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Malformed path", func(t *testing.T) {
+		_, err := td.vault.AddressFromPathE("m/12381'/21888'/x'/0")
+		assert.Error(t, err)
+
+		_, err = td.vault.AddressFromPathE("12381'/21888'/1'/0")
+		assert.ErrorIs(t, err, addresspath.ErrInvalidPath)
+	})
+
+	t.Run("Invalid path depth", func(t *testing.T) {
+		_, err := td.vault.AddressFromPathE("m/12381'/21888'/1'")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+	})
+
+	t.Run("Wrong coin type", func(t *testing.T) {
+		_, err := td.vault.AddressFromPathE("m/12381'/21777'/1'/0")
+		assert.ErrorIs(t, err, ErrInvalidCoinType)
+	})
+
+	t.Run("Path not found", func(t *testing.T) {
+		_, err := td.vault.AddressFromPathE("m/12381'/21888'/1'/5")
+		assert.ErrorIs(t, err, PathNotFoundError{Path: "m/12381'/21888'/1'/5"})
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		info, err := td.vault.AddressFromPathE("m/12381'/21888'/1'/0")
+		require.NoError(t, err)
+		assert.Equal(t, "validator-address", info.Label)
+	})
+}
+
 func TestNewValidatorAddress(t *testing.T) {
 	td := setup(t)
 
@@ -215,160 +509,883 @@ func TestNewBLSAccountAddress(t *testing.T) {
 	assert.Equal(t, pub.AccountAddress().String(), addressInfo.Address)
 }
 
-func TestNewE225519AccountAddress(t *testing.T) {
+func TestDeriveAddressesRange(t *testing.T) {
 	td := setup(t)
 
-	addressInfo, err := td.vault.NewEd25519AccountAddress("addr-2", tPassword)
-	assert.NoError(t, err)
-	assert.NotEmpty(t, addressInfo.Address)
-	assert.NotEmpty(t, addressInfo.PublicKey)
-	assert.Equal(t, "m/44'/21888'/3'/1'", addressInfo.Path)
-
-	pub, _ := ed25519.PublicKeyFromString(addressInfo.PublicKey)
-	assert.Equal(t, pub.AccountAddress().String(), addressInfo.Address)
-}
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		_, err := td.vault.DeriveAddressesRange(PurposeBIP44, crypto.AddressTypeEd25519Account, 1, "")
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+	})
 
-func TestRecover(t *testing.T) {
-	td := setup(t)
+	t.Run("Unsupported address type", func(t *testing.T) {
+		_, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeEd25519Account, 1, "")
+		assert.ErrorIs(t, err, ErrUnsupportedAddressType)
+	})
 
-	t.Run("Invalid mnemonic", func(t *testing.T) {
-		_, err := CreateVaultFromMnemonic("invalid mnemonic phrase seed", 21888)
-		assert.Error(t, err)
+	t.Run("Invalid count", func(t *testing.T) {
+		_, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, -1, "")
+		assert.ErrorIs(t, err, ErrInvalidCount)
 	})
 
 	t.Run("Ok", func(t *testing.T) {
-		recovered, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
-		assert.NoError(t, err)
+		clone, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		require.NoError(t, err)
 
-		// Recover addresses
-		_, err = recovered.NewBLSAccountAddress("bls-account-address")
-		assert.NoError(t, err)
-		_, err = recovered.NewEd25519AccountAddress("ed25519-account-address", "")
-		assert.NoError(t, err)
-		_, err = recovered.NewValidatorAddress("validator-address")
+		infos, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeValidator, 5, "val-")
 		assert.NoError(t, err)
+		assert.Len(t, infos, 5)
+		assert.Equal(t, 11, td.vault.AddressCount())
+		assert.Equal(t, uint32(6), td.vault.Purposes.PurposeBLS.NextValidatorIndex)
+
+		// The first validator address is derived in the setup.
+		_, _ = clone.NewValidatorAddress("")
+		for i, info := range infos {
+			expected, err := clone.NewValidatorAddress("")
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Address, info.Address)
+			assert.Equal(t, expected.PublicKey, info.PublicKey)
+			assert.Equal(t, expected.Path, info.Path)
+			assert.Equal(t, fmt.Sprintf("val-%d", i+1), info.Label)
+		}
+	})
 
-		assert.Equal(t, recovered.Purposes, td.vault.Purposes)
+	t.Run("Label prefix is normalized", func(t *testing.T) {
+		infos, err := td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 2,
+			"cafe\u0301\t\u202e-")
+		require.NoError(t, err)
+
+		for _, info := range infos {
+			index := info.Path[strings.LastIndex(info.Path, "/")+1:]
+			assert.Equal(t, "caf\u00e9 -"+index, info.Label)
+
+			single, err := td.vault.Clone().NewBLSAccountAddress("cafe\u0301\t\u202e-" + index)
+			require.NoError(t, err)
+			assert.Equal(t, single.Label, info.Label)
+		}
 	})
 }
 
-func TestGetPrivateKeys(t *testing.T) {
+func TestNeuteredDeriveNext(t *testing.T) {
 	td := setup(t)
 
-	t.Run("Unknown address", func(t *testing.T) {
-		addr := td.RandAccAddress()
-		_, err := td.vault.PrivateKeys(tPassword, []string{addr.String()})
-		assert.ErrorIs(t, err, NewErrAddressNotFound(addr.String()))
-	})
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		neutered := td.vault.Neuter()
 
-	t.Run("No password", func(t *testing.T) {
-		addr := td.vault.AddressInfos()[0].Address
-		_, err := td.vault.PrivateKeys("", []string{addr})
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
-	})
+		_, err := neutered.NeuteredDeriveNext(PurposeBIP44, crypto.AddressTypeEd25519Account, "")
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
 
-	t.Run("Invalid password", func(t *testing.T) {
-		addr := td.vault.AddressInfos()[0].Address
-		_, err := td.vault.PrivateKeys("wrong_password", []string{addr})
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		_, err = neutered.NeuteredDeriveNext(PurposeImportPrivateKey, crypto.AddressTypeBLSAccount, "")
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+		assert.Equal(t, td.vault.AddressCount(), neutered.AddressCount())
 	})
 
-	t.Run("Check all the private keys", func(t *testing.T) {
-		for _, info := range td.vault.AddressInfos() {
-			prv, err := td.vault.PrivateKeys(tPassword, []string{info.Address})
-			assert.NoError(t, err)
-			addrInfo := td.vault.AddressInfo(info.Address)
-			path, _ := addresspath.FromString(info.Path)
+	t.Run("Unsupported address type", func(t *testing.T) {
+		neutered := td.vault.Neuter()
 
-			switch _N(path.AddressType()) {
-			case uint32(crypto.AddressTypeBLSAccount),
-				uint32(crypto.AddressTypeValidator):
-				pub, _ := bls.PublicKeyFromString(addrInfo.PublicKey)
-				require.True(t, prv[0].PublicKey().EqualsTo(pub))
-			case uint32(crypto.AddressTypeEd25519Account):
-				pub, _ := ed25519.PublicKeyFromString(addrInfo.PublicKey)
-				require.True(t, prv[0].PublicKey().EqualsTo(pub))
-			default:
-				assert.Fail(t, "not supported")
+		_, err := neutered.NeuteredDeriveNext(PurposeBLS12381, crypto.AddressTypeEd25519Account, "")
+		assert.ErrorIs(t, err, ErrUnsupportedAddressType)
+	})
+
+	t.Run("Keeps up with the full vault", func(t *testing.T) {
+		full := td.vault.Clone()
+		neutered := td.vault.Neuter()
+
+		for _, addressType := range []crypto.AddressType{
+			crypto.AddressTypeValidator, crypto.AddressTypeBLSAccount,
+		} {
+			var want *AddressInfo
+			var err error
+			if addressType == crypto.AddressTypeValidator {
+				want, err = full.NewValidatorAddress("label")
+			} else {
+				want, err = full.NewBLSAccountAddress("label")
 			}
+			require.NoError(t, err)
+
+			got, err := neutered.NeuteredDeriveNext(PurposeBLS12381, addressType, "label")
+			require.NoError(t, err)
+
+			assert.Equal(t, want.Address, got.Address)
+			assert.Equal(t, want.PublicKey, got.PublicKey)
+			assert.Equal(t, want.Path, got.Path)
+			assert.Equal(t, "label", got.Label)
+			assert.Equal(t, *got, neutered.Addresses[got.Address])
 		}
+		assert.Equal(t, full.Purposes, neutered.Purposes)
+		assert.Equal(t, full.AddressCount(), neutered.AddressCount())
 	})
 }
 
-func TestImportBLSPrivateKey(t *testing.T) {
+func TestPublicKeysAtPaths(t *testing.T) {
 	td := setup(t)
 
-	_, prv := td.RandBLSKeyPair()
+	neutered := td.vault.Neuter()
 
-	t.Run("Invalid password", func(t *testing.T) {
-		err := td.vault.ImportBLSPrivateKey("invalid-password", prv)
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
-	})
+	t.Run("Same keys as deriving individually", func(t *testing.T) {
+		cloned := td.vault.Clone()
+		accInfos, err := cloned.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 3, "")
+		require.NoError(t, err)
+		valInfos, err := cloned.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeValidator, 2, "")
+		require.NoError(t, err)
+
+		infos := []AddressInfo{accInfos[2], valInfos[0], accInfos[0], valInfos[1], accInfos[1]}
+		paths := make([]string, 0, len(infos))
+		for _, info := range infos {
+			paths = append(paths, info.Path)
+		}
 
-	t.Run("Ok", func(t *testing.T) {
-		err := td.vault.ImportBLSPrivateKey(tPassword, prv)
-		assert.NoError(t, err)
+		pubs, err := neutered.PublicKeysAtPaths(paths)
+		require.NoError(t, err)
+		require.Len(t, pubs, len(infos))
+		for i, info := range infos {
+			assert.Equal(t, info.PublicKey, pubs[i].String(), "path %s", info.Path)
+		}
+		assert.Equal(t, td.vault.AddressCount(), neutered.AddressCount())
+	})
 
-		valAddr := prv.PublicKeyNative().ValidatorAddress().String()
-		accAddr := prv.PublicKeyNative().AccountAddress().String()
+	t.Run("Empty paths", func(t *testing.T) {
+		pubs, err := neutered.PublicKeysAtPaths(nil)
+		require.NoError(t, err)
+		assert.Empty(t, pubs)
+	})
 
-		valAddrInfo := td.vault.AddressInfo(valAddr)
-		accAddrInfo := td.vault.AddressInfo(accAddr)
+	t.Run("Invalid paths", func(t *testing.T) {
+		tests := []struct {
+			path string
+			err  error
+		}{
+			{"invalid", ErrInvalidPath},
+			{"m/44'/21888'/3'/0'", ErrUnsupportedPurpose},
+			{"m/65535'/21888'/2'/0'", ErrUnsupportedPurpose},
+			{"m/12381'/21777'/2'/0", ErrInvalidCoinType},
+		}
+		for _, tt := range tests {
+			_, err := neutered.PublicKeysAtPaths([]string{"m/12381'/21888'/2'/0", tt.path})
+			assert.ErrorIs(t, err, tt.err, "path: %s", tt.path)
+			assert.ErrorAs(t, err, &VaultError{})
+		}
+	})
+}
 
-		assert.True(t, td.vault.Contains(valAddr))
-		assert.True(t, td.vault.Contains(accAddr))
+func TestScanAddresses(t *testing.T) {
+	td := setup(t)
 
-		assert.Equal(t, valAddr, valAddrInfo.Address)
-		assert.Equal(t, accAddr, accAddrInfo.Address)
+	original, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+	require.NoError(t, err)
+	infos, err := original.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 20, "")
+	require.NoError(t, err)
 
-		assert.Equal(t, prv.PublicKeyNative().String(), valAddrInfo.PublicKey)
-		assert.Equal(t, prv.PublicKeyNative().String(), accAddrInfo.PublicKey)
+	// Addresses 2, 3, and 9 are used.
+	usedAddrs := map[string]bool{
+		infos[2].Address: true,
+		infos[3].Address: true,
+		infos[9].Address: true,
+	}
+	used := func(addr string) bool {
+		return usedAddrs[addr]
+	}
 
-		assert.Equal(t, "m/65535'/21888'/1'/2'", valAddrInfo.Path)
-		assert.Equal(t, "m/65535'/21888'/2'/2'", accAddrInfo.Path)
+	t.Run("Invalid gap limit", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		_, err := recovered.ScanAddresses(PurposeBLS12381, crypto.AddressTypeBLSAccount, 0, used)
+		assert.ErrorIs(t, err, ErrInvalidCount)
 	})
 
-	t.Run("Reimporting private key", func(t *testing.T) {
-		err := td.vault.ImportBLSPrivateKey(tPassword, prv)
-		assert.ErrorIs(t, err, ErrAddressExists)
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		_, err := recovered.ScanAddresses(PurposeBIP44, crypto.AddressTypeEd25519Account, 5, used)
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
 	})
-}
 
-func TestImportEd25519PrivateKey(t *testing.T) {
-	td := setup(t)
+	t.Run("Gap limit reached before used address", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		found, err := recovered.ScanAddresses(PurposeBLS12381, crypto.AddressTypeBLSAccount, 5, used)
+		assert.NoError(t, err)
+		assert.Len(t, found, 4)
+		assert.Equal(t, uint32(4), recovered.Purposes.PurposeBLS.NextAccountIndex)
+	})
 
-	_, prv := td.RandEd25519KeyPair()
+	t.Run("Gap counter resets", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		found, err := recovered.ScanAddresses(PurposeBLS12381, crypto.AddressTypeBLSAccount, 6, used)
+		assert.NoError(t, err)
+		assert.Len(t, found, 10)
+		assert.Equal(t, 10, recovered.AddressCount())
+		assert.Equal(t, uint32(10), recovered.Purposes.PurposeBLS.NextAccountIndex)
 
-	t.Run("Invalid password", func(t *testing.T) {
-		err := td.vault.ImportEd25519PrivateKey("invalid-password", prv)
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		for i, info := range found {
+			assert.Equal(t, infos[i].Address, info.Address)
+			assert.Equal(t, infos[i].Path, info.Path)
+		}
 	})
 
-	t.Run("Ok", func(t *testing.T) {
-		err := td.vault.ImportEd25519PrivateKey(tPassword, prv)
+	t.Run("No used address", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		found, err := recovered.ScanAddresses(PurposeBLS12381, crypto.AddressTypeValidator, 5, used)
 		assert.NoError(t, err)
+		assert.Empty(t, found)
+		assert.Zero(t, recovered.AddressCount())
+	})
 
-		accAddr := prv.PublicKeyNative().AccountAddress().String()
+	t.Run("Report progress", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		var dones, totals []int
+		progress := func(done, total int) {
+			dones = append(dones, done)
+			totals = append(totals, total)
+		}
+		found, err := recovered.ScanAddressesWithProgress(context.Background(),
+			PurposeBLS12381, crypto.AddressTypeBLSAccount, 3, used, progress)
+		assert.NoError(t, err)
+		assert.Len(t, found, 4)
 
-		accAddrInfo := td.vault.AddressInfo(accAddr)
-		assert.True(t, td.vault.Contains(accAddr))
-		assert.Equal(t, accAddr, accAddrInfo.Address)
-		assert.Equal(t, prv.PublicKeyNative().String(), accAddrInfo.PublicKey)
-		assert.Equal(t, "m/65535'/21888'/3'/2'", accAddrInfo.Path)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, dones)
+		assert.Equal(t, []int{3, 3, 6, 7, 7, 7, 7}, totals)
 	})
 
-	t.Run("Reimporting private key", func(t *testing.T) {
-		err := td.vault.ImportEd25519PrivateKey(tPassword, td.importedEd25519Prv)
-		assert.ErrorIs(t, err, ErrAddressExists)
+	t.Run("Cancel mid-scan", func(t *testing.T) {
+		recovered, _ := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		ctx, cancel := context.WithCancel(context.Background())
+		checked := 0
+		cancelAfterFive := func(addr string) bool {
+			checked++
+			if checked == 5 {
+				cancel()
+			}
+
+			return used(addr)
+		}
+
+		found, err := recovered.ScanAddressesWithProgress(ctx,
+			PurposeBLS12381, crypto.AddressTypeBLSAccount, 6, cancelAfterFive, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, found)
+		assert.Equal(t, 5, checked)
+		assert.Zero(t, recovered.AddressCount())
+		assert.Zero(t, recovered.Purposes.PurposeBLS.NextAccountIndex)
 	})
 }
 
-func TestGetMnemonic(t *testing.T) {
+func TestDeriveAtPath(t *testing.T) {
 	td := setup(t)
 
-	t.Run("Invalid password", func(t *testing.T) {
-		_, err := td.vault.Mnemonic("invalid-password")
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	t.Run("Jump to a higher index", func(t *testing.T) {
+		info, err := td.vault.DeriveAtPath("m/12381'/21888'/2'/50", "jumped-account")
+		require.NoError(t, err)
+
+		assert.Equal(t, "m/12381'/21888'/2'/50", info.Path)
+		assert.Equal(t, "jumped-account", info.Label)
+		assert.Equal(t, OriginHDDerived, info.Origin)
+		assert.Equal(t, info, td.vault.AddressInfo(info.Address))
+		assert.Equal(t, uint32(51), td.vault.Purposes.PurposeBLS.NextAccountIndex)
+
+		recovered, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		require.NoError(t, err)
+		infos, err := recovered.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 51, "")
+		require.NoError(t, err)
+		assert.Equal(t, infos[50].Address, info.Address)
+		assert.Equal(t, infos[50].PublicKey, info.PublicKey)
+	})
+
+	t.Run("Lower index doesn't move the next index back", func(t *testing.T) {
+		info, err := td.vault.DeriveAtPath("m/12381'/21888'/2'/10", "")
+		require.NoError(t, err)
+		assert.Equal(t, "m/12381'/21888'/2'/10", info.Path)
+		assert.Equal(t, uint32(51), td.vault.Purposes.PurposeBLS.NextAccountIndex)
+	})
+
+	t.Run("Validator address", func(t *testing.T) {
+		info, err := td.vault.DeriveAtPath("m/12381'/21888'/1'/5", "")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(info.Address, "pc1p"))
+		assert.Equal(t, uint32(6), td.vault.Purposes.PurposeBLS.NextValidatorIndex)
+	})
+
+	t.Run("Existing address", func(t *testing.T) {
+		_, err := td.vault.DeriveAtPath("m/12381'/21888'/2'/0", "")
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+
+	t.Run("Malformed path", func(t *testing.T) {
+		_, err := td.vault.DeriveAtPath("m/12381'/21888'/2'/x", "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+
+		_, err = td.vault.DeriveAtPath("m/12381'/21888'/2'", "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+
+		_, err = td.vault.DeriveAtPath("m/12381'/21888'/2'/0'", "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+		assert.ErrorIs(t, err, addresspath.ErrInvalidPath)
+	})
+
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		_, err := td.vault.DeriveAtPath("m/44'/21888'/3'/0'", "")
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+
+		_, err = td.vault.DeriveAtPath("m/65535'/21888'/2'/0'", "")
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+	})
+
+	t.Run("Unsupported address type", func(t *testing.T) {
+		_, err := td.vault.DeriveAtPath("m/12381'/21888'/3'/0", "")
+		assert.ErrorIs(t, err, ErrUnsupportedAddressType)
+	})
+
+	t.Run("Coin type mismatch", func(t *testing.T) {
+		_, err := td.vault.DeriveAtPath("m/12381'/21777'/2'/0", "")
+		assert.ErrorIs(t, err, CoinTypeMismatchError{Expected: 21888, Got: 21777})
+	})
+
+	t.Run("Chain level", func(t *testing.T) {
+		info, err := td.vault.DeriveAtPath("m/12381'/21888'/2'/0/60", "")
+		require.NoError(t, err)
+		assert.Equal(t, "m/12381'/21888'/2'/60", info.Path)
+
+		_, err = td.vault.DeriveAtPath("m/12381'/21888'/2'/1/61", "")
+		assert.ErrorIs(t, err, ErrUnsupportedChain)
+	})
+
+	assert.Equal(t, 10, td.vault.AddressCount())
+}
+
+func TestNewChangeAddress(t *testing.T) {
+	td := setup(t)
+
+	_, err := td.vault.NewChangeAddress(crypto.AddressTypeBLSAccount, "change")
+	assert.ErrorIs(t, err, ErrUnsupportedChain)
+	assert.Equal(t, 6, td.vault.AddressCount())
+}
+
+func BenchmarkDeriveAddressesRange(b *testing.B) {
+	mnemonic, _ := GenerateMnemonic(128)
+
+	b.Run("Range", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			vlt, _ := CreateVaultFromMnemonic(mnemonic, 21888)
+			b.StartTimer()
+
+			_, _ = vlt.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 100, "")
+		}
+	})
+
+	b.Run("Loop of singles", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			vlt, _ := CreateVaultFromMnemonic(mnemonic, 21888)
+			b.StartTimer()
+
+			for j := 0; j < 100; j++ {
+				_, _ = vlt.NewBLSAccountAddress("")
+			}
+		}
+	})
+}
+
+func TestNewE225519AccountAddress(t *testing.T) {
+	td := setup(t)
+
+	addressInfo, err := td.vault.NewEd25519AccountAddress("addr-2", tPassword)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, addressInfo.Address)
+	assert.NotEmpty(t, addressInfo.PublicKey)
+	assert.Equal(t, "m/44'/21888'/3'/1'", addressInfo.Path)
+
+	pub, _ := ed25519.PublicKeyFromString(addressInfo.PublicKey)
+	assert.Equal(t, pub.AccountAddress().String(), addressInfo.Address)
+}
+
+func TestRemoveAddress(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandAccAddress().String()
+		err := td.vault.RemoveAddress(addr, tPassword, false)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr))
+	})
+
+	t.Run("Derived address with a higher index sibling", func(t *testing.T) {
+		info1 := td.vault.AddressFromPath("m/12381'/21888'/2'/0")
+		info2, err := td.vault.NewBLSAccountAddress("bls-account-2")
+		require.NoError(t, err)
+
+		err = td.vault.RemoveAddress(info1.Address, "", false)
+		assert.ErrorIs(t, err, ErrIndexGap)
+		assert.True(t, td.vault.Contains(info1.Address))
+
+		err = td.vault.RemoveAddress(info2.Address, "", false)
+		assert.NoError(t, err)
+		assert.False(t, td.vault.Contains(info2.Address))
+
+		// Removing doesn't roll back the next index.
+		assert.Equal(t, uint32(2), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeBLSAccount))
+
+		err = td.vault.RemoveAddress(info1.Address, "", false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Force removing", func(t *testing.T) {
+		info1, err := td.vault.NewValidatorAddress("validator-2")
+		require.NoError(t, err)
+		_, err = td.vault.NewValidatorAddress("validator-3")
+		require.NoError(t, err)
+
+		err = td.vault.RemoveAddress(info1.Address, "", true)
+		assert.NoError(t, err)
+		assert.False(t, td.vault.Contains(info1.Address))
+	})
+
+	t.Run("Imported key", func(t *testing.T) {
+		validatorAddr := td.importedBLSPrv.PublicKeyNative().ValidatorAddress().String()
+		accountAddr := td.importedBLSPrv.PublicKeyNative().AccountAddress().String()
+
+		// The key is still used by the account address.
+		err := td.vault.RemoveAddress(validatorAddr, "", false)
+		assert.NoError(t, err)
+		keyStore, err := td.vault.decryptKeyStore(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.importedBLSPrv.String(), keyStore.ImportedKeys[0])
+
+		err = td.vault.RemoveAddress(accountAddr, "", false)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.True(t, td.vault.Contains(accountAddr))
+
+		err = td.vault.RemoveAddress(accountAddr, tPassword, false)
+		assert.NoError(t, err)
+		keyStore, err = td.vault.decryptKeyStore(tPassword)
+		require.NoError(t, err)
+		assert.Empty(t, keyStore.ImportedKeys[0])
+		assert.Equal(t, td.importedEd25519Prv.String(), keyStore.ImportedKeys[1])
+
+		// The other imported keys are still accessible.
+		ed25519Addr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+		prvs, err := td.vault.PrivateKeys(tPassword, []string{ed25519Addr})
+		assert.NoError(t, err)
+		assert.Equal(t, td.importedEd25519Prv, prvs[0])
+	})
+}
+
+func TestNewEd25519ValidatorAddress(t *testing.T) {
+	td := setup(t)
+
+	_, err := td.vault.NewEd25519ValidatorAddress("validator", tPassword)
+	assert.ErrorIs(t, err, ErrUnsupportedValidatorScheme)
+	assert.Equal(t, 6, td.vault.AddressCount())
+}
+
+func TestNextIndex(t *testing.T) {
+	td := setup(t)
+
+	assert.Equal(t, uint32(1), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeValidator))
+	assert.Equal(t, uint32(1), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeBLSAccount))
+	assert.Equal(t, uint32(1), td.vault.NextIndex(PurposeBIP44, crypto.AddressTypeEd25519Account))
+	assert.Equal(t, uint32(2), td.vault.NextIndex(PurposeImportPrivateKey, crypto.AddressTypeBLSAccount))
+	assert.Equal(t, uint32(0), td.vault.NextIndex(PurposeWatchOnly, crypto.AddressTypeBLSAccount))
+	assert.Equal(t, uint32(0), td.vault.NextIndex(PurposeBIP44, crypto.AddressTypeValidator))
+	assert.Equal(t, uint32(0), td.vault.NextIndex(1, crypto.AddressTypeValidator))
+
+	t.Run("Deriving validator address advances the HD index", func(t *testing.T) {
+		_, err := td.vault.NewValidatorAddress("")
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeValidator))
+	})
+
+	t.Run("Importing private key doesn't advance the HD index", func(t *testing.T) {
+		_, prv := td.RandBLSKeyPair()
+		assert.NoError(t, td.vault.ImportBLSPrivateKey(tPassword, prv))
+
+		assert.Equal(t, uint32(2), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeValidator))
+		assert.Equal(t, uint32(1), td.vault.NextIndex(PurposeBLS12381, crypto.AddressTypeBLSAccount))
+		assert.Equal(t, uint32(3), td.vault.NextIndex(PurposeImportPrivateKey, crypto.AddressTypeValidator))
+	})
+}
+
+func TestRecover(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid mnemonic", func(t *testing.T) {
+		_, err := CreateVaultFromMnemonic("invalid mnemonic phrase seed", 21888)
+		assert.Error(t, err)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		recovered, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		assert.NoError(t, err)
+
+		// Recover addresses
+		_, err = recovered.NewBLSAccountAddress("bls-account-address")
+		assert.NoError(t, err)
+		_, err = recovered.NewEd25519AccountAddress("ed25519-account-address", "")
+		assert.NoError(t, err)
+		_, err = recovered.NewValidatorAddress("validator-address")
+		assert.NoError(t, err)
+
+		assert.Equal(t, recovered.Purposes, td.vault.Purposes)
+	})
+}
+
+func TestPreviewRecovery(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid mnemonic", func(t *testing.T) {
+		_, _, err := PreviewRecovery("invalid mnemonic phrase seed", "", 21888)
+		assert.Error(t, err)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		fingerprint, firstAddrs, err := PreviewRecovery(td.mnemonic, "", 21888)
+		require.NoError(t, err)
+
+		assert.Equal(t, td.vault.MasterFingerprint(), fingerprint)
+		assert.Equal(t, map[string]string{
+			"validator":       td.vault.AddressesByLabel("validator-address")[0].Address,
+			"bls_account":     td.vault.AddressesByLabel("bls-account-address")[0].Address,
+			"ed25519_account": td.vault.AddressesByLabel("ed25519-account-address")[0].Address,
+		}, firstAddrs)
+	})
+
+	t.Run("Passphrase changes the addresses", func(t *testing.T) {
+		fingerprint, firstAddrs, err := PreviewRecovery(td.mnemonic, "passphrase", 21888)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, td.vault.MasterFingerprint(), fingerprint)
+		assert.NotEqual(t, td.vault.AddressesByLabel("validator-address")[0].Address,
+			firstAddrs["validator"])
+	})
+
+	t.Run("Coin type changes the addresses", func(t *testing.T) {
+		fingerprint, firstAddrs, err := PreviewRecovery(td.mnemonic, "", 21777)
+		require.NoError(t, err)
+
+		assert.Equal(t, td.vault.MasterFingerprint(), fingerprint)
+		assert.NotEqual(t, td.vault.AddressesByLabel("validator-address")[0].Address,
+			firstAddrs["validator"])
+	})
+}
+
+func TestPassphrase(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Empty passphrase is compatible", func(t *testing.T) {
+		vlt1, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		assert.NoError(t, err)
+		vlt2, err := CreateVaultFromMnemonicWithPassphrase(td.mnemonic, "", 21888)
+		assert.NoError(t, err)
+
+		assert.Equal(t, vlt1, vlt2)
+
+		hasPassphrase, err := vlt2.HasPassphrase("")
+		assert.NoError(t, err)
+		assert.False(t, hasPassphrase)
+	})
+
+	t.Run("Different passphrases derive different addresses", func(t *testing.T) {
+		vlt1, err := CreateVaultFromMnemonicWithPassphrase(td.mnemonic, "passphrase-1", 21888)
+		assert.NoError(t, err)
+		vlt2, err := CreateVaultFromMnemonicWithPassphrase(td.mnemonic, "passphrase-2", 21888)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, vlt1.Purposes, vlt2.Purposes)
+		assert.NotEqual(t, vlt1.Purposes, td.vault.Purposes)
+
+		info1, err := vlt1.NewEd25519AccountAddress("", "")
+		assert.NoError(t, err)
+		info2, err := vlt2.NewEd25519AccountAddress("", "")
+		assert.NoError(t, err)
+		assert.NotEqual(t, info1.Address, info2.Address)
+	})
+
+	t.Run("Mnemonic and private keys", func(t *testing.T) {
+		vlt, err := CreateVaultFromMnemonicWithPassphrase(td.mnemonic, "passphrase", 21888)
+		assert.NoError(t, err)
+
+		m, err := vlt.Mnemonic("")
+		assert.NoError(t, err)
+		assert.Equal(t, td.mnemonic, m)
+
+		hasPassphrase, err := vlt.HasPassphrase("")
+		assert.NoError(t, err)
+		assert.True(t, hasPassphrase)
+		assert.NotContains(t, vlt.KeyStore, "passphrase\"")
+
+		blsInfo, err := vlt.NewBLSAccountAddress("")
+		assert.NoError(t, err)
+		ed25519Info, err := vlt.NewEd25519AccountAddress("", "")
+		assert.NoError(t, err)
+
+		prvs, err := vlt.PrivateKeys("", []string{blsInfo.Address, ed25519Info.Address})
+		assert.NoError(t, err)
+		assert.Equal(t, blsInfo.PublicKey, prvs[0].PublicKey().String())
+		assert.Equal(t, ed25519Info.PublicKey, prvs[1].PublicKey().String())
+	})
+}
+
+func TestGetPrivateKeys(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr := td.RandAccAddress()
+		_, err := td.vault.PrivateKeys(tPassword, []string{addr.String()})
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr.String()))
+	})
+
+	t.Run("No password", func(t *testing.T) {
+		addr := td.vault.AddressInfos()[0].Address
+		_, err := td.vault.PrivateKeys("", []string{addr})
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		addr := td.vault.AddressInfos()[0].Address
+		_, err := td.vault.PrivateKeys("wrong_password", []string{addr})
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Check all the private keys", func(t *testing.T) {
+		for _, info := range td.vault.AddressInfos() {
+			prv, err := td.vault.PrivateKeys(tPassword, []string{info.Address})
+			assert.NoError(t, err)
+			addrInfo := td.vault.AddressInfo(info.Address)
+			path, _ := addresspath.FromString(info.Path)
+
+			switch _N(path.AddressType()) {
+			case uint32(crypto.AddressTypeBLSAccount),
+				uint32(crypto.AddressTypeValidator):
+				pub, _ := bls.PublicKeyFromString(addrInfo.PublicKey)
+				require.True(t, prv[0].PublicKey().EqualsTo(pub))
+			case uint32(crypto.AddressTypeEd25519Account):
+				pub, _ := ed25519.PublicKeyFromString(addrInfo.PublicKey)
+				require.True(t, prv[0].PublicKey().EqualsTo(pub))
+			default:
+				assert.Fail(t, "not supported")
+			}
+		}
+	})
+
+	t.Run("Duplicated addresses", func(t *testing.T) {
+		infos := td.vault.AddressInfos()
+		addrs := []string{infos[1].Address, infos[0].Address, infos[1].Address}
+
+		prvs, err := td.vault.PrivateKeys(tPassword, addrs)
+		assert.NoError(t, err)
+		require.Len(t, prvs, 2)
+		assert.Equal(t, infos[1].PublicKey, prvs[0].PublicKey().String())
+		assert.Equal(t, infos[0].PublicKey, prvs[1].PublicKey().String())
+	})
+}
+
+func TestPrivateKeysMap(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Unknown address", func(t *testing.T) {
+		addr1 := td.RandAccAddress().String()
+		addr2 := td.RandAccAddress().String()
+		addrs := []string{td.vault.AddressInfos()[0].Address, addr1, addr2}
+
+		keys, err := td.vault.PrivateKeysMap(tPassword, addrs)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(addr1))
+		assert.Nil(t, keys)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		addr := td.vault.AddressInfos()[0].Address
+		_, err := td.vault.PrivateKeysMap("wrong_password", []string{addr})
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		addrs := make([]string, 0)
+		for _, info := range td.vault.AddressInfos() {
+			addrs = append(addrs, info.Address, info.Address)
+		}
+
+		keys, err := td.vault.PrivateKeysMap(tPassword, addrs)
+		assert.NoError(t, err)
+		assert.Len(t, keys, td.vault.AddressCount())
+		for addr, prv := range keys {
+			assert.Equal(t, td.vault.AddressInfo(addr).PublicKey, prv.PublicKey().String())
+		}
+	})
+}
+
+func TestImportBLSPrivateKey(t *testing.T) {
+	td := setup(t)
+
+	_, prv := td.RandBLSKeyPair()
+
+	t.Run("Invalid password", func(t *testing.T) {
+		err := td.vault.ImportBLSPrivateKey("invalid-password", prv)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		err := td.vault.ImportBLSPrivateKey(tPassword, prv)
+		assert.NoError(t, err)
+
+		valAddr := prv.PublicKeyNative().ValidatorAddress().String()
+		accAddr := prv.PublicKeyNative().AccountAddress().String()
+
+		valAddrInfo := td.vault.AddressInfo(valAddr)
+		accAddrInfo := td.vault.AddressInfo(accAddr)
+
+		assert.True(t, td.vault.Contains(valAddr))
+		assert.True(t, td.vault.Contains(accAddr))
+
+		assert.Equal(t, valAddr, valAddrInfo.Address)
+		assert.Equal(t, accAddr, accAddrInfo.Address)
+
+		assert.Equal(t, prv.PublicKeyNative().String(), valAddrInfo.PublicKey)
+		assert.Equal(t, prv.PublicKeyNative().String(), accAddrInfo.PublicKey)
+
+		assert.Equal(t, "m/65535'/21888'/1'/2'", valAddrInfo.Path)
+		assert.Equal(t, "m/65535'/21888'/2'/2'", accAddrInfo.Path)
+	})
+
+	t.Run("Reimporting private key", func(t *testing.T) {
+		err := td.vault.ImportBLSPrivateKey(tPassword, prv)
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+}
+
+func TestImportBLSPrivateKeys(t *testing.T) {
+	td := setup(t)
+
+	_, prv1 := td.RandBLSKeyPair()
+	_, prv2 := td.RandBLSKeyPair()
+	_, prv3 := td.RandBLSKeyPair()
+
+	t.Run("Duplicated key in the batch", func(t *testing.T) {
+		err := td.vault.ImportBLSPrivateKeys(tPassword, []*bls.PrivateKey{prv1, prv2, prv1})
+		assert.ErrorIs(t, err, ErrAddressExists)
+		assert.ErrorIs(t, err, AddressExistsError{
+			Address: prv1.PublicKeyNative().AccountAddress().String(),
+		})
+		assert.Equal(t, 6, td.vault.AddressCount())
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		count := encrypter.KDFRunCount()
+		err := td.vault.ImportBLSPrivateKeys(tPassword, []*bls.PrivateKey{prv1, prv2})
+		require.NoError(t, err)
+		assert.Equal(t, count+2, encrypter.KDFRunCount())
+
+		assert.Equal(t, 10, td.vault.AddressCount())
+		assert.Equal(t, "m/65535'/21888'/2'/2'",
+			td.vault.AddressInfo(prv1.PublicKeyNative().AccountAddress().String()).Path)
+		assert.Equal(t, "m/65535'/21888'/1'/3'",
+			td.vault.AddressInfo(prv2.PublicKeyNative().ValidatorAddress().String()).Path)
+
+		prvs, err := td.vault.PrivateKeys(tPassword, []string{
+			prv1.PublicKeyNative().AccountAddress().String(),
+			prv2.PublicKeyNative().ValidatorAddress().String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, prv1.String(), prvs[0].String())
+		assert.Equal(t, prv2.String(), prvs[1].String())
+	})
+
+	t.Run("Key already in the vault", func(t *testing.T) {
+		err := td.vault.ImportBLSPrivateKeys(tPassword, []*bls.PrivateKey{prv3, prv2})
+		assert.ErrorIs(t, err, AddressExistsError{
+			Address: prv2.PublicKeyNative().AccountAddress().String(),
+		})
+		assert.False(t, td.vault.Contains(prv3.PublicKeyNative().AccountAddress().String()))
+		assert.Equal(t, 10, td.vault.AddressCount())
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		err := td.vault.Neuter().ImportBLSPrivateKeys("", []*bls.PrivateKey{prv3})
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+}
+
+func BenchmarkImportBLSPrivateKeys(b *testing.B) {
+	ts := testsuite.NewTestSuiteFromSeed(1)
+	mnemonic, _ := GenerateMnemonic(128)
+
+	prvs := make([]*bls.PrivateKey, 10)
+	for i := range prvs {
+		_, prvs[i] = ts.RandBLSKeyPair()
+	}
+
+	newVault := func() *Vault {
+		vlt, _ := CreateVaultFromMnemonic(mnemonic, 21888)
+		_ = vlt.UpdatePassword("", tPassword,
+			encrypter.OptionIteration(1),
+			encrypter.OptionMemory(16*1024),
+			encrypter.OptionParallelism(1))
+
+		return vlt
+	}
+
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			vlt := newVault()
+			b.StartTimer()
+
+			_ = vlt.ImportBLSPrivateKeys(tPassword, prvs)
+		}
+	})
+
+	b.Run("Loop of singles", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			vlt := newVault()
+			b.StartTimer()
+
+			for _, prv := range prvs {
+				_ = vlt.ImportBLSPrivateKey(tPassword, prv)
+			}
+		}
+	})
+}
+
+func TestImportEd25519PrivateKey(t *testing.T) {
+	td := setup(t)
+
+	_, prv := td.RandEd25519KeyPair()
+
+	t.Run("Invalid password", func(t *testing.T) {
+		err := td.vault.ImportEd25519PrivateKey("invalid-password", prv)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		err := td.vault.ImportEd25519PrivateKey(tPassword, prv)
+		assert.NoError(t, err)
+
+		accAddr := prv.PublicKeyNative().AccountAddress().String()
+
+		accAddrInfo := td.vault.AddressInfo(accAddr)
+		assert.True(t, td.vault.Contains(accAddr))
+		assert.Equal(t, accAddr, accAddrInfo.Address)
+		assert.Equal(t, prv.PublicKeyNative().String(), accAddrInfo.PublicKey)
+		assert.Equal(t, "m/65535'/21888'/3'/2'", accAddrInfo.Path)
+
+		prvs, err := td.vault.PrivateKeys(tPassword, []string{accAddr})
+		assert.NoError(t, err)
+		assert.Equal(t, prv, prvs[0])
+	})
+
+	t.Run("Reimporting private key", func(t *testing.T) {
+		err := td.vault.ImportEd25519PrivateKey(tPassword, td.importedEd25519Prv)
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		_, prv := td.RandEd25519KeyPair()
+		err := td.vault.Neuter().ImportEd25519PrivateKey(tPassword, prv)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+}
+
+func TestGetMnemonic(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.Mnemonic("invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
 	})
 
 	t.Run("No password", func(t *testing.T) {
@@ -376,55 +1393,432 @@ func TestGetMnemonic(t *testing.T) {
 		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
 	})
 
-	t.Run("Ok", func(t *testing.T) {
-		m, err := td.vault.Mnemonic(tPassword)
+	t.Run("Ok", func(t *testing.T) {
+		m, err := td.vault.Mnemonic(tPassword)
+		assert.NoError(t, err)
+		assert.Equal(t, m, td.mnemonic)
+	})
+
+	t.Run("Neutered wallet", func(t *testing.T) {
+		_, err := td.vault.Neuter().Mnemonic("")
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+}
+
+func TestUpdatePassword(t *testing.T) {
+	td := setup(t)
+
+	opts := []encrypter.Option{
+		encrypter.OptionIteration(1),
+		encrypter.OptionMemory(1),
+		encrypter.OptionParallelism(1),
+	}
+
+	addrInfos := td.vault.AddressInfos()
+	newPassword := "new-password"
+
+	t.Run("Empty password", func(t *testing.T) {
+		err := td.vault.UpdatePassword("", newPassword)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Incorrect password", func(t *testing.T) {
+		err := td.vault.UpdatePassword("invalid-password", newPassword)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Valid password update", func(t *testing.T) {
+		assert.NoError(t, td.vault.UpdatePassword(tPassword, newPassword, opts...))
+		assert.True(t, td.vault.IsEncrypted())
+		assert.Equal(t, addrInfos, td.vault.AddressInfos())
+	})
+
+	t.Run("Old password should no longer be valid", func(t *testing.T) {
+		err := td.vault.UpdatePassword(tPassword, newPassword)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Set vault password to empty", func(t *testing.T) {
+		assert.NoError(t, td.vault.UpdatePassword(newPassword, ""))
+		assert.False(t, td.vault.IsEncrypted())
+		assert.Equal(t, addrInfos, td.vault.AddressInfos())
+	})
+}
+
+func TestVerifyPassword(t *testing.T) {
+	td := setup(t)
+
+	assert.NoError(t, td.vault.VerifyPassword(tPassword))
+	assert.ErrorIs(t, td.vault.VerifyPassword(""), encrypter.ErrInvalidPassword)
+	assert.ErrorIs(t, td.vault.VerifyPassword("invalid-password"), encrypter.ErrInvalidPassword)
+	assert.ErrorIs(t, td.vault.Neuter().VerifyPassword(tPassword), ErrNeutered)
+
+	assert.NoError(t, td.vault.UpdatePassword(tPassword, ""))
+	assert.NoError(t, td.vault.VerifyPassword(""))
+	assert.ErrorIs(t, td.vault.VerifyPassword(tPassword), encrypter.ErrInvalidPassword)
+	assert.ErrorIs(t, td.vault.VerifyPassword("invalid-password"), encrypter.ErrInvalidPassword)
+}
+
+func TestRekey(t *testing.T) {
+	td := setup(t)
+
+	opts := []encrypter.Option{
+		encrypter.OptionIteration(1),
+		encrypter.OptionMemory(8),
+		encrypter.OptionParallelism(1),
+	}
+
+	t.Run("Same password", func(t *testing.T) {
+		oldKeyStore := td.vault.KeyStore
+		oldPrvs, err := td.vault.PrivateKeysMap(tPassword, []string{
+			td.importedBLSPrv.PublicKeyNative().AccountAddress().String(),
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, td.vault.Rekey(tPassword, tPassword, opts...))
+
+		oldData, _ := base64.StdEncoding.DecodeString(oldKeyStore)
+		newData, _ := base64.StdEncoding.DecodeString(td.vault.KeyStore)
+		require.Len(t, newData, len(oldData))
+		assert.NotEqual(t, oldData[:16], newData[:16], "salt should be regenerated")
+		for i := 16; i < len(newData); i += 16 {
+			end := min(i+16, len(newData))
+			assert.NotEqual(t, oldData[i:end], newData[i:end], "cipher block at %d should change", i)
+		}
+
+		newPrvs, err := td.vault.PrivateKeysMap(tPassword, []string{
+			td.importedBLSPrv.PublicKeyNative().AccountAddress().String(),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, oldPrvs, newPrvs)
+	})
+
+	t.Run("Roll back on failure", func(t *testing.T) {
+		oldKeyStore := td.vault.KeyStore
+		oldEncrypter := td.vault.Encrypter
+
+		// Invalid scrypt parameter, N should be a power of two.
+		err := td.vault.Rekey(tPassword, "new-password",
+			encrypter.OptionKDF(encrypter.KDFScrypt), encrypter.OptionScryptN(3))
+		assert.ErrorIs(t, err, encrypter.ErrInvalidParam)
+
+		assert.Equal(t, oldKeyStore, td.vault.KeyStore)
+		assert.Equal(t, oldEncrypter, td.vault.Encrypter)
+		_, err = td.vault.Mnemonic(tPassword)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		err := td.vault.Rekey("invalid-password", tPassword)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Report progress", func(t *testing.T) {
+		var dones []int
+		progress := func(done, total int) {
+			assert.Equal(t, 2, total)
+			dones = append(dones, done)
+		}
+		err := td.vault.RekeyWithProgress(context.Background(), tPassword, tPassword, progress, opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, dones)
+	})
+
+	t.Run("Cancel after decryption", func(t *testing.T) {
+		oldKeyStore := td.vault.KeyStore
+		oldEncrypter := td.vault.Encrypter
+
+		ctx, cancel := context.WithCancel(context.Background())
+		progress := func(done, _ int) {
+			if done == 1 {
+				cancel()
+			}
+		}
+		err := td.vault.RekeyWithProgress(ctx, tPassword, "new-password", progress, opts...)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Equal(t, oldKeyStore, td.vault.KeyStore)
+		assert.Equal(t, oldEncrypter, td.vault.Encrypter)
+		_, err = td.vault.Mnemonic(tPassword)
+		assert.NoError(t, err)
+	})
+}
+
+// rekeyOptions returns the fast Argon2 options with the given memory in KiB.
+func rekeyOptions(memory uint32) []encrypter.Option {
+	return []encrypter.Option{
+		encrypter.OptionIteration(1),
+		encrypter.OptionMemory(memory),
+		encrypter.OptionParallelism(1),
+	}
+}
+
+// newVaultWithImportedKeys creates an encrypted vault with the given number of
+// imported BLS keys, using the given Argon2 memory in KiB.
+func newVaultWithImportedKeys(tb testing.TB, count int, memory uint32) *Vault {
+	tb.Helper()
+
+	ts := testsuite.NewTestSuiteFromSeed(1)
+	mnemonic, _ := GenerateMnemonic(128)
+	vlt, err := CreateVaultFromMnemonic(mnemonic, 21888)
+	require.NoError(tb, err)
+	require.NoError(tb, vlt.UpdatePassword("", tPassword, rekeyOptions(memory)...))
+
+	prvs := make([]*bls.PrivateKey, count)
+	for i := range prvs {
+		_, prvs[i] = ts.RandBLSKeyPair()
+	}
+	require.NoError(tb, vlt.ImportBLSPrivateKeys(tPassword, prvs))
+
+	return vlt
+}
+
+func TestRekeyManyImportedKeys(t *testing.T) {
+	const memory = 16 * 1024 // 16 MiB
+
+	rekey := func(vlt *Vault) (uint64, uint64) {
+		var before, after runtime.MemStats
+		count := encrypter.KDFRunCount()
+		runtime.ReadMemStats(&before)
+
+		require.NoError(t, vlt.Rekey(tPassword, tPassword, rekeyOptions(memory)...))
+
+		runtime.ReadMemStats(&after)
+
+		return encrypter.KDFRunCount() - count, after.TotalAlloc - before.TotalAlloc
+	}
+
+	fewRuns, fewAlloc := rekey(newVaultWithImportedKeys(t, 1, memory))
+	manyRuns, manyAlloc := rekey(newVaultWithImportedKeys(t, 100, memory))
+
+	// The password is hashed once for decryption and once for encryption.
+	assert.Equal(t, uint64(2), fewRuns)
+	assert.Equal(t, uint64(2), manyRuns)
+
+	// The imported keys add a few KiB, not an Argon2 buffer per key.
+	assert.Greater(t, fewAlloc, uint64(2*memory*1024))
+	assert.Less(t, manyAlloc, fewAlloc+memory*1024/2)
+}
+
+func BenchmarkRekey(b *testing.B) {
+	const memory = 16 * 1024 // 16 MiB
+
+	for _, count := range []int{1, 10, 100} {
+		vlt := newVaultWithImportedKeys(b, count, memory)
+
+		b.Run(fmt.Sprintf("%d imported keys", count), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = vlt.Rekey(tPassword, tPassword, rekeyOptions(memory)...)
+			}
+		})
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	td := setup(t)
+
+	addr := td.importedBLSPrv.PublicKeyNative().AccountAddress().String()
+
+	t.Run("Pre-cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := td.vault.PrivateKeysCtx(ctx, tPassword, []string{addr})
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = td.vault.MnemonicCtx(ctx, tPassword)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		oldKeyStore := td.vault.KeyStore
+		err = td.vault.UpdatePasswordCtx(ctx, tPassword, "new-password")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, oldKeyStore, td.vault.KeyStore)
+	})
+
+	t.Run("Active context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		prvs, err := td.vault.PrivateKeysCtx(ctx, tPassword, []string{addr})
 		assert.NoError(t, err)
-		assert.Equal(t, m, td.mnemonic)
+		assert.Equal(t, td.importedBLSPrv, prvs[0])
+
+		mnemonic, err := td.vault.MnemonicCtx(ctx, tPassword)
+		assert.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
 	})
 
-	t.Run("Neutered wallet", func(t *testing.T) {
-		_, err := td.vault.Neuter().Mnemonic("")
-		assert.ErrorIs(t, err, ErrNeutered)
+	t.Run("Deadline exceeded during password hashing", func(t *testing.T) {
+		vlt, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		require.NoError(t, err)
+
+		// A slow password hasher, that takes longer than the deadline.
+		require.NoError(t, vlt.UpdatePassword("", tPassword,
+			encrypter.OptionIteration(4),
+			encrypter.OptionMemory(64*1024),
+			encrypter.OptionParallelism(1)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		_, err = vlt.MnemonicCtx(ctx, tPassword)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
 	})
 }
 
-func TestUpdatePassword(t *testing.T) {
+func TestUpdatePasswordMinEntropy(t *testing.T) {
 	td := setup(t)
 
 	opts := []encrypter.Option{
 		encrypter.OptionIteration(1),
-		encrypter.OptionMemory(1),
+		encrypter.OptionMemory(8),
 		encrypter.OptionParallelism(1),
+		encrypter.OptionMinPasswordEntropy(50),
 	}
 
-	addrInfos := td.vault.AddressInfos()
-	newPassword := "new-password"
+	err := td.vault.UpdatePassword(tPassword, "qwerty123", opts...)
+	assert.ErrorIs(t, err, encrypter.ErrWeakPassword)
+	_, err = td.vault.Mnemonic(tPassword)
+	assert.NoError(t, err, "password should not be changed")
 
-	t.Run("Empty password", func(t *testing.T) {
-		err := td.vault.UpdatePassword("", newPassword)
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	err = td.vault.UpdatePassword(tPassword, "Tr0ub4dor&3-horse", opts...)
+	assert.NoError(t, err)
+
+	// Disabling the encryption bypasses the password policy.
+	err = td.vault.UpdatePassword("Tr0ub4dor&3-horse", "", opts...)
+	assert.NoError(t, err)
+	assert.False(t, td.vault.IsEncrypted())
+}
+
+func TestEncryptionParams(t *testing.T) {
+	td := setup(t)
+
+	info, err := td.vault.EncryptionParams()
+	assert.NoError(t, err)
+	assert.Equal(t, "ARGON2ID", info.KDF)
+	assert.Equal(t, uint64(1), info.KDFParams["iterations"])
+	assert.Equal(t, uint64(8), info.KDFParams["memory"])
+	assert.Equal(t, uint64(1), info.KDFParams["parallelism"])
+	assert.Equal(t, "AES_256_CTR", info.Cipher)
+	assert.Equal(t, 16, info.SaltLen)
+
+	_, err = td.vault.Neuter().EncryptionParams()
+	assert.ErrorIs(t, err, encrypter.ErrNotEncrypted)
+
+	assert.NoError(t, td.vault.UpdatePassword(tPassword, ""))
+	_, err = td.vault.EncryptionParams()
+	assert.ErrorIs(t, err, encrypter.ErrNotEncrypted)
+}
+
+func TestUpdatePasswordSwitchKDF(t *testing.T) {
+	td := setup(t)
+
+	mnemonic, err := td.vault.Mnemonic(tPassword)
+	require.NoError(t, err)
+
+	scryptOpts := []encrypter.Option{
+		encrypter.OptionKDF(encrypter.KDFScrypt),
+		encrypter.OptionScryptN(16),
+		encrypter.OptionScryptR(1),
+		encrypter.OptionScryptP(1),
+	}
+	argon2Opts := []encrypter.Option{
+		encrypter.OptionKDF(encrypter.KDFArgon2ID),
+		encrypter.OptionIteration(1),
+		encrypter.OptionMemory(8),
+		encrypter.OptionParallelism(1),
+	}
+
+	// Argon2id to scrypt
+	assert.NoError(t, td.vault.UpdatePassword(tPassword, "scrypt-password", scryptOpts...))
+	assert.Equal(t, "SCRYPT-AES_256_CTR-MACV1", td.vault.Encrypter.Method)
+	restored, err := td.vault.Mnemonic("scrypt-password")
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, restored)
+
+	// scrypt to Argon2id
+	assert.NoError(t, td.vault.UpdatePassword("scrypt-password", tPassword, argon2Opts...))
+	assert.Equal(t, "ARGON2ID-AES_256_CTR-MACV1", td.vault.Encrypter.Method)
+	restored, err = td.vault.Mnemonic(tPassword)
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, restored)
+}
+
+func TestUpgradeEncryption(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Strong enough", func(t *testing.T) {
+		oldKeyStore := td.vault.KeyStore
+		kdfRuns := encrypter.KDFRunCount()
+
+		upgraded, err := td.vault.UpgradeEncryption(tPassword,
+			encrypter.OptionIteration(1), encrypter.OptionMemory(8), encrypter.OptionParallelism(1))
+		require.NoError(t, err)
+		assert.False(t, upgraded)
+		assert.Equal(t, oldKeyStore, td.vault.KeyStore)
+		assert.Equal(t, kdfRuns, encrypter.KDFRunCount())
 	})
 
-	t.Run("Incorrect password", func(t *testing.T) {
-		err := td.vault.UpdatePassword("invalid-password", newPassword)
+	t.Run("Invalid password", func(t *testing.T) {
+		oldEncrypter := td.vault.Encrypter.Clone()
+
+		upgraded, err := td.vault.UpgradeEncryption("invalid-password",
+			encrypter.OptionIteration(2), encrypter.OptionMemory(8), encrypter.OptionParallelism(1))
 		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+		assert.False(t, upgraded)
+		assert.Equal(t, oldEncrypter, td.vault.Encrypter)
 	})
 
-	t.Run("Valid password update", func(t *testing.T) {
-		assert.NoError(t, td.vault.UpdatePassword(tPassword, newPassword, opts...))
-		assert.True(t, td.vault.IsEncrypted())
-		assert.Equal(t, addrInfos, td.vault.AddressInfos())
+	t.Run("Upgrade weak parameters", func(t *testing.T) {
+		upgraded, err := td.vault.UpgradeEncryption(tPassword,
+			encrypter.OptionIteration(2), encrypter.OptionMemory(16), encrypter.OptionParallelism(1))
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+
+		info, err := td.vault.Encrypter.Info()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), info.KDFParams["iterations"])
+		assert.Equal(t, uint64(16), info.KDFParams["memory"])
+
+		mnemonic, err := td.vault.Mnemonic(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, td.mnemonic, mnemonic)
+
+		// Already upgraded
+		upgraded, err = td.vault.UpgradeEncryption(tPassword,
+			encrypter.OptionIteration(2), encrypter.OptionMemory(16), encrypter.OptionParallelism(1))
+		require.NoError(t, err)
+		assert.False(t, upgraded)
 	})
 
-	t.Run("Old password should no longer be valid", func(t *testing.T) {
-		err := td.vault.UpdatePassword(tPassword, newPassword)
-		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	t.Run("Switch KDF", func(t *testing.T) {
+		upgraded, err := td.vault.UpgradeEncryption(tPassword,
+			encrypter.OptionKDF(encrypter.KDFScrypt), encrypter.OptionScryptN(16))
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+
+		info, err := td.vault.Encrypter.Info()
+		require.NoError(t, err)
+		assert.Equal(t, "SCRYPT", info.KDF)
+
+		_, err = td.vault.Mnemonic(tPassword)
+		assert.NoError(t, err)
 	})
 
-	t.Run("Set vault password to empty", func(t *testing.T) {
-		assert.NoError(t, td.vault.UpdatePassword(newPassword, ""))
-		assert.False(t, td.vault.IsEncrypted())
-		assert.Equal(t, addrInfos, td.vault.AddressInfos())
+	t.Run("Not encrypted vault", func(t *testing.T) {
+		vlt, err := CreateVaultFromMnemonic(td.mnemonic, 21888)
+		require.NoError(t, err)
+
+		upgraded, err := vlt.UpgradeEncryption("")
+		require.NoError(t, err)
+		assert.False(t, upgraded)
+		assert.False(t, vlt.IsEncrypted())
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		_, err := td.vault.Neuter().UpgradeEncryption(tPassword)
+		assert.ErrorIs(t, err, ErrNeutered)
 	})
 }
 
@@ -459,6 +1853,112 @@ func TestSetLabel(t *testing.T) {
 	})
 }
 
+func TestDefaultAddress(t *testing.T) {
+	td := setup(t)
+
+	addr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+
+	_, ok := td.vault.DefaultAddress()
+	assert.False(t, ok)
+
+	t.Run("Unknown address", func(t *testing.T) {
+		unknown := td.RandAccAddress().String()
+		err := td.vault.SetDefaultAddress(unknown)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(unknown))
+	})
+
+	t.Run("Set and clear", func(t *testing.T) {
+		events, unsubscribe := td.vault.Subscribe()
+		defer unsubscribe()
+
+		require.NoError(t, td.vault.SetDefaultAddress(addr))
+		defaultAddr, ok := td.vault.DefaultAddress()
+		assert.True(t, ok)
+		assert.Equal(t, addr, defaultAddr)
+		assert.Equal(t, VaultEvent{Kind: EventDefaultAddressChanged, Address: addr}, <-events)
+
+		// Setting the same address again doesn't emit any event.
+		require.NoError(t, td.vault.SetDefaultAddress(addr))
+		require.NoError(t, td.vault.SetDefaultAddress(""))
+		_, ok = td.vault.DefaultAddress()
+		assert.False(t, ok)
+		assert.Equal(t, VaultEvent{Kind: EventDefaultAddressChanged}, <-events)
+	})
+
+	t.Run("Survives serialization", func(t *testing.T) {
+		require.NoError(t, td.vault.SetDefaultAddress(addr))
+
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+		defaultAddr, _ := restored.DefaultAddress()
+		assert.Equal(t, addr, defaultAddr)
+
+		data, err = td.vault.MarshalBinary()
+		require.NoError(t, err)
+		restored = new(Vault)
+		require.NoError(t, restored.UnmarshalBinary(data))
+		defaultAddr, _ = restored.DefaultAddress()
+		assert.Equal(t, addr, defaultAddr)
+
+		defaultAddr, _ = td.vault.Neuter().DefaultAddress()
+		assert.Equal(t, addr, defaultAddr)
+	})
+
+	t.Run("Removing the default address clears it", func(t *testing.T) {
+		require.NoError(t, td.vault.SetDefaultAddress(addr))
+		require.NoError(t, td.vault.RemoveAddress(addr, tPassword, true))
+
+		_, ok := td.vault.DefaultAddress()
+		assert.False(t, ok)
+	})
+}
+
+func TestXPrvAccount(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Invalid password", func(t *testing.T) {
+		_, err := td.vault.XPrvAccount("wrong_password", PurposeBLS12381)
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		_, err := td.vault.Neuter().XPrvAccount(tPassword, PurposeBLS12381)
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+
+	t.Run("Unsupported purpose", func(t *testing.T) {
+		_, err := td.vault.XPrvAccount(tPassword, PurposeImportPrivateKey)
+		assert.ErrorIs(t, err, ErrUnsupportedPurpose)
+	})
+
+	t.Run("BLS purpose", func(t *testing.T) {
+		xPrv, err := td.vault.XPrvAccount(tPassword, PurposeBLS12381)
+		assert.NoError(t, err)
+
+		ext, err := hdkeychain.NewKeyFromString(xPrv)
+		require.NoError(t, err)
+		assert.True(t, ext.IsPrivate())
+		assert.Equal(t, td.vault.Purposes.PurposeBLS.XPubAccount, ext.Neuter().String())
+	})
+
+	t.Run("BIP44 purpose", func(t *testing.T) {
+		xPrv, err := td.vault.XPrvAccount(tPassword, PurposeBIP44)
+		assert.NoError(t, err)
+
+		ext, err := ed25519hdkeychain.NewKeyFromString(xPrv)
+		require.NoError(t, err)
+		ext, err = ext.Derive(_H(0))
+		require.NoError(t, err)
+
+		prv, err := ed25519.PrivateKeyFromBytes(ext.RawPrivateKey())
+		require.NoError(t, err)
+		info := td.vault.AddressFromPath("m/44'/21888'/3'/0'")
+		assert.Equal(t, info.Address, prv.PublicKeyNative().AccountAddress().String())
+	})
+}
+
 func TestNeuter(t *testing.T) {
 	td := setup(t)
 
@@ -478,3 +1978,281 @@ func TestNeuter(t *testing.T) {
 	err = td.vault.Neuter().UpdatePassword("any", "any")
 	assert.ErrorIs(t, err, ErrNeutered)
 }
+
+func TestNeuterInPlace(t *testing.T) {
+	td := setup(t)
+
+	original := td.vault.Clone()
+	require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+	require.NotEmpty(t, td.vault.KeyStore)
+	assert.True(t, td.vault.CanUnneuter())
+
+	td.vault.NeuterInPlace()
+
+	assert.True(t, td.vault.IsNeutered())
+	assert.False(t, td.vault.CanUnneuter())
+	assert.False(t, td.vault.IsEncrypted())
+	assert.False(t, td.vault.IsUnlocked())
+	assert.Empty(t, td.vault.KeyStore)
+	assert.Equal(t, original.Neuter().Addresses, td.vault.Addresses)
+	assert.Equal(t, original.Purposes, td.vault.Purposes)
+
+	_, err := td.vault.Mnemonic(tPassword)
+	assert.ErrorIs(t, err, ErrNeutered)
+
+	_, err = td.vault.Mnemonic("")
+	assert.ErrorIs(t, err, ErrNeutered)
+
+	addr := td.vault.AddressesByLabel("bls-account-address")[0].Address
+	_, err = td.vault.PrivateKeys(tPassword, []string{addr})
+	assert.ErrorIs(t, err, ErrNeutered)
+
+	_, prv := td.RandBLSKeyPair()
+	err = td.vault.ImportBLSPrivateKey(tPassword, prv)
+	assert.ErrorIs(t, err, ErrNeutered)
+
+	_, edPrv := td.RandEd25519KeyPair()
+	err = td.vault.ImportEd25519PrivateKey(tPassword, edPrv)
+	assert.ErrorIs(t, err, ErrNeutered)
+
+	// Public derivation still works.
+	_, err = td.vault.NewBLSAccountAddress("")
+	assert.NoError(t, err)
+}
+
+func TestMasterFingerprint(t *testing.T) {
+	td := setup(t)
+
+	fingerprint := td.vault.MasterFingerprint()
+	assert.NotZero(t, fingerprint)
+	assert.Equal(t, fmt.Sprintf("%08x", fingerprint), td.vault.Fingerprint)
+
+	t.Run("Neutered vault has the same fingerprint", func(t *testing.T) {
+		assert.Equal(t, fingerprint, td.vault.Neuter().MasterFingerprint())
+	})
+
+	t.Run("Same mnemonic, same fingerprint", func(t *testing.T) {
+		other, err := CreateVaultFromMnemonic(td.mnemonic, 21777)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, other.MasterFingerprint())
+	})
+
+	t.Run("Different mnemonic, different fingerprint", func(t *testing.T) {
+		mnemonic, _ := GenerateMnemonic(128)
+		other, err := CreateVaultFromMnemonic(mnemonic, 21888)
+		require.NoError(t, err)
+		assert.NotEqual(t, fingerprint, other.MasterFingerprint())
+	})
+
+	t.Run("Old vault without fingerprint", func(t *testing.T) {
+		td.vault.Fingerprint = ""
+		assert.Zero(t, td.vault.MasterFingerprint())
+	})
+}
+
+func TestAddressTimestamps(t *testing.T) {
+	td := setup(t)
+
+	before := time.Now().Add(-time.Second)
+	info, err := td.vault.NewBLSAccountAddress("new-address")
+	require.NoError(t, err)
+	assert.True(t, info.CreatedAt.After(before))
+	assert.True(t, info.LastUsedAt.IsZero())
+
+	t.Run("Touch unknown address", func(t *testing.T) {
+		invAddr := td.RandAccAddress().String()
+		err := td.vault.TouchAddress(invAddr)
+		assert.ErrorIs(t, err, NewErrAddressNotFound(invAddr))
+	})
+
+	t.Run("Touch address", func(t *testing.T) {
+		err := td.vault.TouchAddress(info.Address)
+		require.NoError(t, err)
+		assert.False(t, td.vault.AddressInfo(info.Address).LastUsedAt.Before(info.CreatedAt))
+	})
+
+	t.Run("Timestamps survive serialization", func(t *testing.T) {
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, td.vault.Addresses, restored.Addresses)
+	})
+
+	t.Run("Zero timestamps are omitted", func(t *testing.T) {
+		data, err := json.Marshal(AddressInfo{Address: info.Address})
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "created_at")
+		assert.NotContains(t, string(data), "last_used_at")
+	})
+}
+
+func TestSortByCreatedAt(t *testing.T) {
+	td := setup(t)
+
+	infos := td.vault.AddressInfos()
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, info := range infos {
+		info.CreatedAt = now.Add(-time.Duration(i) * time.Minute)
+		td.vault.Addresses[info.Address] = info
+	}
+
+	sorted := td.vault.SortByCreatedAt()
+	require.Len(t, sorted, len(infos))
+	for i := range sorted {
+		assert.Equal(t, infos[len(infos)-1-i].Address, sorted[i].Address)
+	}
+
+	t.Run("Old vault keeps the path order", func(t *testing.T) {
+		for _, info := range infos {
+			info.CreatedAt = time.Time{}
+			td.vault.Addresses[info.Address] = info
+		}
+
+		assert.Equal(t, td.vault.AddressInfos(), td.vault.SortByCreatedAt())
+	})
+}
+
+func TestClone(t *testing.T) {
+	td := setup(t)
+
+	cloned := td.vault.Clone()
+	assert.Equal(t, td.vault.AddressInfos(), cloned.AddressInfos())
+	assert.Equal(t, td.vault.Purposes, cloned.Purposes)
+	assert.Equal(t, td.vault.Encrypter, cloned.Encrypter)
+	assert.Equal(t, td.vault.KeyStore, cloned.KeyStore)
+
+	t.Run("Deriving on the clone doesn't affect the original", func(t *testing.T) {
+		_, err := cloned.NewValidatorAddress("cloned-validator")
+		require.NoError(t, err)
+
+		assert.Equal(t, 6, td.vault.AddressCount())
+		assert.Equal(t, 7, cloned.AddressCount())
+		assert.Equal(t, uint32(1), td.vault.Purposes.PurposeBLS.NextValidatorIndex)
+	})
+
+	t.Run("Updating the clone doesn't affect the original", func(t *testing.T) {
+		addr := td.vault.AddressesByLabel("validator-address")[0].Address
+		require.NoError(t, cloned.SetLabel(addr, "cloned-label"))
+		assert.Equal(t, "validator-address", td.vault.Label(addr))
+
+		require.NoError(t, cloned.UpdatePassword(tPassword, "new-password",
+			encrypter.OptionIteration(1),
+			encrypter.OptionMemory(16),
+			encrypter.OptionParallelism(1)))
+		assert.Equal(t, "8", td.vault.Encrypter.Params["memory"])
+		assert.NotEqual(t, td.vault.KeyStore, cloned.KeyStore)
+
+		_, err := td.vault.Mnemonic(tPassword)
+		require.NoError(t, err)
+	})
+
+	t.Run("Clone is locked", func(t *testing.T) {
+		require.NoError(t, td.vault.Unlock(tPassword, time.Minute))
+		assert.False(t, td.vault.Clone().IsUnlocked())
+	})
+}
+
+func TestEqual(t *testing.T) {
+	td := setup(t)
+
+	t.Run("Clone is equal", func(t *testing.T) {
+		assert.True(t, td.vault.Equal(td.vault.Clone()))
+	})
+
+	t.Run("JSON round trip is equal", func(t *testing.T) {
+		data, err := json.Marshal(td.vault)
+		require.NoError(t, err)
+
+		restored := new(Vault)
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.True(t, td.vault.Equal(restored))
+		assert.True(t, restored.Equal(td.vault))
+	})
+
+	t.Run("Timestamps are not compared", func(t *testing.T) {
+		cloned := td.vault.Clone()
+		addr := cloned.AddressInfos()[0].Address
+		require.NoError(t, cloned.TouchAddress(addr))
+		assert.True(t, td.vault.Equal(cloned))
+	})
+
+	t.Run("Key store is not compared", func(t *testing.T) {
+		cloned := td.vault.Clone()
+		require.NoError(t, cloned.UpdatePassword(tPassword, "new-password",
+			encrypter.OptionIteration(1),
+			encrypter.OptionMemory(8),
+			encrypter.OptionParallelism(1)))
+		assert.NotEqual(t, td.vault.KeyStore, cloned.KeyStore)
+		assert.True(t, td.vault.Equal(cloned))
+	})
+
+	t.Run("Different KDF parameters", func(t *testing.T) {
+		cloned := td.vault.Clone()
+		require.NoError(t, cloned.UpdatePassword(tPassword, tPassword,
+			encrypter.OptionIteration(1),
+			encrypter.OptionMemory(16),
+			encrypter.OptionParallelism(1)))
+		assert.False(t, td.vault.Equal(cloned))
+	})
+
+	t.Run("Different label", func(t *testing.T) {
+		cloned := td.vault.Clone()
+		addr := cloned.AddressInfos()[0].Address
+		require.NoError(t, cloned.SetLabel(addr, "another-label"))
+		assert.False(t, td.vault.Equal(cloned))
+	})
+
+	t.Run("Different addresses", func(t *testing.T) {
+		cloned := td.vault.Clone()
+		_, err := cloned.NewBLSAccountAddress("new-address")
+		require.NoError(t, err)
+		assert.False(t, td.vault.Equal(cloned))
+		assert.False(t, cloned.Equal(td.vault))
+	})
+
+	t.Run("Neutered vault is not equal", func(t *testing.T) {
+		assert.False(t, td.vault.Equal(td.vault.Neuter()))
+	})
+}
+
+func TestNetwork(t *testing.T) {
+	td := setup(t)
+
+	assert.False(t, td.vault.IsTestnet())
+	assert.Equal(t, genesis.Mainnet, td.vault.Network())
+
+	testnetVault, err := CreateVaultFromMnemonic(td.mnemonic, CoinTypeTestnet)
+	require.NoError(t, err)
+	assert.True(t, testnetVault.IsTestnet())
+	assert.Equal(t, genesis.Testnet, testnetVault.Network())
+
+	customVault, err := CreateVaultFromMnemonic(td.mnemonic, 12345)
+	require.NoError(t, err)
+	assert.False(t, customVault.IsTestnet())
+	assert.Equal(t, genesis.Localnet, customVault.Network())
+
+	info, err := customVault.NewBLSAccountAddress("custom")
+	require.NoError(t, err)
+	assert.Equal(t, "m/12381'/12345'/2'/0", info.Path)
+}
+
+func TestDeriveCoinTypeMismatch(t *testing.T) {
+	td := setup(t)
+
+	// The extended public keys are derived for the mainnet coin type.
+	td.vault.CoinType = CoinTypeTestnet
+	expectedErr := CoinTypeMismatchError{Expected: CoinTypeTestnet, Got: CoinTypeMainnet}
+
+	_, err := td.vault.NewValidatorAddress("validator")
+	assert.ErrorIs(t, err, expectedErr)
+
+	_, err = td.vault.NewBLSAccountAddress("bls-account")
+	assert.ErrorIs(t, err, expectedErr)
+
+	_, err = td.vault.DeriveAddressesRange(PurposeBLS12381, crypto.AddressTypeBLSAccount, 2, "bls-")
+	assert.ErrorIs(t, err, expectedErr)
+
+	assert.Equal(t, 6, td.vault.AddressCount())
+}
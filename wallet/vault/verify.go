@@ -0,0 +1,276 @@
+package vault
+
+import (
+	"fmt"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+	"golang.org/x/exp/slices"
+)
+
+// InconsistencyKind defines the kind of an inconsistency found by Verify.
+type InconsistencyKind int
+
+const (
+	InconsistencyAddressKey    = InconsistencyKind(1) // Address is stored under a different key
+	InconsistencyInvalidPath   = InconsistencyKind(2) // Path can't be parsed
+	InconsistencyDuplicatePath = InconsistencyKind(3) // Another address has the same path
+	InconsistencyPublicKey     = InconsistencyKind(4) // Public key doesn't match the address
+	InconsistencyDerivation    = InconsistencyKind(5) // Key at the path doesn't match the public key
+	InconsistencyMissingKey    = InconsistencyKind(6) // Imported key is missing in the key store
+)
+
+func (k InconsistencyKind) String() string {
+	switch k {
+	case InconsistencyAddressKey:
+		return "address-key"
+	case InconsistencyInvalidPath:
+		return "invalid-path"
+	case InconsistencyDuplicatePath:
+		return "duplicate-path"
+	case InconsistencyPublicKey:
+		return "public-key"
+	case InconsistencyDerivation:
+		return "derivation"
+	case InconsistencyMissingKey:
+		return "missing-key"
+	default:
+		return fmt.Sprintf("unknown inconsistency: %d", int(k))
+	}
+}
+
+// Inconsistency describes an address entry that doesn't match its own data.
+type Inconsistency struct {
+	Address string            // Key of the address entry in the vault
+	Path    string            // Path of the address entry
+	Kind    InconsistencyKind // Kind of the inconsistency
+	Reason  string            // Human-readable explanation
+}
+
+// Verify checks that each address is consistent with its path and public key.
+// HD addresses are derived again from their paths, and imported addresses are
+// checked against the keys in the key store.
+// The password is only needed for the private keys, so it is not used for neutered vaults.
+// It reports the inconsistencies without changing the vault.
+func (v *Vault) Verify(password string) ([]Inconsistency, error) {
+	var keyStore *keyStore
+	var seed *secureBytes
+	if !v.IsNeutered() {
+		var err error
+		keyStore, err = v.decryptKeyStore(password)
+		if err != nil {
+			return nil, err
+		}
+
+		seedBytes, err := keyStore.MasterNode.seed()
+		if err != nil {
+			return nil, err
+		}
+		seed = newSecureBytes(seedBytes)
+		defer seed.Close()
+	}
+
+	addrs := make([]string, 0, len(v.Addresses))
+	for addr := range v.Addresses {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+
+	issues := make([]Inconsistency, 0)
+	report := func(addr string, info AddressInfo, kind InconsistencyKind, reason string) {
+		issues = append(issues, Inconsistency{
+			Address: addr,
+			Path:    info.Path,
+			Kind:    kind,
+			Reason:  reason,
+		})
+	}
+
+	paths := make(map[string]string, len(addrs))
+	for _, addr := range addrs {
+		info := v.Addresses[addr]
+		if info.Address != addr {
+			report(addr, info, InconsistencyAddressKey,
+				fmt.Sprintf("stored under %s, but the address is %s", addr, info.Address))
+		}
+
+		addrPath, err := addresspath.FromString(info.Path)
+		if err != nil || len(addrPath) != 4 {
+			report(addr, info, InconsistencyInvalidPath, fmt.Sprintf("invalid path: %s", info.Path))
+
+			continue
+		}
+
+		if other, ok := paths[info.Path]; ok {
+			report(addr, info, InconsistencyDuplicatePath,
+				fmt.Sprintf("same path as %s", other))
+		}
+		paths[info.Path] = addr
+
+		addressType := crypto.AddressType(_N(addrPath.AddressType()))
+		pubAddr, err := addressFromPublicKey(info.PublicKey, addressType)
+		if err != nil {
+			report(addr, info, InconsistencyPublicKey, err.Error())
+		} else if pubAddr != info.Address {
+			report(addr, info, InconsistencyPublicKey,
+				fmt.Sprintf("public key belongs to %s", pubAddr))
+		}
+
+		if keyStore != nil && addrPath.Purpose() == _H(PurposeImportPrivateKey) &&
+			int(_N(addrPath.AddressIndex())) >= len(keyStore.ImportedKeys) {
+			report(addr, info, InconsistencyMissingKey,
+				fmt.Sprintf("no imported key at index %d", _N(addrPath.AddressIndex())))
+
+			continue
+		}
+
+		derivedPub, err := v.derivedPublicKey(keyStore, seed, info, addrPath)
+		switch {
+		case err != nil:
+			report(addr, info, InconsistencyDerivation, err.Error())
+		case derivedPub != "" && derivedPub != info.PublicKey:
+			report(addr, info, InconsistencyDerivation,
+				fmt.Sprintf("path derives to public key %s", derivedPub))
+		}
+	}
+
+	return issues, nil
+}
+
+// Repair recomputes the addresses from their paths and public keys, and stores
+// each address under its own key.
+// The public keys of the BLS HD addresses are derived again from their paths.
+// If two entries end up with the same address, one of them is kept.
+// It returns the number of repaired entries.
+func (v *Vault) Repair() (int, error) {
+	repaired := make(map[string]AddressInfo, len(v.Addresses))
+	count := 0
+	for addr, info := range v.Addresses {
+		addrPath, err := addresspath.FromString(info.Path)
+		if err != nil || len(addrPath) != 4 {
+			// Nothing to recompute from, it is reported by Verify.
+			repaired[addr] = info
+
+			continue
+		}
+
+		fixed, err := v.repairAddressInfo(info, addrPath)
+		if err != nil {
+			return 0, err
+		}
+
+		if fixed != info || fixed.Address != addr {
+			count++
+		}
+		repaired[fixed.Address] = fixed
+	}
+	v.Addresses = repaired
+
+	return count, nil
+}
+
+func (v *Vault) repairAddressInfo(info AddressInfo, addrPath addresspath.Path) (AddressInfo, error) {
+	addressType := crypto.AddressType(_N(addrPath.AddressType()))
+
+	if addrPath.Purpose() == _H(PurposeBLS12381) {
+		ext, _, err := v.blsExtendedKey(PurposeBLS12381, addressType)
+		if err != nil {
+			return AddressInfo{}, err
+		}
+
+		derived, err := deriveBLSAddressInfo(ext, addressType, addrPath.AddressIndex())
+		if err != nil {
+			return AddressInfo{}, err
+		}
+		info.Address = derived.Address
+		info.PublicKey = derived.PublicKey
+
+		return info, nil
+	}
+
+	addr, err := addressFromPublicKey(info.PublicKey, addressType)
+	if err != nil {
+		return AddressInfo{}, err
+	}
+	info.Address = addr
+
+	return info, nil
+}
+
+// derivedPublicKey derives the public key of the address from its path,
+// or from the key store for the imported keys.
+// It returns an empty string if the key can't be derived, like for watch-only
+// addresses or the private keys of a neutered vault.
+func (v *Vault) derivedPublicKey(keyStore *keyStore, seed *secureBytes,
+	info AddressInfo, addrPath addresspath.Path,
+) (string, error) {
+	if info.IsWatchOnly {
+		return "", nil
+	}
+
+	switch addrPath.Purpose() {
+	case _H(PurposeBLS12381):
+		addressType := crypto.AddressType(_N(addrPath.AddressType()))
+		ext, _, err := v.blsExtendedKey(PurposeBLS12381, addressType)
+		if err != nil {
+			return "", err
+		}
+
+		derived, err := deriveBLSAddressInfo(ext, addressType, addrPath.AddressIndex())
+		if err != nil {
+			return "", err
+		}
+
+		return derived.PublicKey, nil
+
+	case _H(PurposeBIP44), _H(PurposeImportPrivateKey):
+		if keyStore == nil {
+			return "", nil
+		}
+
+		prv, err := v.privateKey(keyStore, seed.Bytes(), info)
+		if err != nil {
+			return "", err
+		}
+		defer clearPrivateKey(prv)
+
+		return prv.PublicKey().String(), nil
+
+	default:
+		return "", nil
+	}
+}
+
+// addressFromPublicKey returns the address of the public key for the address type.
+func addressFromPublicKey(pubStr string, addressType crypto.AddressType) (string, error) {
+	switch addressType {
+	case crypto.AddressTypeValidator:
+		pub, err := bls.PublicKeyFromString(pubStr)
+		if err != nil {
+			return "", err
+		}
+
+		return pub.ValidatorAddress().String(), nil
+
+	case crypto.AddressTypeBLSAccount:
+		pub, err := bls.PublicKeyFromString(pubStr)
+		if err != nil {
+			return "", err
+		}
+
+		return pub.AccountAddress().String(), nil
+
+	case crypto.AddressTypeEd25519Account:
+		pub, err := ed25519.PublicKeyFromString(pubStr)
+		if err != nil {
+			return "", err
+		}
+
+		return pub.AccountAddress().String(), nil
+
+	default:
+		return "", ErrUnsupportedAddressType
+	}
+}
@@ -0,0 +1,179 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/pactus-project/pactus/wallet/encrypter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inconsistencyKinds(issues []Inconsistency, addr string) []InconsistencyKind {
+	kinds := make([]InconsistencyKind, 0)
+	for _, issue := range issues {
+		if issue.Address == addr {
+			kinds = append(kinds, issue.Kind)
+		}
+	}
+
+	return kinds
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("Consistent vault", func(t *testing.T) {
+		td := setup(t)
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		td := setup(t)
+
+		_, err := td.vault.Verify("invalid-password")
+		assert.ErrorIs(t, err, encrypter.ErrInvalidPassword)
+	})
+
+	t.Run("Address stored under another key", func(t *testing.T) {
+		td := setup(t)
+
+		info := td.vault.AddressesByLabel("bls-account-address")[0]
+		wrongAddr := td.RandAccAddress().String()
+		delete(td.vault.Addresses, info.Address)
+		td.vault.Addresses[wrongAddr] = info
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, []InconsistencyKind{InconsistencyAddressKey},
+			inconsistencyKinds(issues, wrongAddr))
+		assert.Len(t, issues, 1)
+	})
+
+	t.Run("Public key doesn't match the address", func(t *testing.T) {
+		td := setup(t)
+
+		info := td.vault.AddressesByLabel("validator-address")[0]
+		pub, _ := td.RandBLSKeyPair()
+		info.PublicKey = pub.String()
+		td.vault.Addresses[info.Address] = info
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, []InconsistencyKind{InconsistencyPublicKey, InconsistencyDerivation},
+			inconsistencyKinds(issues, info.Address))
+	})
+
+	t.Run("Imported key doesn't match the public key", func(t *testing.T) {
+		td := setup(t)
+
+		addr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+		info := td.vault.Addresses[addr]
+		pub, _ := td.RandEd25519KeyPair()
+		info.Address = pub.AccountAddress().String()
+		info.PublicKey = pub.String()
+		td.vault.Addresses[addr] = info
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, []InconsistencyKind{InconsistencyAddressKey, InconsistencyDerivation},
+			inconsistencyKinds(issues, addr))
+	})
+
+	t.Run("Missing imported key", func(t *testing.T) {
+		td := setup(t)
+
+		addr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+		info := td.vault.Addresses[addr]
+		info.Path = "m/65535'/21888'/3'/9'"
+		td.vault.Addresses[addr] = info
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, []InconsistencyKind{InconsistencyMissingKey},
+			inconsistencyKinds(issues, addr))
+	})
+
+	t.Run("Invalid and duplicated paths", func(t *testing.T) {
+		td := setup(t)
+
+		accInfo := td.vault.AddressesByLabel("bls-account-address")[0]
+		valInfo := td.vault.AddressesByLabel("validator-address")[0]
+		edInfo := td.vault.AddressesByLabel("ed25519-account-address")[0]
+
+		accInfo.Path = "m/invalid"
+		td.vault.Addresses[accInfo.Address] = accInfo
+
+		edInfo.Path = valInfo.Path
+		td.vault.Addresses[edInfo.Address] = edInfo
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Equal(t, []InconsistencyKind{InconsistencyInvalidPath},
+			inconsistencyKinds(issues, accInfo.Address))
+
+		// One of them is reported as duplicated, depending on the order of the addresses.
+		kinds := inconsistencyKinds(issues, edInfo.Address)
+		kinds = append(kinds, inconsistencyKinds(issues, valInfo.Address)...)
+		assert.Contains(t, kinds, InconsistencyDuplicatePath)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		td := setup(t)
+
+		neutered := td.vault.Neuter()
+		issues, err := neutered.Verify("")
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		info := neutered.AddressesByLabel("bls-account-address")[0]
+		pub, _ := td.RandBLSKeyPair()
+		info.PublicKey = pub.String()
+		neutered.Addresses[info.Address] = info
+
+		issues, err = neutered.Verify("")
+		require.NoError(t, err)
+		assert.Equal(t, []InconsistencyKind{InconsistencyPublicKey, InconsistencyDerivation},
+			inconsistencyKinds(issues, info.Address))
+	})
+}
+
+func TestRepair(t *testing.T) {
+	td := setup(t)
+
+	original := td.vault.Clone()
+
+	t.Run("Consistent vault", func(t *testing.T) {
+		count, err := td.vault.Repair()
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.True(t, original.Equal(td.vault))
+	})
+
+	t.Run("Repair addresses and public keys", func(t *testing.T) {
+		accInfo := td.vault.AddressesByLabel("bls-account-address")[0]
+		wrongAddr := td.RandAccAddress().String()
+		delete(td.vault.Addresses, accInfo.Address)
+		accInfo.Address = wrongAddr
+		td.vault.Addresses[wrongAddr] = accInfo
+
+		valInfo := td.vault.AddressesByLabel("validator-address")[0]
+		pub, _ := td.RandBLSKeyPair()
+		valInfo.PublicKey = pub.String()
+		td.vault.Addresses[valInfo.Address] = valInfo
+
+		edAddr := td.importedEd25519Prv.PublicKeyNative().AccountAddress().String()
+		edInfo := td.vault.Addresses[edAddr]
+		delete(td.vault.Addresses, edAddr)
+		td.vault.Addresses[wrongAddr+"-ed"] = edInfo
+
+		count, err := td.vault.Repair()
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.True(t, original.Equal(td.vault))
+
+		issues, err := td.vault.Verify(tPassword)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+}
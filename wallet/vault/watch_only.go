@@ -0,0 +1,140 @@
+package vault
+
+import (
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/wallet/addresspath"
+)
+
+// ImportWatchOnlyPublicKey adds the address of the given public key to the vault
+// as a watch-only address. It works for both full and neutered vaults.
+// For a BLS public key, both the account and the validator addresses are added,
+// and the account address is returned.
+func (v *Vault) ImportWatchOnlyPublicKey(pub crypto.PublicKey, label string) (*AddressInfo, error) {
+	label, err := validateLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	addressIndex := v.nextPurposeIndex(PurposeWatchOnly)
+	if err := v.keyFingerprints().add(pub, ""); err != nil {
+		return nil, err
+	}
+
+	switch pub := pub.(type) {
+	case *bls.PublicKey:
+		accAddr := pub.AccountAddress()
+		if v.Contains(accAddr.String()) {
+			return nil, ErrAddressExists
+		}
+
+		valAddr := pub.ValidatorAddress()
+		if v.Contains(valAddr.String()) {
+			return nil, ErrAddressExists
+		}
+
+		valInfo := v.watchOnlyAddressInfo(valAddr, pub, crypto.AddressTypeValidator, addressIndex, label)
+		accInfo := v.watchOnlyAddressInfo(accAddr, pub, crypto.AddressTypeBLSAccount, addressIndex, label)
+		v.Addresses[valInfo.Address] = valInfo
+		v.Addresses[accInfo.Address] = accInfo
+		v.notify(EventAddressAdded, valInfo.Address)
+		v.notify(EventAddressAdded, accInfo.Address)
+
+		return &accInfo, nil
+
+	case *ed25519.PublicKey:
+		accAddr := pub.AccountAddress()
+		if v.Contains(accAddr.String()) {
+			return nil, ErrAddressExists
+		}
+
+		accInfo := v.watchOnlyAddressInfo(accAddr, pub, crypto.AddressTypeEd25519Account, addressIndex, label)
+		v.Addresses[accInfo.Address] = accInfo
+		v.notify(EventAddressAdded, accInfo.Address)
+
+		return &accInfo, nil
+
+	default:
+		return nil, ErrInvalidKey
+	}
+}
+
+// ImportWatchOnlyXPub adds the address of the given BLS extended public key to
+// the vault as a watch-only address.
+// The extended key should belong to an address node, e.g. `m/12381'/21888'/2'/0`,
+// with the BLS purpose and the coin type of the vault, and a non-hardened index.
+// The address type is determined by the path of the extended key.
+func (v *Vault) ImportWatchOnlyXPub(xpub, label string) error {
+	ext, err := blshdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return err
+	}
+
+	if ext.IsPrivate() {
+		return ErrInvalidKey
+	}
+
+	label, err = validateLabel(label)
+	if err != nil {
+		return err
+	}
+
+	extPath := addresspath.NewPath(ext.Path()...)
+	if len(extPath) != 4 || extPath.AddressIndex() >= addresspath.HardenedKeyStart {
+		return ErrInvalidPath
+	}
+	if err := v.checkKeyPath(ext.Path(), PurposeBLS12381); err != nil {
+		return err
+	}
+
+	pub, err := bls.PublicKeyFromBytes(ext.RawPublicKey())
+	if err != nil {
+		return err
+	}
+
+	if err := v.keyFingerprints().add(pub, ""); err != nil {
+		return err
+	}
+
+	var addr crypto.Address
+	switch extPath.AddressType() {
+	case _H(crypto.AddressTypeValidator):
+		addr = pub.ValidatorAddress()
+	case _H(crypto.AddressTypeBLSAccount):
+		addr = pub.AccountAddress()
+	default:
+		return ErrUnsupportedAddressType
+	}
+
+	if v.Contains(addr.String()) {
+		return ErrAddressExists
+	}
+
+	info := v.watchOnlyAddressInfo(addr, pub, addr.Type(), v.nextPurposeIndex(PurposeWatchOnly), label)
+	v.Addresses[info.Address] = info
+	v.notify(EventAddressAdded, info.Address)
+
+	return nil
+}
+
+// watchOnlyAddressInfo returns the info of a watch-only address.
+// The label should be validated by the caller.
+func (v *Vault) watchOnlyAddressInfo(addr crypto.Address, pub crypto.PublicKey,
+	addressType crypto.AddressType, addressIndex uint32, label string,
+) AddressInfo {
+	return AddressInfo{
+		Address:   addr.String(),
+		PublicKey: pub.String(),
+		Label:     label,
+		Path: addresspath.NewPath(
+			_H(PurposeWatchOnly),
+			_H(v.CoinType),
+			_H(addressType),
+			_H(addressIndex)).String(),
+		IsWatchOnly: true,
+		Origin:      OriginWatchOnly,
+		CreatedAt:   timeNow(),
+	}
+}
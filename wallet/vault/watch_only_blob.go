@@ -0,0 +1,240 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/ed25519"
+	"github.com/pactus-project/pactus/util/bech32m"
+	"github.com/pactus-project/pactus/util/encoding"
+	"github.com/pactus-project/pactus/wallet/encrypter"
+)
+
+const (
+	// watchOnlyBlobVersion is the version of the watch-only blob format.
+	watchOnlyBlobVersion = uint8(1)
+
+	// maxWatchOnlyBlobIndex is the maximum next index of an address type in the
+	// watch-only blob. The addresses up to the next indexes are derived again on
+	// import, so a corrupted or crafted blob must not make it derive billions of keys.
+	maxWatchOnlyBlobIndex = 10_000
+)
+
+// ExportWatchOnly exports the public part of the vault as a compact binary blob,
+// small enough to be shown as a single QR code.
+// The blob contains the extended public keys and the next indexes of the BLS
+// purpose, and the public keys of the derived Ed25519 addresses.
+// Imported and watch-only addresses, and the labels, are not exported.
+//
+// The blob format is:
+//
+//	version (1 byte) | coin type (4 bytes) | fingerprint (4 bytes) |
+//	xpub validator (var bytes) | xpub account (var bytes) |
+//	next validator index (var int) | next account index (var int) |
+//	next Ed25519 index (var int) | Ed25519 key count (var int) |
+//	[index (var int) | public key (32 bytes)]... | CRC32 (4 bytes)
+func (v *Vault) ExportWatchOnly() ([]byte, error) {
+	_, _, xPubValidator, err := bech32m.DecodeToBase256WithTypeNoLimit(v.Purposes.PurposeBLS.XPubValidator)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, xPubAccount, err := bech32m.DecodeToBase256WithTypeNoLimit(v.Purposes.PurposeBLS.XPubAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := make([]byte, 4)
+	binary.BigEndian.PutUint32(fingerprint, v.MasterFingerprint())
+
+	ed25519Infos := v.FilterAddresses(FilterOptions{
+		Purposes: []uint32{PurposeBIP44},
+	})
+
+	w := new(bytes.Buffer)
+	err = encoding.WriteElements(w, watchOnlyBlobVersion, v.CoinType, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range [][]byte{xPubValidator, xPubAccount} {
+		if err := encoding.WriteVarBytes(w, data); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, val := range []uint32{
+		v.Purposes.PurposeBLS.NextValidatorIndex,
+		v.Purposes.PurposeBLS.NextAccountIndex,
+		v.Purposes.PurposeBIP44.NextEd25519Index,
+		uint32(len(ed25519Infos)),
+	} {
+		if err := encoding.WriteVarInt(w, uint64(val)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, info := range ed25519Infos {
+		path, err := v.parsePath(info.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		pub, err := ed25519.PublicKeyFromString(info.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := encoding.WriteVarInt(w, uint64(_N(path.AddressIndex()))); err != nil {
+			return nil, err
+		}
+
+		if err := encoding.WriteElement(w, pub.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	crc := crc32.ChecksumIEEE(w.Bytes())
+	if err := encoding.WriteElement(w, crc); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// ImportWatchOnlyBlob creates a neutered vault from the blob exported by ExportWatchOnly.
+// The addresses of the source vault, up to the next indexes, are derived again.
+// It returns ErrInvalidBlobChecksum if the blob is corrupted, e.g. by a scan error,
+// and ErrInvalidBlob if a next index is higher than 10,000.
+func ImportWatchOnlyBlob(data []byte) (*Vault, error) {
+	if len(data) < 4 {
+		return nil, ErrInvalidBlob
+	}
+
+	payload := data[:len(data)-4]
+	crc := binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc != crc32.ChecksumIEEE(payload) {
+		return nil, ErrInvalidBlobChecksum
+	}
+
+	vlt, err := decodeWatchOnlyBlob(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBlob, err)
+	}
+
+	return vlt, nil
+}
+
+func decodeWatchOnlyBlob(r io.Reader) (*Vault, error) {
+	version := uint8(0)
+	coinType := uint32(0)
+	fingerprint := make([]byte, 4)
+	if err := encoding.ReadElements(r, &version, &coinType, fingerprint); err != nil {
+		return nil, err
+	}
+
+	if version != watchOnlyBlobVersion {
+		return nil, ErrInvalidBlob
+	}
+
+	xPubs := make([]string, 2)
+	for i := range xPubs {
+		raw, err := encoding.ReadVarBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		xPubs[i], err = bech32m.EncodeFromBase256WithType(crypto.XPublicKeyHRP, crypto.SignatureTypeBLS, raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	counters := make([]uint32, 4)
+	for i := range counters {
+		val, err := encoding.ReadVarInt(r)
+		if err != nil {
+			return nil, err
+		}
+		if val > maxWatchOnlyBlobIndex {
+			return nil, fmt.Errorf("counter %d exceeds the maximum of %d", val, maxWatchOnlyBlobIndex)
+		}
+		counters[i] = uint32(val)
+	}
+	nextValidatorIndex, nextAccountIndex, nextEd25519Index, ed25519Count :=
+		counters[0], counters[1], counters[2], counters[3]
+	if ed25519Count > nextEd25519Index {
+		return nil, fmt.Errorf("the Ed25519 key count %d exceeds the next index %d", ed25519Count, nextEd25519Index)
+	}
+
+	vlt := &Vault{
+		Version:   CurrentVaultVersion,
+		Type:      TypeNeutered,
+		CoinType:  coinType,
+		Encrypter: encrypter.NopeEncrypter(),
+		Addresses: make(map[string]AddressInfo),
+		KeyStore:  "",
+		Purposes: purposes{
+			PurposeBLS: purposeBLS{
+				XPubValidator: xPubs[0],
+				XPubAccount:   xPubs[1],
+			},
+		},
+	}
+	if binary.BigEndian.Uint32(fingerprint) != 0 {
+		vlt.Fingerprint = hex.EncodeToString(fingerprint)
+	}
+
+	for i := uint32(0); i < nextValidatorIndex; i++ {
+		if _, err := vlt.NewValidatorAddress(""); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := uint32(0); i < nextAccountIndex; i++ {
+		if _, err := vlt.NewBLSAccountAddress(""); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := uint32(0); i < ed25519Count; i++ {
+		index, err := encoding.ReadVarInt(r)
+		if err != nil {
+			return nil, err
+		}
+		if index >= uint64(nextEd25519Index) {
+			return nil, fmt.Errorf("the Ed25519 index %d exceeds the next index %d", index, nextEd25519Index)
+		}
+
+		pubData := make([]byte, ed25519.PublicKeySize)
+		if err := encoding.ReadElement(r, pubData); err != nil {
+			return nil, err
+		}
+
+		pub, err := ed25519.PublicKeyFromBytes(pubData)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := vlt.addressPath(PurposeBIP44, crypto.AddressTypeEd25519Account, uint32(index))
+		if err != nil {
+			return nil, err
+		}
+
+		addr := pub.AccountAddress().String()
+		vlt.Addresses[addr] = AddressInfo{
+			Address:   addr,
+			PublicKey: pub.String(),
+			Path:      path.String(),
+			CreatedAt: timeNow(),
+		}
+	}
+	vlt.Purposes.PurposeBIP44.NextEd25519Index = nextEd25519Index
+
+	return vlt, nil
+}
@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/pactus-project/pactus/util/bech32m"
+	"github.com/pactus-project/pactus/util/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWatchOnly(t *testing.T) {
+	td := setup(t)
+
+	_, err := td.vault.NewValidatorAddress("validator-2")
+	require.NoError(t, err)
+	_, err = td.vault.NewEd25519AccountAddress("ed25519-2", tPassword)
+	require.NoError(t, err)
+
+	blob, err := td.vault.ExportWatchOnly()
+	require.NoError(t, err)
+
+	// A single QR code can hold 2953 bytes in binary mode.
+	assert.Less(t, len(blob), 1000)
+
+	vlt, err := ImportWatchOnlyBlob(blob)
+	require.NoError(t, err)
+
+	assert.True(t, vlt.IsNeutered())
+	assert.False(t, vlt.IsEncrypted())
+	assert.Equal(t, td.vault.CoinType, vlt.CoinType)
+	assert.Equal(t, td.vault.Purposes, vlt.Purposes)
+	assert.Equal(t, td.vault.MasterFingerprint(), vlt.MasterFingerprint())
+
+	// Only the HD addresses are exported.
+	hdInfos := td.vault.FilterAddresses(FilterOptions{
+		Purposes: []uint32{PurposeBLS12381, PurposeBIP44},
+	})
+	infos := vlt.AddressInfos()
+	require.Len(t, infos, len(hdInfos))
+	for i, info := range infos {
+		assert.Equal(t, hdInfos[i].Address, info.Address)
+		assert.Equal(t, hdInfos[i].PublicKey, info.PublicKey)
+		assert.Equal(t, hdInfos[i].Path, info.Path)
+	}
+
+	t.Run("Imported vault derives identical addresses", func(t *testing.T) {
+		info1, err := td.vault.NewBLSAccountAddress("")
+		require.NoError(t, err)
+		info2, err := vlt.NewBLSAccountAddress("")
+		require.NoError(t, err)
+		assert.Equal(t, info1.Address, info2.Address)
+
+		info1, err = td.vault.NewValidatorAddress("")
+		require.NoError(t, err)
+		info2, err = vlt.NewValidatorAddress("")
+		require.NoError(t, err)
+		assert.Equal(t, info1.Address, info2.Address)
+	})
+
+	t.Run("No secret is exported", func(t *testing.T) {
+		assert.Empty(t, vlt.KeyStore)
+		_, err := vlt.Mnemonic("")
+		assert.ErrorIs(t, err, ErrNeutered)
+	})
+}
+
+func TestImportWatchOnlyBlobInvalid(t *testing.T) {
+	td := setup(t)
+
+	blob, err := td.vault.ExportWatchOnly()
+	require.NoError(t, err)
+
+	t.Run("Scan error", func(t *testing.T) {
+		corrupted := make([]byte, len(blob))
+		copy(corrupted, blob)
+		corrupted[10] ^= 0x01
+
+		_, err := ImportWatchOnlyBlob(corrupted)
+		assert.ErrorIs(t, err, ErrInvalidBlobChecksum)
+	})
+
+	t.Run("Truncated blob", func(t *testing.T) {
+		_, err := ImportWatchOnlyBlob(blob[:len(blob)-1])
+		assert.ErrorIs(t, err, ErrInvalidBlobChecksum)
+
+		_, err = ImportWatchOnlyBlob([]byte{1, 2})
+		assert.ErrorIs(t, err, ErrInvalidBlob)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		payload := make([]byte, len(blob)-4)
+		copy(payload, blob)
+		payload[0] = 2
+
+		crc := make([]byte, 4)
+		binary.LittleEndian.PutUint32(crc, crc32.ChecksumIEEE(payload))
+
+		_, err := ImportWatchOnlyBlob(append(payload, crc...))
+		assert.ErrorIs(t, err, ErrInvalidBlob)
+	})
+	t.Run("Too many addresses", func(t *testing.T) {
+		_, err := ImportWatchOnlyBlob(makeWatchOnlyBlob(t, td.vault,
+			[]uint64{maxWatchOnlyBlobIndex + 1, 0, 0, 0}, nil))
+		assert.ErrorIs(t, err, ErrInvalidBlob)
+
+		_, err = ImportWatchOnlyBlob(makeWatchOnlyBlob(t, td.vault,
+			[]uint64{0, 1 << 32, 0, 0}, nil))
+		assert.ErrorIs(t, err, ErrInvalidBlob)
+	})
+
+	t.Run("Too many Ed25519 keys", func(t *testing.T) {
+		_, err := ImportWatchOnlyBlob(makeWatchOnlyBlob(t, td.vault,
+			[]uint64{0, 0, 1, maxWatchOnlyBlobIndex}, nil))
+		assert.ErrorIs(t, err, ErrInvalidBlob)
+	})
+
+	t.Run("Ed25519 index out of range", func(t *testing.T) {
+		pub, _ := td.RandEd25519KeyPair()
+		entry := append([]byte{5}, pub.Bytes()...)
+
+		_, err := ImportWatchOnlyBlob(makeWatchOnlyBlob(t, td.vault,
+			[]uint64{0, 0, 6, 1}, entry))
+		assert.NoError(t, err)
+
+		_, err = ImportWatchOnlyBlob(makeWatchOnlyBlob(t, td.vault,
+			[]uint64{0, 0, 5, 1}, entry))
+		assert.ErrorIs(t, err, ErrInvalidBlob)
+	})
+}
+
+// makeWatchOnlyBlob encodes a watch-only blob with the extended public keys of
+// the vault and the given counters, followed by the given Ed25519 entries.
+func makeWatchOnlyBlob(t *testing.T, vlt *Vault, counters []uint64, entries []byte) []byte {
+	t.Helper()
+
+	w := new(bytes.Buffer)
+	require.NoError(t, encoding.WriteElements(w, watchOnlyBlobVersion, vlt.CoinType, make([]byte, 4)))
+	for _, xPub := range []string{vlt.Purposes.PurposeBLS.XPubValidator, vlt.Purposes.PurposeBLS.XPubAccount} {
+		_, _, data, err := bech32m.DecodeToBase256WithTypeNoLimit(xPub)
+		require.NoError(t, err)
+		require.NoError(t, encoding.WriteVarBytes(w, data))
+	}
+	for _, val := range counters {
+		require.NoError(t, encoding.WriteVarInt(w, val))
+	}
+	w.Write(entries)
+
+	crc := crc32.ChecksumIEEE(w.Bytes())
+	require.NoError(t, encoding.WriteElement(w, crc))
+
+	return w.Bytes()
+}
@@ -0,0 +1,200 @@
+package vault
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pactus-project/pactus/crypto"
+	"github.com/pactus-project/pactus/crypto/bls"
+	blshdkeychain "github.com/pactus-project/pactus/crypto/bls/hdkeychain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportWatchOnlyPublicKey(t *testing.T) {
+	td := setup(t)
+
+	t.Run("BLS public key", func(t *testing.T) {
+		pub, _ := td.RandBLSKeyPair()
+		info, err := td.vault.ImportWatchOnlyPublicKey(pub, "cold-storage")
+		assert.NoError(t, err)
+
+		assert.Equal(t, pub.AccountAddress().String(), info.Address)
+		assert.Equal(t, "m/65534'/21888'/2'/0'", info.Path)
+		assert.True(t, info.IsWatchOnly)
+		assert.Equal(t, OriginWatchOnly, info.Origin)
+		assert.False(t, td.vault.IsImported(info.Address))
+
+		valInfo := td.vault.AddressInfo(pub.ValidatorAddress().String())
+		assert.Equal(t, "m/65534'/21888'/1'/0'", valInfo.Path)
+		assert.True(t, valInfo.IsWatchOnly)
+		assert.Equal(t, 8, td.vault.AddressCount())
+
+		_, err = td.vault.ImportWatchOnlyPublicKey(pub, "cold-storage")
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+
+	t.Run("Ed25519 public key", func(t *testing.T) {
+		pub, _ := td.RandEd25519KeyPair()
+		info, err := td.vault.ImportWatchOnlyPublicKey(pub, "cold-storage")
+		assert.NoError(t, err)
+
+		assert.Equal(t, pub.AccountAddress().String(), info.Address)
+		assert.Equal(t, "m/65534'/21888'/3'/1'", info.Path)
+		assert.True(t, info.IsWatchOnly)
+	})
+
+	t.Run("Existing address", func(t *testing.T) {
+		_, err := td.vault.ImportWatchOnlyPublicKey(td.importedBLSPrv.PublicKeyNative(), "")
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+
+	t.Run("Neutered vault", func(t *testing.T) {
+		neutered := td.vault.Neuter()
+		pub, _ := td.RandEd25519KeyPair()
+		info, err := neutered.ImportWatchOnlyPublicKey(pub, "")
+		assert.NoError(t, err)
+
+		_, err = neutered.PrivateKeys(tPassword, []string{info.Address})
+		assert.ErrorIs(t, err, ErrWatchOnly)
+	})
+
+	t.Run("No private key", func(t *testing.T) {
+		pub, _ := td.RandEd25519KeyPair()
+		info, err := td.vault.ImportWatchOnlyPublicKey(pub, "")
+		assert.NoError(t, err)
+
+		_, err = td.vault.PrivateKeys(tPassword, []string{info.Address})
+		assert.ErrorIs(t, err, ErrWatchOnly)
+	})
+}
+
+func TestImportWatchOnlyXPub(t *testing.T) {
+	td := setup(t)
+
+	mnemonic, _ := GenerateMnemonic(128)
+	coldVault, err := CreateVaultFromMnemonic(mnemonic, 21888)
+	require.NoError(t, err)
+	seed, _ := coldVault.MnemonicSeed("")
+	masterKey, _ := blshdkeychain.NewMaster(seed, false)
+
+	t.Run("Validator address", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeValidator), 0,
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "cold-validator")
+		assert.NoError(t, err)
+
+		expected, _ := coldVault.NewValidatorAddress("")
+		info := td.vault.AddressInfo(expected.Address)
+		require.NotNil(t, info)
+		assert.Equal(t, expected.PublicKey, info.PublicKey)
+		assert.True(t, info.IsWatchOnly)
+		assert.Equal(t, "cold-validator", info.Label)
+
+		err = td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "cold-validator")
+		assert.ErrorIs(t, err, ErrAddressExists)
+	})
+
+	t.Run("Account address", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount), 0,
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "cold-account")
+		assert.NoError(t, err)
+
+		expected, _ := coldVault.NewBLSAccountAddress("")
+		assert.True(t, td.vault.Contains(expected.Address))
+	})
+
+	t.Run("Private extended key", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount), 1,
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.String(), "")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("Invalid extended key", func(t *testing.T) {
+		err := td.vault.ImportWatchOnlyXPub("invalid-xpub", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid path", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{_H(PurposeBLS12381)})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+	})
+
+	t.Run("Label is too long", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount), 2,
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), strings.Repeat("a", MaxLabelLength+1))
+		assert.ErrorIs(t, err, ErrLabelTooLong)
+	})
+
+	t.Run("Account-level extended key", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount),
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+	})
+
+	t.Run("Hardened address index", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount), _H(0),
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+	})
+
+	t.Run("Other purpose", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBIP44), _H(21888), _H(crypto.AddressTypeBLSAccount), 0,
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "")
+		assert.ErrorIs(t, err, ErrInvalidPath)
+	})
+
+	t.Run("Other coin type", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21777), _H(crypto.AddressTypeBLSAccount), 0,
+		})
+		err := td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "")
+		assert.ErrorIs(t, err, CoinTypeMismatchError{Expected: 21888, Got: 21777})
+	})
+
+	t.Run("Key imported by another route", func(t *testing.T) {
+		ext, _ := masterKey.DerivePath([]uint32{
+			_H(PurposeBLS12381), _H(21888), _H(crypto.AddressTypeBLSAccount), 5,
+		})
+		pub, err := bls.PublicKeyFromBytes(ext.RawPublicKey())
+		require.NoError(t, err)
+
+		accInfo, err := td.vault.ImportWatchOnlyPublicKey(pub, "")
+		require.NoError(t, err)
+		valAddr := pub.ValidatorAddress().String()
+
+		// The key is still in the vault by its validator address.
+		require.NoError(t, td.vault.RemoveAddress(accInfo.Address, "", false))
+
+		err = td.vault.ImportWatchOnlyXPub(ext.Neuter().String(), "")
+		assert.ErrorIs(t, err, AddressExistsError{Address: valAddr})
+		assert.False(t, td.vault.Contains(accInfo.Address))
+	})
+}
+
+func TestWatchOnlySorting(t *testing.T) {
+	td := setup(t)
+
+	pub, _ := td.RandBLSKeyPair()
+	_, err := td.vault.ImportWatchOnlyPublicKey(pub, "")
+	assert.NoError(t, err)
+
+	infos := td.vault.AddressInfos()
+	assert.Len(t, infos, 8)
+	assert.Equal(t, "m/65534'/21888'/1'/0'", infos[3].Path)
+	assert.Equal(t, "m/65534'/21888'/2'/0'", infos[4].Path)
+}
@@ -64,6 +64,14 @@ func Open(walletPath string, offline bool, options ...Option) (*Wallet, error) {
 		return nil, err
 	}
 
+	// The CRC is checked before upgrading, since the upgrade saves the wallet
+	// again with a new CRC. The wallet files of version 1 and 2 are not checked.
+	if store.Version == VersionLatest {
+		if err := store.ValidateCRC(); err != nil {
+			return nil, err
+		}
+	}
+
 	err = store.UpgradeWallet(walletPath)
 	if err != nil {
 		return nil, err
@@ -74,10 +82,6 @@ func Open(walletPath string, offline bool, options ...Option) (*Wallet, error) {
 		opt(opts)
 	}
 
-	if err := store.ValidateCRC(); err != nil {
-		return nil, err
-	}
-
 	return newWallet(walletPath, store, offline, opts)
 }
 
@@ -102,9 +106,9 @@ func Create(walletPath, mnemonic, password string, chain genesis.ChainType,
 	var coinType uint32
 	switch chain {
 	case genesis.Mainnet:
-		coinType = 21888
+		coinType = vault.CoinTypeMainnet
 	case genesis.Testnet, genesis.Localnet:
-		coinType = 21777
+		coinType = vault.CoinTypeTestnet
 	default:
 		return nil, ErrInvalidNetwork
 	}
@@ -519,12 +523,12 @@ func (w *Wallet) History(addr string) []HistoryInfo {
 }
 
 func (w *Wallet) SignMessage(password, addr, msg string) (string, error) {
-	prv, err := w.PrivateKey(password, addr)
+	sig, err := w.store.Vault.SignMessage(password, addr, []byte(msg))
 	if err != nil {
 		return "", err
 	}
 
-	return prv.Sign([]byte(msg)).String(), nil
+	return sig.String(), nil
 }
 
 func (w *Wallet) Version() int {
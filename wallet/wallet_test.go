@@ -1,6 +1,7 @@
 package wallet_test
 
 import (
+	"bytes"
 	"context"
 	"path"
 	"testing"
@@ -107,6 +108,29 @@ func TestOpenWallet(t *testing.T) {
 		_, err := wallet.Open(td.wallet.Path(), true)
 		assert.Error(t, err)
 	})
+
+	t.Run("Tampered wallet of version 3", func(t *testing.T) {
+		// The vault of this wallet is migrated on decoding, but the CRC should be
+		// checked before the wallet is saved again.
+		data, err := util.ReadFile("./testdata/wallet_version_3")
+		require.NoError(t, err)
+		tampered := bytes.Replace(data,
+			[]byte(`"label": "Validator Address"`), []byte(`"label": "Tampered Address"`), 1)
+		require.NotEqual(t, data, tampered)
+
+		tempPath := util.TempFilePath()
+		require.NoError(t, util.WriteFile(tempPath, tampered))
+
+		_, err = wallet.Open(tempPath, true)
+		assert.ErrorIs(t, err, wallet.CRCNotMatchError{
+			Expected: 3569464319,
+			Got:      1964534705,
+		})
+
+		saved, err := util.ReadFile(tempPath)
+		require.NoError(t, err)
+		assert.Equal(t, tampered, saved, "the tampered wallet should not be saved again")
+	})
 }
 
 func TestRecoverWallet(t *testing.T) {